@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/config"
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+	"github.com/yourusername/esports-scouting-backend/internal/services"
+)
+
+func syncSeries(cfg *config.Config, repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("sync-series", flag.ExitOnError)
+	title := fs.String("title", "", "valorant or lol (required)")
+	tournaments := fs.String("tournaments", "", "comma-separated tournament ids (default: the title's configured tournaments)")
+	refresh := fs.Bool("refresh", false, "re-download series that are already stored")
+	fs.Parse(args)
+
+	if *title == "" {
+		return fmt.Errorf("-title is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	patches, err := patch.Load(cfg.PatchCalendar)
+	if err != nil {
+		return err
+	}
+
+	sync := services.NewSeriesSyncService(grid.NewClient(cfg.GridAPIKey), repo, patches)
+	result, err := sync.SyncTitle(ctx, *title, splitList(*tournaments), *refresh)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Listed %d series: %d saved, %d already stored, %d unfinished or unavailable\n",
+		result.Listed, result.Saved, result.Skipped, result.Failed)
+
+	// Keep ratings in step with the stored history
+	ratings, err := services.NewRatingService(repo).Recompute(*title)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Recomputed ratings for %d teams\n", len(ratings))
+	return nil
+}
+
+func syncEvents(cfg *config.Config, repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("sync-events", flag.ExitOnError)
+	title := fs.String("title", "", "valorant or lol (required)")
+	series := fs.String("series", "", "comma-separated series ids (default: every stored series of the title)")
+	refresh := fs.Bool("refresh", false, "re-download events that are already stored")
+	fs.Parse(args)
+
+	if *title == "" {
+		return fmt.Errorf("-title is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+	defer cancel()
+
+	ingest := services.NewEventIngestService(grid.NewFileDownloader(cfg.GridAPIKey), repo)
+	result, err := ingest.IngestTitle(ctx, *title, splitList(*series), *refresh)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Checked %d series: %d saved, %d already stored, %d without an events file\n",
+		result.Listed, result.Saved, result.Skipped, result.Failed)
+	return nil
+}
+
+func tagPatches(cfg *config.Config, repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("tag-patches", flag.ExitOnError)
+	title := fs.String("title", "", "valorant or lol (required)")
+	fs.Parse(args)
+
+	if *title == "" {
+		return fmt.Errorf("-title is required")
+	}
+	if cfg.PatchCalendar == "" {
+		return fmt.Errorf("PATCH_CALENDAR is not set")
+	}
+
+	patches, err := patch.Load(cfg.PatchCalendar)
+	if err != nil {
+		return err
+	}
+
+	counts, err := services.NewSeriesSyncService(nil, repo, patches).RetagPatches(*title)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATCH\tSTART\tSERIES")
+	for _, p := range patches.Patches(*title) {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", p.Version, p.Start.Format("2006-01-02"), counts[p.Version])
+	}
+	if counts[""] > 0 {
+		fmt.Fprintf(w, "(before first patch)\t\t%d\n", counts[""])
+	}
+	return w.Flush()
+}
+
+func recomputeRatings(repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("ratings", flag.ExitOnError)
+	title := fs.String("title", "", "valorant or lol (required)")
+	top := fs.Int("top", 20, "number of teams to print")
+	fs.Parse(args)
+
+	if *title == "" {
+		return fmt.Errorf("-title is required")
+	}
+
+	ratings, err := services.NewRatingService(repo).Recompute(*title)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RANK\tTEAM\tRATING\tRD\tSERIES\tW-L\tLAST PLAYED")
+	for i, r := range ratings {
+		if i >= *top {
+			break
+		}
+		name := r.TeamName
+		if r.Provisional {
+			name += " (provisional)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%.0f\t%.0f\t%d\t%d-%d\t%s\n",
+			r.Rank, name, r.Rating, r.RD, r.SeriesPlayed, r.Wins, r.Losses, r.LastPlayed.Format("2006-01-02"))
+	}
+	return w.Flush()
+}
+
+func backtest(repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	title := fs.String("title", "", "valorant or lol (required)")
+	tournaments := fs.String("tournaments", "", "comma-separated tournament ids to score (default: all stored)")
+	fs.Parse(args)
+
+	if *title == "" {
+		return fmt.Errorf("-title is required")
+	}
+
+	canonical := models.CanonicalTitle(*title)
+	series, err := repo.ListSeries(canonical)
+	if err != nil {
+		return err
+	}
+	if len(series) == 0 {
+		return fmt.Errorf("no stored %s series - run sync-series first", canonical)
+	}
+
+	report := services.Backtest(canonical, series, splitList(*tournaments))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOURNAMENT\tSTART\tSERIES\tBRIER\tLOG LOSS\tACCURACY\tCALIBRATED")
+	for _, t := range report.Tournaments {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.4f\t%.4f\t%.1f%%\t%v\n",
+			t.TournamentID, t.Start.Format("2006-01-02"), t.Series, t.Brier, t.LogLoss, t.Accuracy*100, t.Calibrated)
+	}
+	fmt.Fprintf(w, "ALL\t\t%d\t%.4f\t%.4f\t%.1f%%\t\n",
+		report.Overall.Series, report.Overall.Brier, report.Overall.LogLoss, report.Overall.Accuracy*100)
+	w.Flush()
+
+	fmt.Println("\nBrier 0.2500 = always predicting 50%. Lower is better.")
+	fmt.Println("\nCalibration (predicted vs observed team 1 win rate):")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BUCKET\tSERIES\tPREDICTED\tOBSERVED")
+	for _, bin := range report.Calibration {
+		fmt.Fprintf(w, "%.0f-%.0f%%\t%d\t%.1f%%\t%.1f%%\n",
+			bin.Lower*100, bin.Upper*100, bin.Count, bin.MeanPredicted*100, bin.ObservedRate*100)
+	}
+	return w.Flush()
+}
+
+// splitList parses a comma-separated flag value, dropping blanks
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
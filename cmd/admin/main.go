@@ -0,0 +1,273 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/esports-scouting-backend/internal/auth"
+	"github.com/yourusername/esports-scouting-backend/internal/config"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+const usage = `Usage: admin <command> [flags]
+
+Commands:
+  create-key   Issue a new API key (printed once)
+  list-keys    List all API keys
+  revoke-key   Revoke an API key by id
+  usage        Show per-key request counts
+  create-org   Create an organisation
+  list-orgs    List organisations
+  create-user  Add a user to an organisation
+  sync-series  Store finished series from Grid for ratings and predictions
+  backtest     Score the prediction model on past tournaments
+  ratings      Recompute Glicko-2 team ratings from stored series
+  tag-patches  Re-tag stored series with their patch from PATCH_CALENDAR
+  sync-events  Store kill, round and objective events of stored series
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	pgRepo, err := repository.NewPostgresRepo(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to Postgres: %v", err)
+	}
+	if err := pgRepo.RunMigrations(); err != nil {
+		log.Fatalf("Failed to create tables: %v", err)
+	}
+
+	cmd, args := os.Args[1], os.Args[2:]
+	switch cmd {
+	case "create-key":
+		err = createKey(pgRepo, args)
+	case "list-keys":
+		err = listKeys(pgRepo)
+	case "revoke-key":
+		err = revokeKey(pgRepo, args)
+	case "usage":
+		err = showUsage(pgRepo, args)
+	case "create-org":
+		err = createOrg(pgRepo, args)
+	case "list-orgs":
+		err = listOrgs(pgRepo)
+	case "create-user":
+		err = createUser(pgRepo, args)
+	case "sync-series":
+		err = syncSeries(cfg, pgRepo, args)
+	case "backtest":
+		err = backtest(pgRepo, args)
+	case "ratings":
+		err = recomputeRatings(pgRepo, args)
+	case "tag-patches":
+		err = tagPatches(cfg, pgRepo, args)
+	case "sync-events":
+		err = syncEvents(cfg, pgRepo, args)
+	default:
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("%s failed: %v", cmd, err)
+	}
+}
+
+func createKey(repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("create-key", flag.ExitOnError)
+	name := fs.String("name", "", "owner of the key, e.g. partner team name (required)")
+	rps := fs.Float64("rps", 10, "sustained requests per second")
+	burst := fs.Int("burst", 20, "burst size")
+	quota := fs.Int("quota", 5000, "requests per day (0 = unlimited)")
+	orgID := fs.String("org", "", "bind the key to an organisation id (grants analyst access to its data)")
+	fs.Parse(args)
+
+	if *name == "" {
+		return fmt.Errorf("-name is required")
+	}
+
+	rawKey, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return err
+	}
+
+	key := &models.APIKey{
+		ID:         uuid.New().String(),
+		Name:       *name,
+		OrgID:      *orgID,
+		Prefix:     prefix,
+		RateLimit:  *rps,
+		Burst:      *burst,
+		DailyQuota: *quota,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := repo.CreateAPIKey(key, auth.HashAPIKey(rawKey)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created API key for %s (id %s)\n", key.Name, key.ID)
+	fmt.Printf("Limits: %.1f req/s, burst %d, %d req/day\n\n", key.RateLimit, key.Burst, key.DailyQuota)
+	fmt.Printf("  %s\n\n", rawKey)
+	fmt.Println("Store it now - the key cannot be shown again.")
+	return nil
+}
+
+func listKeys(repo *repository.PostgresRepo) error {
+	keys, err := repo.ListAPIKeys()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tRPS\tBURST\tQUOTA\tCREATED\tSTATUS")
+	for _, k := range keys {
+		status := "active"
+		if k.RevokedAt != nil {
+			status = "revoked " + k.RevokedAt.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.1f\t%d\t%d\t%s\t%s\n",
+			k.ID, k.Name, k.Prefix, k.RateLimit, k.Burst, k.DailyQuota, k.CreatedAt.Format("2006-01-02"), status)
+	}
+	return w.Flush()
+}
+
+func revokeKey(repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("revoke-key", flag.ExitOnError)
+	id := fs.String("id", "", "key id (required)")
+	fs.Parse(args)
+
+	if *id == "" {
+		return fmt.Errorf("-id is required")
+	}
+	if err := repo.RevokeAPIKey(*id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Revoked API key %s\n", *id)
+	return nil
+}
+
+func showUsage(repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("usage", flag.ExitOnError)
+	days := fs.Int("days", 7, "number of days to include")
+	fs.Parse(args)
+
+	since := time.Now().UTC().AddDate(0, 0, -(*days - 1))
+	usage, err := repo.GetAPIKeyUsage("", since)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tKEY\tENDPOINT\tREQUESTS\tREJECTED")
+	for _, u := range usage {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\n", u.Day.Format("2006-01-02"), u.KeyName, u.Endpoint, u.Requests, u.Rejected)
+	}
+	return w.Flush()
+}
+
+func createOrg(repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("create-org", flag.ExitOnError)
+	name := fs.String("name", "", "organisation name (required)")
+	fs.Parse(args)
+
+	if *name == "" {
+		return fmt.Errorf("-name is required")
+	}
+
+	org := &models.Organization{
+		ID:        uuid.New().String(),
+		Name:      *name,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := repo.CreateOrganization(org); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created organisation %s (id %s)\n", org.Name, org.ID)
+	return nil
+}
+
+func listOrgs(repo *repository.PostgresRepo) error {
+	orgs, err := repo.ListOrganizations()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCREATED")
+	for _, o := range orgs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", o.ID, o.Name, o.CreatedAt.Format("2006-01-02"))
+	}
+	return w.Flush()
+}
+
+func createUser(repo *repository.PostgresRepo, args []string) error {
+	fs := flag.NewFlagSet("create-user", flag.ExitOnError)
+	orgID := fs.String("org", "", "organisation id (required)")
+	email := fs.String("email", "", "login email (required)")
+	name := fs.String("name", "", "display name (required)")
+	role := fs.String("role", string(models.RoleAnalyst), "admin | coach | analyst | player")
+	password := fs.String("password", "", "initial password (generated if empty)")
+	fs.Parse(args)
+
+	if *orgID == "" || *email == "" || *name == "" {
+		return fmt.Errorf("-org, -email and -name are required")
+	}
+	if !models.ValidRole(models.Role(*role)) {
+		return fmt.Errorf("invalid role %q", *role)
+	}
+	if _, err := repo.GetOrganization(*orgID); err != nil {
+		return err
+	}
+
+	generated := *password == ""
+	if generated {
+		buf := make([]byte, 12)
+		if _, err := rand.Read(buf); err != nil {
+			return err
+		}
+		*password = base64.RawURLEncoding.EncodeToString(buf)
+	}
+
+	hash, err := auth.HashPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	user := &models.User{
+		ID:           uuid.New().String(),
+		OrgID:        *orgID,
+		Email:        strings.ToLower(*email),
+		Name:         *name,
+		Role:         models.Role(*role),
+		PasswordHash: hash,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := repo.CreateUser(user); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s %s (id %s)\n", user.Role, user.Email, user.ID)
+	if generated {
+		fmt.Printf("Initial password: %s\n", *password)
+	}
+	return nil
+}
@@ -2,56 +2,66 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/auth"
 	"github.com/yourusername/esports-scouting-backend/internal/config"
 	"github.com/yourusername/esports-scouting-backend/internal/grid"
 	"github.com/yourusername/esports-scouting-backend/internal/handlers"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
 	"github.com/yourusername/esports-scouting-backend/internal/repository"
 	"github.com/yourusername/esports-scouting-backend/pkg/cache"
 	"golang.org/x/time/rate"
 )
 
 // RATE LIMITER
-type IPRateLimiter struct {
-	ips map[string]*rate.Limiter
-	mu  *sync.RWMutex
-	r   rate.Limit
-	b   int
+type RateLimiter struct {
+	limiters map[string]*rate.Limiter
+	mu       *sync.Mutex
 }
 
-func NewIPRateLimiter(r rate.Limit, b int) *IPRateLimiter {
-	return &IPRateLimiter{
-		ips: make(map[string]*rate.Limiter),
-		mu:  &sync.RWMutex{},
-		r:   r,
-		b:   b,
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		limiters: make(map[string]*rate.Limiter),
+		mu:       &sync.Mutex{},
 	}
 }
 
-func (i *IPRateLimiter) GetLimiter(ip string) *rate.Limiter {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// GetLimiter returns the limiter for a client key, creating it on first use.
+// Limits are refreshed on every call so changes to an API key apply without a restart.
+func (l *RateLimiter) GetLimiter(key string, r rate.Limit, b int) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	limiter, exists := i.ips[ip]
+	limiter, exists := l.limiters[key]
 	if !exists {
-		limiter = rate.NewLimiter(i.r, i.b)
-		i.ips[ip] = limiter
+		limiter = rate.NewLimiter(r, b)
+		l.limiters[key] = limiter
+		return limiter
+	}
+	if limiter.Limit() != r {
+		limiter.SetLimit(r)
+	}
+	if limiter.Burst() != b {
+		limiter.SetBurst(b)
 	}
 	return limiter
 }
 
-func rateLimitMiddleware(limiter *IPRateLimiter) gin.HandlerFunc {
+// rateLimitMiddleware limits unauthenticated routes by client IP
+func rateLimitMiddleware(limiter *RateLimiter, r rate.Limit, b int) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		l := limiter.GetLimiter(ip)
+		l := limiter.GetLimiter("ip:"+c.ClientIP(), r, b)
 
 		if !l.Allow() {
 			c.JSON(http.StatusTooManyRequests, gin.H{
@@ -65,6 +75,149 @@ func rateLimitMiddleware(limiter *IPRateLimiter) gin.HandlerFunc {
 	}
 }
 
+// API KEY AUTHENTICATION
+// apiKeyStore is the part of the repository the API key middleware needs
+type apiKeyStore interface {
+	GetAPIKeyByHash(keyHash string) (*models.APIKey, error)
+	GetAPIKeyDailyUsage(keyID string, day time.Time) (int, error)
+	RecordAPIKeyUsage(keyID string, day time.Time, endpoint string, rejected bool) error
+}
+
+// apiKeyMiddleware authenticates X-API-Key, applies the key's rate limit and
+// daily quota, and records usage per key and endpoint.
+func apiKeyMiddleware(repo apiKeyStore, limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == "OPTIONS" {
+			c.Next()
+			return
+		}
+
+		rawKey := c.GetHeader("X-API-Key")
+		if rawKey == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "missing API key",
+				"message": "Send your key in the X-API-Key header",
+			})
+			return
+		}
+
+		apiKey, err := repo.GetAPIKeyByHash(auth.HashAPIKey(rawKey))
+		if errors.Is(err, repository.ErrNotFound) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or revoked API key"})
+			return
+		}
+		if err != nil {
+			log.Printf("[ERROR] Failed to look up API key: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to verify API key"})
+			return
+		}
+
+		today := time.Now().UTC()
+		endpoint := c.FullPath()
+
+		if !limiter.GetLimiter("key:"+apiKey.ID, rate.Limit(apiKey.RateLimit), apiKey.Burst).Allow() {
+			recordUsage(repo, apiKey.ID, today, endpoint, true)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       "Rate limit exceeded for this API key. Please try again later.",
+				"rate_limit":  apiKey.RateLimit,
+				"burst":       apiKey.Burst,
+				"retry_after": "1s",
+			})
+			return
+		}
+
+		if apiKey.DailyQuota > 0 {
+			used, err := repo.GetAPIKeyDailyUsage(apiKey.ID, today)
+			if err != nil {
+				log.Printf("[ERROR] Failed to read usage for key %s: %v", apiKey.Prefix, err)
+			} else if used >= apiKey.DailyQuota {
+				recordUsage(repo, apiKey.ID, today, endpoint, true)
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+					"error":       "Daily quota exhausted for this API key",
+					"daily_quota": apiKey.DailyQuota,
+					"used":        used,
+					"resets_at":   time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+				})
+				return
+			}
+		}
+
+		principal := &auth.Principal{APIKeyID: apiKey.ID, OrgID: apiKey.OrgID}
+		if apiKey.OrgID != "" {
+			principal.Role = models.RoleAnalyst
+		}
+
+		c.Set(handlers.APIKeyContextKey, apiKey)
+		c.Set(handlers.PrincipalContextKey, principal)
+		c.Next()
+
+		recordUsage(repo, apiKey.ID, today, endpoint, false)
+	}
+}
+
+// AUTH MIDDLEWARE
+// authMiddleware accepts either a user session (Authorization: Bearer <jwt>)
+// or falls back to API key authentication.
+func authMiddleware(tokens *auth.TokenManager, limiter *RateLimiter, apiKeyAuth gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			apiKeyAuth(c)
+			return
+		}
+
+		claims, err := tokens.Parse(strings.TrimPrefix(header, "Bearer "))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "invalid or expired session",
+				"message": "Log in again via POST /api/v1/auth/login",
+			})
+			return
+		}
+
+		if !limiter.GetLimiter("user:"+claims.Subject, 10, 20).Allow() {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       "Rate limit exceeded. Please try again later.",
+				"retry_after": "1s",
+			})
+			return
+		}
+
+		c.Set(handlers.PrincipalContextKey, &auth.Principal{
+			UserID: claims.Subject,
+			OrgID:  claims.OrgID,
+			Role:   claims.Role,
+			Email:  claims.Email,
+		})
+		c.Next()
+	}
+}
+
+// requireRole restricts a route to callers holding one of the given roles
+func requireRole(roles ...models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get(handlers.PrincipalContextKey)
+		principal, _ := value.(*auth.Principal)
+		if !principal.HasOrg() || !principal.HasRole(roles...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":         "insufficient permissions",
+				"requiredRoles": roles,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+func recordUsage(repo apiKeyStore, keyID string, day time.Time, endpoint string, rejected bool) {
+	if endpoint == "" {
+		endpoint = "unknown"
+	}
+	if err := repo.RecordAPIKeyUsage(keyID, day, endpoint, rejected); err != nil {
+		log.Printf("[WARN] Failed to record API key usage: %v", err)
+	}
+}
+
 // SECURITY HEADERS
 func securityHeadersMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -90,7 +243,7 @@ func corsMiddleware() gin.HandlerFunc {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			c.Writer.Header().Set("Access-Control-Max-Age", "3600")
 		}
 
@@ -128,6 +281,11 @@ func main() {
 	// 4. Initialize Grid API Client
 	gridClient := grid.NewClient(cfg.GridAPIKey)
 
+	patches, err := patch.Load(cfg.PatchCalendar)
+	if err != nil {
+		log.Fatalf("Failed to load patch calendar: %v", err)
+	}
+
 	// 5. Setup Gin
 	router := gin.Default()
 
@@ -135,32 +293,73 @@ func main() {
 	router.Use(corsMiddleware())
 	router.Use(securityHeadersMiddleware())
 
-	limiter := NewIPRateLimiter(10, 20)
-	router.Use(rateLimitMiddleware(limiter))
+	limiter := NewRateLimiter()
 
 	// 6. Initialize handlers
-	handler := handlers.NewHandler(pgRepo, redisCache, gridClient)
+	tokens := auth.NewTokenManager(cfg.JWTSecret, cfg.SessionTTL)
+	handler := handlers.NewHandler(pgRepo, redisCache, gridClient, tokens, cfg.SignificanceLevel, cfg.FormHalfLifeDays, patches)
 
 	// 7. Routes
-	router.GET("/health", handler.HealthCheck)
+	router.GET("/health", rateLimitMiddleware(limiter, 10, 20), handler.HealthCheck)
+	router.POST("/api/v1/auth/login", rateLimitMiddleware(limiter, 1, 5), handler.Login)
 
-	// API routes
+	// API routes (require a user session or an API key)
 	api := router.Group("/api/v1")
+	api.Use(authMiddleware(tokens, limiter, apiKeyMiddleware(pgRepo, limiter)))
 	{
+		// Account
+		api.GET("/usage", handler.GetAPIKeyUsage)
+		api.GET("/auth/me", handler.GetCurrentPrincipal)
+
+		// Organisation administration
+		orgAdmin := api.Group("/org", requireRole(models.RoleAdmin))
+		orgAdmin.GET("/users", handler.ListOrgUsers)
+		orgAdmin.POST("/users", handler.CreateOrgUser)
+		orgAdmin.PATCH("/users/:id", handler.UpdateOrgUserRole)
+
+		// Watchlists (organisation-scoped)
+		api.GET("/watchlists", handler.ListWatchlists)
+		api.POST("/watchlists", handler.CreateWatchlist)
+		api.DELETE("/watchlists/:id", handler.DeleteWatchlist)
+		api.POST("/watchlists/:id/teams", handler.AddWatchlistTeam)
+		api.DELETE("/watchlists/:id/teams/:team", handler.RemoveWatchlistTeam)
+
 		// Comparison & Analysis
 		api.GET("/compare", handler.CompareTeams)
 		api.GET("/trends", handler.GetTeamTrends)
+		api.GET("/trends/series", handler.GetTrendSeries)
 		api.GET("/meta", handler.GetMeta)
+		api.GET("/predict", handler.PredictMatch)
+		api.GET("/ratings", handler.GetRatings)
+		api.GET("/teams/:name/rating-history", handler.GetRatingHistory)
+		api.GET("/teams/:name/sites", handler.GetTeamSites)
+		api.GET("/teams/:name/draft", handler.GetTeamDraft)
+		api.GET("/series/:id", handler.GetSeries)
 
 		// Scouting Report (comprehensive)
 		api.GET("/scouting-report", handler.GenerateScoutingReport)
 
+		// Saved reports (organisation-scoped)
+		api.GET("/reports", handler.ListSavedReports)
+		api.GET("/reports/:id", handler.GetSavedReport)
+		api.PATCH("/reports/:id", handler.UpdateSavedReport)
+		api.GET("/reports/:id/diff", handler.DiffSavedReport)
+		api.POST("/reports/diff", handler.DiffPostedReport)
+
+		// Analyst annotations (organisation-scoped)
+		api.GET("/teams/:name/notes", handler.ListTeamAnnotations)
+		api.POST("/teams/:name/notes", handler.CreateTeamAnnotation)
+		api.GET("/reports/:id/notes", handler.ListReportAnnotations)
+		api.POST("/reports/:id/notes", handler.CreateReportAnnotation)
+		api.DELETE("/notes/:id", handler.DeleteAnnotation)
+
 		// Search & Discovery
 		api.GET("/search", handler.SearchTeams)
 		api.GET("/teams/search", handler.SearchTeams)
 		api.GET("/teams", handler.GetAvailableTeams)
 		api.GET("/titles", handler.GetAvailableTitles)
 		api.GET("/tournaments", handler.GetAvailableTournaments)
+		api.GET("/patches", handler.GetPatches)
 	}
 
 	// 8. Start server with graceful shutdown
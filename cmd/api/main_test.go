@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/auth"
+	"github.com/yourusername/esports-scouting-backend/internal/handlers"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// fakeKeyStore serves a single API key by its hash
+type fakeKeyStore struct {
+	hash      string
+	key       *models.APIKey
+	lookupErr error
+	used      int
+	recorded  []bool // rejected flag of every recorded request
+}
+
+func (s *fakeKeyStore) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	if s.lookupErr != nil {
+		return nil, s.lookupErr
+	}
+	if keyHash != s.hash {
+		return nil, fmt.Errorf("api key: %w", repository.ErrNotFound)
+	}
+	return s.key, nil
+}
+
+func (s *fakeKeyStore) GetAPIKeyDailyUsage(keyID string, day time.Time) (int, error) {
+	return s.used, nil
+}
+
+func (s *fakeKeyStore) RecordAPIKeyUsage(keyID string, day time.Time, endpoint string, rejected bool) error {
+	s.recorded = append(s.recorded, rejected)
+	return nil
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const rawKey = "esk_valid"
+
+	tests := []struct {
+		name          string
+		header        string
+		lookupErr     error
+		dailyQuota    int
+		used          int
+		wantStatus    int
+		wantPrincipal bool
+		wantRecorded  []bool
+	}{
+		{"missing key", "", nil, 0, 0, http.StatusUnauthorized, false, nil},
+		{"unknown key", "esk_unknown", nil, 0, 0, http.StatusUnauthorized, false, nil},
+		{"lookup fails", rawKey, errors.New("connection refused"), 0, 0, http.StatusInternalServerError, false, nil},
+		{"valid key", rawKey, nil, 0, 0, http.StatusOK, true, []bool{false}},
+		{"quota left", rawKey, nil, 100, 99, http.StatusOK, true, []bool{false}},
+		{"quota exhausted", rawKey, nil, 100, 100, http.StatusTooManyRequests, false, []bool{true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeKeyStore{
+				hash:      auth.HashAPIKey(rawKey),
+				key:       &models.APIKey{ID: "key-1", OrgID: "org-1", RateLimit: 100, Burst: 100, DailyQuota: tt.dailyQuota},
+				lookupErr: tt.lookupErr,
+				used:      tt.used,
+			}
+
+			var principal *auth.Principal
+			router := gin.New()
+			router.GET("/api/v1/teams", apiKeyMiddleware(store, NewRateLimiter()), func(c *gin.Context) {
+				value, _ := c.Get(handlers.PrincipalContextKey)
+				principal, _ = value.(*auth.Principal)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/teams", nil)
+			if tt.header != "" {
+				req.Header.Set("X-API-Key", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := principal != nil; got != tt.wantPrincipal {
+				t.Fatalf("principal set = %v, want %v", got, tt.wantPrincipal)
+			}
+			if principal != nil && (principal.APIKeyID != "key-1" || principal.OrgID != "org-1" || principal.Role != models.RoleAnalyst) {
+				t.Errorf("principal = %+v", principal)
+			}
+			if fmt.Sprint(store.recorded) != fmt.Sprint(tt.wantRecorded) {
+				t.Errorf("recorded usage = %v, want %v", store.recorded, tt.wantRecorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// APIKeyPrefix marks keys issued by this service so they are easy to spot in logs and configs
+const APIKeyPrefix = "esk_"
+
+// GenerateAPIKey creates a new random API key. The plaintext key is only ever
+// shown once; callers must store HashAPIKey(key) instead.
+func GenerateAPIKey() (key string, displayPrefix string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:len(APIKeyPrefix)+8], nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 of a key. Keys carry 256 bits of
+// entropy, so a fast hash is sufficient and keeps lookups cheap.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey: %v", err)
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		t.Errorf("key %q does not start with %q", key, APIKeyPrefix)
+	}
+	if !strings.HasPrefix(key, prefix) || len(prefix) != len(APIKeyPrefix)+8 {
+		t.Errorf("display prefix %q is not the first %d characters of the key", prefix, len(APIKeyPrefix)+8)
+	}
+
+	other, _, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey: %v", err)
+	}
+	if key == other {
+		t.Error("two generated keys are identical")
+	}
+}
+
+func TestHashAPIKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{"empty", "", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"known key", "esk_test", "c8542b0506f71bf5a7a01e6f8429318cb6eb4defb8d6a5abb7617fff2b8ea33a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HashAPIKey(tt.key)
+			if got != tt.want {
+				t.Errorf("HashAPIKey(%q) = %s, want %s", tt.key, got, tt.want)
+			}
+			if got != HashAPIKey(tt.key) {
+				t.Errorf("HashAPIKey(%q) is not deterministic", tt.key)
+			}
+		})
+	}
+
+	if HashAPIKey("esk_a") == HashAPIKey("esk_b") {
+		t.Error("different keys share a hash")
+	}
+}
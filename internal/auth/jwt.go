@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+const tokenIssuer = "esports-scouting-backend"
+
+// Claims is the session payload carried in the JWT
+type Claims struct {
+	OrgID string      `json:"org"`
+	Role  models.Role `json:"role"`
+	Email string      `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager issues and verifies HS256 session tokens
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenManager(secret string, ttl time.Duration) *TokenManager {
+	return &TokenManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// Issue creates a signed session token for a user
+func (m *TokenManager) Issue(user *models.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	claims := Claims{
+		OrgID: user.OrgID,
+		Role:  user.Role,
+		Email: user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID,
+			Issuer:    tokenIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse verifies a token's signature, issuer and expiry and returns its claims
+func (m *TokenManager) Parse(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(tokenIssuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("invalid session token: %w", err)
+	}
+	return claims, nil
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestTokenManagerRoundTrip(t *testing.T) {
+	m := NewTokenManager("test-secret", time.Hour)
+	user := &models.User{ID: "user-1", OrgID: "org-1", Email: "coach@team.gg", Role: models.RoleCoach}
+
+	token, expiresAt, err := m.Issue(user)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if d := time.Until(expiresAt); d <= 59*time.Minute || d > time.Hour {
+		t.Errorf("expiresAt is %s away, want about an hour", d)
+	}
+
+	claims, err := m.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if claims.Subject != user.ID || claims.OrgID != user.OrgID || claims.Email != user.Email || claims.Role != user.Role {
+		t.Errorf("claims = %+v, want the user's id, org, email and role", claims)
+	}
+}
+
+func TestTokenManagerParseRejects(t *testing.T) {
+	m := NewTokenManager("test-secret", time.Hour)
+	user := &models.User{ID: "user-1", OrgID: "org-1", Role: models.RoleAnalyst}
+
+	expired, _, err := NewTokenManager("test-secret", -time.Minute).Issue(user)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	otherSecret, _, err := NewTokenManager("other-secret", time.Hour).Issue(user)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	sign := func(method jwt.SigningMethod, key interface{}, claims jwt.Claims) string {
+		signed, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString: %v", err)
+		}
+		return signed
+	}
+	future := jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"garbage", "not-a-token"},
+		{"expired", expired},
+		{"wrong secret", otherSecret},
+		{"wrong issuer", sign(jwt.SigningMethodHS256, []byte("test-secret"), Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1", Issuer: "someone-else", ExpiresAt: future},
+		})},
+		{"no expiry", sign(jwt.SigningMethodHS256, []byte("test-secret"), Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1", Issuer: tokenIssuer},
+		})},
+		{"unsigned", sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1", Issuer: tokenIssuer, ExpiresAt: future},
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if claims, err := m.Parse(tt.token); err == nil {
+				t.Errorf("Parse accepted the token: %+v", claims)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "testing"
+
+func TestHashPassword(t *testing.T) {
+	if _, err := HashPassword("short"); err == nil {
+		t.Error("HashPassword accepted a password shorter than MinPasswordLength")
+	}
+
+	hash, err := HashPassword("correct horse battery")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"correct horse battery", true},
+		{"correct horse battery ", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := CheckPassword(hash, tt.password); got != tt.want {
+			t.Errorf("CheckPassword(%q) = %v, want %v", tt.password, got, tt.want)
+		}
+	}
+
+	// Must not panic or accept anything; it only burns bcrypt time
+	RejectPassword("correct horse battery")
+}
@@ -0,0 +1,50 @@
+package auth
+
+import "github.com/yourusername/esports-scouting-backend/internal/models"
+
+// Principal is the authenticated caller of an /api/v1 request: either a user
+// session (JWT) or a partner API key.
+type Principal struct {
+	UserID   string      `json:"userId,omitempty"`
+	APIKeyID string      `json:"apiKeyId,omitempty"`
+	OrgID    string      `json:"orgId,omitempty"`
+	Role     models.Role `json:"role,omitempty"`
+	Email    string      `json:"email,omitempty"`
+}
+
+// HasOrg reports whether the caller can access organisation-scoped data
+func (p *Principal) HasOrg() bool {
+	return p != nil && p.OrgID != ""
+}
+
+// HasRole reports whether the caller holds one of the given roles
+func (p *Principal) HasRole(roles ...models.Role) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range roles {
+		if p.Role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// CanEdit reports whether the caller may create or change organisation prep
+func (p *Principal) CanEdit() bool {
+	return p.HasOrg() && p.HasRole(models.RoleAdmin, models.RoleCoach, models.RoleAnalyst)
+}
+
+// Actor is a human-readable identifier for audit fields such as "author"
+func (p *Principal) Actor() string {
+	if p == nil {
+		return ""
+	}
+	if p.Email != "" {
+		return p.Email
+	}
+	if p.APIKeyID != "" {
+		return "api-key:" + p.APIKeyID
+	}
+	return p.UserID
+}
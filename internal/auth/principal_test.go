@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestPrincipalHasRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		roles     []models.Role
+		want      bool
+	}{
+		{"nil principal", nil, []models.Role{models.RoleAdmin}, false},
+		{"matching role", &Principal{Role: models.RoleCoach}, []models.Role{models.RoleAdmin, models.RoleCoach}, true},
+		{"other role", &Principal{Role: models.RolePlayer}, []models.Role{models.RoleAdmin, models.RoleCoach}, false},
+		{"no roles given", &Principal{Role: models.RoleAdmin}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.principal.HasRole(tt.roles...); got != tt.want {
+				t.Errorf("HasRole(%v) = %v, want %v", tt.roles, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrincipalCanEdit(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		want      bool
+	}{
+		{"nil principal", nil, false},
+		{"admin", &Principal{OrgID: "org-1", Role: models.RoleAdmin}, true},
+		{"coach", &Principal{OrgID: "org-1", Role: models.RoleCoach}, true},
+		{"analyst", &Principal{OrgID: "org-1", Role: models.RoleAnalyst}, true},
+		{"player", &Principal{OrgID: "org-1", Role: models.RolePlayer}, false},
+		{"admin without organisation", &Principal{Role: models.RoleAdmin}, false},
+		{"org-bound api key", &Principal{APIKeyID: "key-1", OrgID: "org-1", Role: models.RoleAnalyst}, true},
+		{"unbound api key", &Principal{APIKeyID: "key-1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.principal.CanEdit(); got != tt.want {
+				t.Errorf("CanEdit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
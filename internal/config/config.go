@@ -3,6 +3,8 @@ package config
 import (
     "fmt"
     "os"
+    "strconv"
+    "time"
 
     "github.com/joho/godotenv"
 )
@@ -14,6 +16,14 @@ type Config struct {
     GridAPIKey     string
     DatabaseURL    string
     TrustedProxies string
+    JWTSecret      string        // signs user session tokens
+    SessionTTL     time.Duration // lifetime of a session token
+    // Significance level (alpha) an advantage must pass before it is reported
+    SignificanceLevel float64
+    // Default half-life, in days, of the recency-weighted form
+    FormHalfLifeDays float64
+    // Path to the JSON patch calendar; empty disables patch filters and breakdowns
+    PatchCalendar string
 }
 
 func Load() (*Config, error) {
@@ -29,8 +39,28 @@ func Load() (*Config, error) {
         GridAPIKey:     os.Getenv("GRID_API_KEY"),
         DatabaseURL:    os.Getenv("DATABASE_URL"),
         TrustedProxies: os.Getenv("TRUSTED_PROXIES"),
+        JWTSecret:      os.Getenv("JWT_SECRET"),
+        PatchCalendar:  os.Getenv("PATCH_CALENDAR"),
     }
 
+    sessionTTL, err := time.ParseDuration(getEnv("SESSION_TTL", "12h"))
+    if err != nil {
+        return nil, fmt.Errorf("invalid SESSION_TTL: %w", err)
+    }
+    cfg.SessionTTL = sessionTTL
+
+    alpha, err := strconv.ParseFloat(getEnv("SIGNIFICANCE_LEVEL", "0.05"), 64)
+    if err != nil || alpha <= 0 || alpha >= 0.5 {
+        return nil, fmt.Errorf("invalid SIGNIFICANCE_LEVEL: must be a number between 0 and 0.5")
+    }
+    cfg.SignificanceLevel = alpha
+
+    halfLife, err := strconv.ParseFloat(getEnv("FORM_HALF_LIFE_DAYS", "14"), 64)
+    if err != nil || halfLife < 1 || halfLife > 365 {
+        return nil, fmt.Errorf("invalid FORM_HALF_LIFE_DAYS: must be a number of days between 1 and 365")
+    }
+    cfg.FormHalfLifeDays = halfLife
+
     // Validate required fields
     if cfg.RedisURL == "" {
         return nil, fmt.Errorf("REDIS_URL environment variable is required")
@@ -41,6 +71,9 @@ func Load() (*Config, error) {
     if cfg.DatabaseURL == "" {
         return nil, fmt.Errorf("DATABASE_URL environment variable is required")
     }
+    if len(cfg.JWTSecret) < 32 {
+        return nil, fmt.Errorf("JWT_SECRET environment variable is required (at least 32 characters)")
+    }
 
     return cfg, nil
 }
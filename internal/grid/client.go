@@ -58,20 +58,22 @@ func (c *Client) newRequest(query string) *graphql.Request {
 	return req
 }
 
-// GetTeamSeriesHistory fetches series for a team from hackathon tournaments
-func (c *Client) GetTeamSeriesHistory(ctx context.Context, teamIDOrName string, limit int, tournamentIDs []string) ([]SeriesData, error) {
-	now := time.Now()
-	twoYearsAgo := now.AddDate(-2, 0, 0)
+// GetTeamSeriesHistory fetches series for a team from hackathon tournaments,
+// newest first, scheduled between from and to. A zero to means no upper bound.
+func (c *Client) GetTeamSeriesHistory(ctx context.Context, teamIDOrName string, limit int, tournamentIDs []string, from, to time.Time) ([]SeriesData, error) {
+	if to.IsZero() {
+		to = time.Now().AddDate(1, 0, 0)
+	}
 
 	// Hackathon data: query ALL recent series and filter client-side (max 50 per page)
 	var query string
 	if len(tournamentIDs) > 0 {
 		// Filter by specific tournaments
 		query = `
-			query($startTime: String!, $tournamentIds: [ID!]) {
+			query($startTime: String!, $endTime: String!, $tournamentIds: [ID!]) {
 				allSeries(
 					filter: {
-						startTimeScheduled: { gte: $startTime }
+						startTimeScheduled: { gte: $startTime, lte: $endTime }
 						tournament: { id: { in: $tournamentIds }, includeChildren: { equals: true } }
 						types: ESPORTS
 					}
@@ -99,10 +101,10 @@ func (c *Client) GetTeamSeriesHistory(ctx context.Context, teamIDOrName string,
 	} else {
 		// Query all series
 		query = `
-			query($startTime: String!) {
+			query($startTime: String!, $endTime: String!) {
 				allSeries(
 					filter: {
-						startTimeScheduled: { gte: $startTime }
+						startTimeScheduled: { gte: $startTime, lte: $endTime }
 						types: ESPORTS
 					}
 					orderBy: StartTimeScheduled
@@ -129,7 +131,8 @@ func (c *Client) GetTeamSeriesHistory(ctx context.Context, teamIDOrName string,
 	}
 
 	req := c.newRequest(query)
-	req.Var("startTime", twoYearsAgo.Format(time.RFC3339))
+	req.Var("startTime", from.Format(time.RFC3339))
+	req.Var("endTime", to.Format(time.RFC3339))
 	if len(tournamentIDs) > 0 {
 		req.Var("tournamentIds", tournamentIDs)
 	}
@@ -165,12 +168,39 @@ func (c *Client) GetTeamSeriesHistory(ctx context.Context, teamIDOrName string,
 	var seriesData []SeriesData
 	searchName := strings.ToLower(teamIDOrName)
 
+	// Every other team's record in series that don't involve the searched team,
+	// used to estimate opponent strength
+	otherRecords := make(map[string]*teamRecord)
+	for _, edge := range resp.AllSeries.Edges {
+		teams := edge.Node.Teams
+		if len(teams) != 2 || teams[0].ScoreAdvantage == teams[1].ScoreAdvantage {
+			continue
+		}
+		if strings.Contains(strings.ToLower(teams[0].BaseInfo.Name), searchName) ||
+			strings.Contains(strings.ToLower(teams[1].BaseInfo.Name), searchName) {
+			continue
+		}
+		for _, team := range teams {
+			record := otherRecords[team.BaseInfo.ID]
+			if record == nil {
+				record = &teamRecord{}
+				otherRecords[team.BaseInfo.ID] = record
+			}
+			record.series++
+		}
+		winner := teams[0]
+		if teams[1].ScoreAdvantage > teams[0].ScoreAdvantage {
+			winner = teams[1]
+		}
+		otherRecords[winner.BaseInfo.ID].wins++
+	}
+
 	for _, edge := range resp.AllSeries.Edges {
 		series := edge.Node
 
 		var teamFound bool
 		var teamWon bool
-		var opponentName string
+		var opponentName, opponentID string
 		var teamID string
 		var ourTeamScore, opponentScore int
 
@@ -183,20 +213,26 @@ func (c *Client) GetTeamSeriesHistory(ctx context.Context, teamIDOrName string,
 				ourTeamScore = team.ScoreAdvantage
 			} else {
 				opponentName = team.BaseInfo.Name
+				opponentID = team.BaseInfo.ID
 				opponentScore = team.ScoreAdvantage
 			}
 		}
 
 		if teamFound && len(seriesData) < limit {
 			teamWon = ourTeamScore > opponentScore
-			seriesData = append(seriesData, SeriesData{
+			data := SeriesData{
 				ID:       series.ID,
 				TeamID:   teamID,
 				Date:     series.StartTimeScheduled,
 				Format:   "BO3", // Default
 				Won:      teamWon,
 				Opponent: opponentName,
-			})
+			}
+			if record := otherRecords[opponentID]; record != nil {
+				data.OpponentWins = record.wins
+				data.OpponentSeries = record.series
+			}
+			seriesData = append(seriesData, data)
 		}
 	}
 
@@ -236,35 +272,30 @@ type SeriesData struct {
 	Format   string
 	Won      bool
 	Opponent string
+	// Opponent's record against teams other than the searched one
+	OpponentWins   int
+	OpponentSeries int
+}
+
+type teamRecord struct {
+	wins, series int
 }
 
 // GetTeamStatistics fetches series and uses Series State API for detailed stats
-// FIXED: Implements graduated fallback for better accuracy
-func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title string, timeWindow models.TimeWindow, tournamentIDs []string) (*models.TeamStats, error) {
+// FIXED: Implements graduated fallback for better accuracy. With strict set the
+// requested window is never widened. stats.Window discloses what was used.
+func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title string, timeWindow models.TimeWindow, tournamentIDs []string, strict bool) (*models.TeamStats, error) {
 	// Auto-select tournaments if none specified
 	if len(tournamentIDs) == 0 {
-		switch strings.ToLower(title) {
-		case "valorant":
-			tournamentIDs = []string{
-				"757371", "757481", "774782", // 2024
-				"775516", "800675", "826660", // 2025
-			}
-			fmt.Printf("[DEBUG] Auto-selected Valorant tournaments\n")
-		case "lol", "leagueoflegends":
-			tournamentIDs = []string{
-				"758024", "774794", "825490", "826679", // LCK
-				"758043", "774888", // LCS
-				"758077", "774622", "825468", "826906", // LEC
-				"758054", "774845", "775662", "825450", // LPL
-			}
-			fmt.Printf("[DEBUG] Auto-selected LoL tournaments\n")
-		default:
+		tournamentIDs = DefaultTournamentIDs(title)
+		if len(tournamentIDs) == 0 {
 			return nil, fmt.Errorf("no tournaments configured for title: %s", title)
 		}
+		fmt.Printf("[DEBUG] Auto-selected %s tournaments\n", title)
 	}
 
 	// Step 1: Get series IDs for this team by name
-	seriesHistory, err := c.GetTeamSeriesHistory(ctx, teamName, 50, tournamentIDs)
+	seriesHistory, err := c.GetTeamSeriesHistory(ctx, teamName, 50, tournamentIDs, time.Now().AddDate(-2, 0, 0), time.Time{})
 	if err != nil {
 		return nil, err
 	}
@@ -273,12 +304,16 @@ func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title s
 		return nil, fmt.Errorf("no match data found for team %s", teamName)
 	}
 
-	// Step 2: Filter by time window with GRADUATED FALLBACK
+	// Step 2: Filter by time window with GRADUATED FALLBACK (strict mode only tries the requested window)
 	now := time.Now()
 	var filteredSeries []SeriesData
 	actualWindow := timeWindow
+	var expansionReason string
 
 	windowSequence := getWindowFallbackSequence(timeWindow)
+	if strict {
+		windowSequence = windowSequence[:1]
+	}
 
 	for _, window := range windowSequence {
 		cutoffDate := calculateCutoffDate(now, window)
@@ -290,8 +325,9 @@ func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title s
 			}
 		}
 
-		if len(filteredSeries) >= 3 {
-			actualWindow = window
+		// The widest window tried is used even when it falls short
+		actualWindow = window
+		if len(filteredSeries) >= minWindowMatches {
 			if window != timeWindow {
 				fmt.Printf("[INFO] Expanded time window from %s to %s to get sufficient data (%d matches)\n",
 					timeWindow, window, len(filteredSeries))
@@ -299,28 +335,127 @@ func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title s
 			break
 		}
 
-		fmt.Printf("[DEBUG] %s: found %d matches (need ≥3), trying next window\n", window, len(filteredSeries))
+		if expansionReason == "" {
+			expansionReason = fmt.Sprintf("only %d of the %d matches needed in %s", len(filteredSeries), minWindowMatches, window)
+		}
+		fmt.Printf("[DEBUG] %s: found %d matches (need ≥%d), trying next window\n", window, len(filteredSeries), minWindowMatches)
 	}
 
 	// ✅ FIX: Return InsufficientDataError instead of generic error
 	if len(filteredSeries) == 0 {
+		reason := "no recent matches found"
+		if strict {
+			reason = fmt.Sprintf("no matches in %s and strict mode disables fallback", actualWindow)
+		}
 		return nil, &InsufficientDataError{
 			TeamName:  teamName,
-			Reason:    "no recent matches found",
+			Reason:    reason,
 			LastMatch: seriesHistory[0].Date,
 		}
 	}
 
-	if len(filteredSeries) < 3 {
+	if len(filteredSeries) < minWindowMatches {
 		fmt.Printf("[WARN] Only %d matches found for %s - confidence will be LOW\n", len(filteredSeries), teamName)
 	}
 
 	fmt.Printf("[DEBUG] Using %d series from %s window for stats calculation\n", len(filteredSeries), actualWindow)
 
+	stats, err := c.buildTeamStats(ctx, teamName, filteredSeries, actualWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	disclosure := &models.WindowDisclosure{
+		Requested: timeWindow,
+		Actual:    actualWindow,
+		Expanded:  actualWindow != timeWindow,
+		Strict:    strict,
+	}
+	if disclosure.Expanded {
+		disclosure.Reason = expansionReason
+	}
+	disclosure.FirstMatch, disclosure.LastMatch = seriesSpan(filteredSeries)
+	stats.Window = disclosure
+	return stats, nil
+}
+
+// GetTeamStatisticsBetween is GetTeamStatistics over an explicit date range. The
+// range is never widened; a team without series in it gets an InsufficientDataError.
+func (c *Client) GetTeamStatisticsBetween(ctx context.Context, teamName string, title string, dateRange models.DateRange, tournamentIDs []string) (*models.TeamStats, error) {
+	if len(tournamentIDs) == 0 {
+		tournamentIDs = DefaultTournamentIDs(title)
+		if len(tournamentIDs) == 0 {
+			return nil, fmt.Errorf("no tournaments configured for title: %s", title)
+		}
+	}
+
+	seriesHistory, err := c.GetTeamSeriesHistory(ctx, teamName, 50, tournamentIDs, dateRange.From, dateRange.To)
+	if err != nil {
+		return nil, err
+	}
+
+	// The query bounds are inclusive; keep the range half-open so adjacent ranges don't share a series
+	var filteredSeries []SeriesData
+	for _, series := range seriesHistory {
+		if dateRange.Contains(series.Date) {
+			filteredSeries = append(filteredSeries, series)
+		}
+	}
+
+	if len(filteredSeries) == 0 {
+		return nil, &InsufficientDataError{
+			TeamName: teamName,
+			Reason:   fmt.Sprintf("no matches between %s and %s", dateRange.From.Format("2006-01-02"), dateRange.To.Format("2006-01-02")),
+		}
+	}
+
+	fmt.Printf("[DEBUG] Using %d series between %s and %s for stats calculation\n",
+		len(filteredSeries), dateRange.From.Format(time.RFC3339), dateRange.To.Format(time.RFC3339))
+
+	stats, err := c.buildTeamStats(ctx, teamName, filteredSeries, "")
+	if err != nil {
+		return nil, err
+	}
+	stats.DateRange = &dateRange
+	stats.Window = &models.WindowDisclosure{}
+	stats.Window.FirstMatch, stats.Window.LastMatch = seriesSpan(filteredSeries)
+	return stats, nil
+}
+
+// seriesSpan returns the dates of the earliest and latest series
+func seriesSpan(series []SeriesData) (first, last time.Time) {
+	for i, s := range series {
+		if i == 0 || s.Date.Before(first) {
+			first = s.Date
+		}
+		if i == 0 || s.Date.After(last) {
+			last = s.Date
+		}
+	}
+	return first, last
+}
+
+// buildTeamStats aggregates the chosen series, downloading Series State data for
+// up to the 10 most recent
+func (c *Client) buildTeamStats(ctx context.Context, teamName string, filteredSeries []SeriesData, actualWindow models.TimeWindow) (*models.TeamStats, error) {
 	// Step 3: Fetch Series State data
 	var totalKills, totalDeaths, totalGames int
 	successfulDownloads := 0
 
+	results := make([]models.SeriesResult, len(filteredSeries))
+	for i, series := range filteredSeries {
+		results[i] = models.SeriesResult{
+			SeriesID:  series.ID,
+			TeamID:    series.TeamID,
+			StartTime: series.Date,
+			Opponent:  series.Opponent,
+			Won:       series.Won,
+
+			OpponentWins:   series.OpponentWins,
+			OpponentSeries: series.OpponentSeries,
+		}
+	}
+
 	for i, series := range filteredSeries {
 		if i >= 10 {
 			break
@@ -341,6 +476,10 @@ func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title s
 				totalGames += stats.GamesPlayed
 				successfulDownloads++
 				foundStats = true
+				results[i].HasStats = true
+				results[i].Kills = stats.Kills
+				results[i].Deaths = stats.Deaths
+				results[i].Games = stats.GamesPlayed
 				fmt.Printf("[DEBUG] Series %s: +%d kills, +%d deaths, +%d games\n",
 					series.ID, stats.Kills, stats.Deaths, stats.GamesPlayed)
 				break
@@ -404,6 +543,7 @@ func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title s
 	stats := &models.TeamStats{
 		WinRate:       winRate,
 		MatchesPlayed: totalMatches,
+		Wins:          totalWins,
 		Kills:         totalKills,
 		KillsAvg:      killsAvg,
 		Deaths:        totalDeaths,
@@ -416,6 +556,7 @@ func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title s
 		SampleSize: totalMatches,
 		// Store actual window used for transparency
 		ActualTimeWindow: actualWindow,
+		Series:           results,
 	}
 
 	fmt.Printf("[SUCCESS] Retrieved stats from %d/%d series attempts\n", successfulDownloads, min(10, len(filteredSeries)))
@@ -423,6 +564,9 @@ func (c *Client) GetTeamStatistics(ctx context.Context, teamName string, title s
 	return stats, nil
 }
 
+// minWindowMatches is how many matches a window needs before it is used without widening
+const minWindowMatches = 3
+
 // Helper: Get fallback sequence based on requested window
 func getWindowFallbackSequence(requested models.TimeWindow) []models.TimeWindow {
 	switch requested {
@@ -460,20 +604,7 @@ func getWindowFallbackSequence(requested models.TimeWindow) []models.TimeWindow
 
 // Helper: Calculate cutoff date for a time window
 func calculateCutoffDate(now time.Time, window models.TimeWindow) time.Time {
-	switch window {
-	case models.LastWeek:
-		return now.AddDate(0, 0, -7)
-	case models.LastMonth:
-		return now.AddDate(0, -1, 0)
-	case models.Last3Months:
-		return now.AddDate(0, -3, 0)
-	case models.Last6Months:
-		return now.AddDate(0, -6, 0)
-	case models.LastYear:
-		return now.AddDate(-1, 0, 0)
-	default:
-		return now.AddDate(0, -3, 0)
-	}
+	return window.Cutoff(now)
 }
 
 // Helper function for min
@@ -517,22 +648,8 @@ func (c *Client) GetAvailableTeams(ctx context.Context, title string, tournament
 
 	// Auto-select tournaments if none specified (same logic as GetTeamStatistics)
 	if len(tournamentIDs) == 0 {
-		switch strings.ToLower(title) {
-		case "valorant":
-			tournamentIDs = []string{
-				"757371", "757481", "774782", // 2024
-				"775516", "800675", "826660", // 2025
-			}
-			fmt.Printf("[DEBUG] Auto-selected Valorant tournaments: %v\n", tournamentIDs)
-		case "lol", "leagueoflegends":
-			tournamentIDs = []string{
-				"758024", "774794", "825490", "826679", // LCK
-				"758043", "774888", // LCS
-				"758077", "774622", "825468", "826906", // LEC
-				"758054", "774845", "775662", "825450", // LPL
-			}
-			fmt.Printf("[DEBUG] Auto-selected LoL tournaments: %v\n", tournamentIDs)
-		}
+		tournamentIDs = DefaultTournamentIDs(title)
+		fmt.Printf("[DEBUG] Auto-selected %s tournaments: %v\n", title, tournamentIDs)
 	}
 
 	// Now tournamentIDs will always be set for valorant/lol
@@ -0,0 +1,375 @@
+package grid
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// objectiveTargets are the Grid target types counted as LoL objectives
+var objectiveTargets = map[string]bool{
+	"tower":      true,
+	"inhibitor":  true,
+	"ATierNPC":   true, // dragons, herald, baron
+	"BTierNPC":   true,
+	"towerPlate": true,
+}
+
+// goldMarks are the game clock minutes at which LoL team net worth is recorded
+var goldMarks = []int{10, 15}
+
+// DownloadSeriesEvents downloads the JSONL event file of a finished series and
+// normalises it into kills, rounds, bomb plants/defuses, objectives and game
+// results, plus Valorant buys and LoL gold at set game times. The file
+// is parsed as it streams; Grid serves it either plain or zipped.
+func (fd *FileDownloader) DownloadSeriesEvents(ctx context.Context, seriesID string) ([]models.GridEvent, error) {
+	if err := fd.checkFileReady(ctx, seriesID, "events"); err != nil {
+		return nil, err
+	}
+
+	body, err := fd.download(ctx, fmt.Sprintf("https://api.grid.gg/file-download/events/grid/series/%s", seriesID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+	if magic, _ := reader.Peek(2); !bytes.Equal(magic, []byte("PK")) {
+		return parseEventStream(reader, seriesID)
+	}
+
+	// Zip archives need random access, so spool to disk rather than memory
+	tmp, err := os.CreateTemp("", "grid-events-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download events file: %w", err)
+	}
+
+	archive, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open events archive: %w", err)
+	}
+	for _, file := range archive.File {
+		if !strings.HasSuffix(file.Name, ".jsonl") {
+			continue
+		}
+		entry, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+		}
+		defer entry.Close()
+		return parseEventStream(entry, seriesID)
+	}
+	return nil, fmt.Errorf("events archive for series %s has no .jsonl file", seriesID)
+}
+
+// streamTransaction is one line of the events file. The full series state each
+// line carries is not decoded.
+type streamTransaction struct {
+	OccurredAt string        `json:"occurredAt"`
+	Events     []streamEvent `json:"events"`
+}
+
+type streamEvent struct {
+	Type   string       `json:"type"` // <actor>-<action>-<target>
+	Actor  streamEntity `json:"actor"`
+	Target streamEntity `json:"target"`
+	// Only the game clock, loadouts, net worth and winners are read from the
+	// state after the event
+	SeriesState *struct {
+		Games []streamGame `json:"games"`
+	} `json:"seriesState"`
+}
+
+type streamGame struct {
+	SequenceNumber int `json:"sequenceNumber"`
+	Clock          struct {
+		CurrentSeconds int `json:"currentSeconds"`
+	} `json:"clock"`
+	Teams []struct {
+		ID           string `json:"id"`
+		Won          bool   `json:"won"`
+		NetWorth     int    `json:"netWorth"`
+		LoadoutValue int    `json:"loadoutValue"`
+		Players      []struct {
+			LoadoutValue int `json:"loadoutValue"`
+		} `json:"players"`
+	} `json:"teams"`
+}
+
+type streamEntity struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	State struct {
+		Name           string `json:"name"`
+		TeamID         string `json:"teamId"`
+		SequenceNumber int    `json:"sequenceNumber"`
+		Site           string `json:"site"`
+		Map            struct {
+			Name string `json:"name"`
+		} `json:"map"`
+	} `json:"state"`
+	StateDelta struct {
+		WeaponKills map[string]int `json:"weaponKills"`
+	} `json:"stateDelta"`
+}
+
+// parseEventStream reads Grid's JSONL series events one line at a time and keeps
+// the events we analyse. Game and round numbers are tracked from the start
+// events, so every stored event knows where in the series it happened.
+func parseEventStream(r io.Reader, seriesID string) ([]models.GridEvent, error) {
+	decoder := json.NewDecoder(r)
+	events := []models.GridEvent{}
+	game, round := 0, 0
+	// Team loadouts as of the latest event, and whether this round's were stored
+	loadouts := make(map[string]int)
+	buyRecorded := false
+	// Gold marks already recorded in the current game
+	marked := make(map[int]bool)
+
+	for line := 1; ; line++ {
+		var tx streamTransaction
+		if err := decoder.Decode(&tx); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse events line %d: %w", line, err)
+		}
+
+		occurredAt, _ := time.Parse(time.RFC3339Nano, tx.OccurredAt)
+
+		for _, ev := range tx.Events {
+			eventType := normaliseEventType(ev.Type, ev.Target.Type)
+			if eventType == "" {
+				updateLoadouts(loadouts, ev, game)
+				events = recordGoldMarks(events, ev, seriesID, game, occurredAt, marked)
+				continue
+			}
+
+			switch eventType {
+			case models.EventGameStart:
+				game++
+				if ev.Target.State.SequenceNumber > 0 {
+					game = ev.Target.State.SequenceNumber
+				}
+				round = 0
+				marked = make(map[int]bool)
+			case models.EventRoundStart:
+				round++
+				if ev.Target.State.SequenceNumber > 0 {
+					round = ev.Target.State.SequenceNumber
+				}
+				buyRecorded = false
+			case models.EventKill, models.EventRoundEnd:
+				// Buys are final once the first shot lands, before the kill drops weapons
+				if round > 0 && !buyRecorded {
+					buyRecorded = true
+					for _, teamID := range sortedKeys(loadouts) {
+						events = append(events, models.GridEvent{
+							SeriesID:    seriesID,
+							Sequence:    len(events) + 1,
+							GameNumber:  game,
+							RoundNumber: round,
+							Type:        models.EventBuy,
+							SourceType:  ev.Type,
+							OccurredAt:  occurredAt,
+							ActorTeamID: teamID,
+							ActorID:     teamID,
+							Value:       loadouts[teamID],
+						})
+					}
+				}
+			}
+
+			event := models.GridEvent{
+				SeriesID:    seriesID,
+				Sequence:    len(events) + 1,
+				GameNumber:  game,
+				RoundNumber: round,
+				Type:        eventType,
+				SourceType:  ev.Type,
+				OccurredAt:  occurredAt,
+			}
+			event.ActorTeamID, event.ActorID, event.ActorName = entityFields(ev.Actor)
+			if eventType != models.EventGameStart && eventType != models.EventRoundStart {
+				event.TargetTeamID, event.TargetID, event.TargetName = entityFields(ev.Target)
+			}
+
+			switch eventType {
+			case models.EventGameStart:
+				event.Detail = ev.Target.State.Map.Name
+			case models.EventKill:
+				event.Weapon = killWeapon(ev.Actor)
+			case models.EventBombPlant, models.EventBombDefuse:
+				event.Detail = ev.Actor.State.Site
+				if event.Detail == "" {
+					event.Detail = ev.Target.State.Site
+				}
+			case models.EventObjective:
+				event.Detail = ev.Target.ID
+			case models.EventGameEnd:
+				if g := gameSnapshot(ev, game); g != nil {
+					event.Value = g.Clock.CurrentSeconds
+					for _, team := range g.Teams {
+						if team.Won {
+							event.ActorTeamID, event.ActorID = team.ID, team.ID
+						}
+					}
+				}
+			}
+
+			events = append(events, event)
+			updateLoadouts(loadouts, ev, game)
+			events = recordGoldMarks(events, ev, seriesID, game, occurredAt, marked)
+		}
+	}
+
+	return events, nil
+}
+
+// normaliseEventType maps a Grid event type onto ours, or "" for events we don't keep
+func normaliseEventType(gridType, targetType string) string {
+	switch gridType {
+	case "series-started-game":
+		return models.EventGameStart
+	case "series-ended-game":
+		return models.EventGameEnd
+	case "game-started-round":
+		return models.EventRoundStart
+	case "team-won-round":
+		return models.EventRoundEnd
+	case "player-killed-player":
+		return models.EventKill
+	case "player-completed-plantBomb":
+		return models.EventBombPlant
+	case "player-completed-defuseBomb":
+		return models.EventBombDefuse
+	}
+
+	parts := strings.SplitN(gridType, "-", 3)
+	if len(parts) == 3 && (parts[1] == "killed" || parts[1] == "destroyed") && objectiveTargets[targetType] {
+		return models.EventObjective
+	}
+	return ""
+}
+
+// entityFields returns the team, ID and name of an event's actor or target. A
+// team entity is its own team.
+func entityFields(e streamEntity) (teamID, id, name string) {
+	switch e.Type {
+	case "player":
+		return e.State.TeamID, e.ID, e.State.Name
+	case "team":
+		return e.ID, e.ID, e.State.Name
+	}
+	return "", "", ""
+}
+
+// killWeapon is the weapon whose kill count went up with this kill. Weapons are
+// checked in name order so a delta with several entries always gives the same one.
+func killWeapon(actor streamEntity) string {
+	weapons := make([]string, 0, len(actor.StateDelta.WeaponKills))
+	for weapon := range actor.StateDelta.WeaponKills {
+		weapons = append(weapons, weapon)
+	}
+	sort.Strings(weapons)
+	for _, weapon := range weapons {
+		if actor.StateDelta.WeaponKills[weapon] > 0 {
+			return weapon
+		}
+	}
+	return ""
+}
+
+// gameSnapshot returns the given game's state after an event, or nil when the
+// event carries none
+func gameSnapshot(ev streamEvent, game int) *streamGame {
+	if ev.SeriesState == nil {
+		return nil
+	}
+	for i := range ev.SeriesState.Games {
+		if ev.SeriesState.Games[i].SequenceNumber == game {
+			return &ev.SeriesState.Games[i]
+		}
+	}
+	return nil
+}
+
+// updateLoadouts records each team's loadout value in the given game from the
+// state after an event. A team without its own value sums its players'.
+func updateLoadouts(loadouts map[string]int, ev streamEvent, game int) {
+	g := gameSnapshot(ev, game)
+	if g == nil {
+		return
+	}
+	for _, team := range g.Teams {
+		value := team.LoadoutValue
+		if value == 0 {
+			for _, player := range team.Players {
+				value += player.LoadoutValue
+			}
+		}
+		if value > 0 {
+			loadouts[team.ID] = value
+		}
+	}
+}
+
+// recordGoldMarks appends a gold event per team the first time the game clock
+// passes each gold mark. Games without net worth (Valorant) record none.
+func recordGoldMarks(events []models.GridEvent, ev streamEvent, seriesID string, game int, at time.Time, marked map[int]bool) []models.GridEvent {
+	g := gameSnapshot(ev, game)
+	if g == nil {
+		return events
+	}
+	for _, minute := range goldMarks {
+		if marked[minute] || g.Clock.CurrentSeconds < minute*60 {
+			continue
+		}
+		for _, team := range g.Teams {
+			if team.NetWorth == 0 {
+				continue
+			}
+			marked[minute] = true
+			events = append(events, models.GridEvent{
+				SeriesID:    seriesID,
+				Sequence:    len(events) + 1,
+				GameNumber:  game,
+				Type:        models.EventGold,
+				SourceType:  ev.Type,
+				OccurredAt:  at,
+				ActorTeamID: team.ID,
+				ActorID:     team.ID,
+				Detail:      strconv.Itoa(minute),
+				Value:       team.NetWorth,
+			})
+		}
+	}
+	return events
+}
+
+// sortedKeys returns the keys of m in order, so parsed events are deterministic
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+const testEventStream = `{"occurredAt":"2024-07-01T12:00:00Z","events":[{"type":"series-started-game","actor":{"type":"series","id":"s1"},"target":{"type":"game","id":"g1","state":{"sequenceNumber":1,"map":{"name":"ascent"}}}}]}
+{"occurredAt":"2024-07-01T12:01:00Z","events":[{"type":"game-started-round","actor":{"type":"game","id":"g1"},"target":{"type":"round","id":"r1"}}]}
+{"occurredAt":"2024-07-01T12:01:10Z","events":[{"type":"player-purchased-item","actor":{"type":"player","id":"p1"},"target":{"type":"item","id":"ghost"},"seriesState":{"games":[{"sequenceNumber":1,"teams":[{"id":"t1","loadoutValue":4000},{"id":"t2","players":[{"loadoutValue":800},{"loadoutValue":900}]}]}]}}]}
+{"occurredAt":"2024-07-01T12:01:30.5Z","events":[{"type":"player-killed-player","actor":{"type":"player","id":"p1","state":{"name":"TenZ","teamId":"t1"},"stateDelta":{"weaponKills":{"vandal":1}}},"target":{"type":"player","id":"p6","state":{"name":"Derke","teamId":"t2"}}},{"type":"player-damaged-player","actor":{"type":"player","id":"p1"},"target":{"type":"player","id":"p7"}}]}
+{"occurredAt":"2024-07-01T12:02:00Z","events":[{"type":"player-completed-plantBomb","actor":{"type":"player","id":"p7","state":{"name":"Chronicle","teamId":"t2","site":"B"}},"target":{"type":"plantBomb","id":"plant"}}]}
+{"occurredAt":"2024-07-01T12:02:40Z","events":[{"type":"team-won-round","actor":{"type":"team","id":"t2","state":{"name":"FNATIC"}},"target":{"type":"round","id":"r1"}}]}
+{"occurredAt":"2024-07-01T12:03:00Z","events":[{"type":"game-started-round","actor":{"type":"game","id":"g1"},"target":{"type":"round","id":"r2"}}]}
+{"occurredAt":"2024-07-01T13:00:00Z","events":[{"type":"series-started-game","actor":{"type":"series","id":"s1"},"target":{"type":"game","id":"g2","state":{"map":{"name":"bind"}}}}]}
+`
+
+func TestParseEventStream(t *testing.T) {
+	events, err := parseEventStream(strings.NewReader(testEventStream), "s1")
+	if err != nil {
+		t.Fatalf("parseEventStream: %v", err)
+	}
+
+	want := []struct {
+		eventType   string
+		game, round int
+	}{
+		{models.EventGameStart, 1, 0},
+		{models.EventRoundStart, 1, 1},
+		{models.EventBuy, 1, 1},
+		{models.EventBuy, 1, 1},
+		{models.EventKill, 1, 1},
+		{models.EventBombPlant, 1, 1},
+		{models.EventRoundEnd, 1, 1},
+		{models.EventRoundStart, 1, 2},
+		{models.EventGameStart, 2, 0},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d (damage and purchase events are dropped): %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Type != w.eventType || e.GameNumber != w.game || e.RoundNumber != w.round || e.Sequence != i+1 {
+			t.Errorf("event %d = %s game %d round %d seq %d, want %s game %d round %d",
+				i, e.Type, e.GameNumber, e.RoundNumber, e.Sequence, w.eventType, w.game, w.round)
+		}
+	}
+
+	if buy := events[2]; buy.ActorTeamID != "t1" || buy.Value != 4000 {
+		t.Errorf("first buy = %+v, want t1 at 4000", buy)
+	}
+	if buy := events[3]; buy.ActorTeamID != "t2" || buy.Value != 1700 {
+		t.Errorf("second buy = %+v, want t2 summed from players to 1700", buy)
+	}
+
+	kill := events[4]
+	if kill.ActorTeamID != "t1" || kill.ActorName != "TenZ" || kill.TargetID != "p6" || kill.TargetTeamID != "t2" || kill.Weapon != "vandal" {
+		t.Errorf("kill = %+v", kill)
+	}
+	if kill.OccurredAt.Second() != 30 || kill.SeriesID != "s1" {
+		t.Errorf("kill time/series = %v %s", kill.OccurredAt, kill.SeriesID)
+	}
+	if plant := events[5]; plant.Detail != "B" || plant.ActorTeamID != "t2" {
+		t.Errorf("plant = %+v", plant)
+	}
+	if end := events[6]; end.ActorTeamID != "t2" || end.ActorName != "FNATIC" {
+		t.Errorf("round end = %+v, want won by t2", end)
+	}
+	if events[0].Detail != "ascent" || events[8].Detail != "bind" {
+		t.Errorf("maps = %q, %q", events[0].Detail, events[8].Detail)
+	}
+}
+
+func TestParseEventStreamRejectsBrokenLine(t *testing.T) {
+	stream := `{"occurredAt":"2024-07-01T12:00:00Z","events":[]}
+{"occurredAt":`
+	if _, err := parseEventStream(strings.NewReader(stream), "s1"); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v, want a line 2 parse error", err)
+	}
+}
+
+func TestKillWeapon(t *testing.T) {
+	var actor streamEntity
+	actor.StateDelta.WeaponKills = map[string]int{"vandal": 1, "phantom": 0, "classic": 1, "sheriff": 2}
+	for i := 0; i < 20; i++ {
+		if got := killWeapon(actor); got != "classic" {
+			t.Fatalf("killWeapon = %q, want classic", got)
+		}
+	}
+}
+
+func TestNormaliseEventType(t *testing.T) {
+	tests := []struct {
+		gridType, targetType, want string
+	}{
+		{"player-killed-player", "player", models.EventKill},
+		{"team-destroyed-tower", "tower", models.EventObjective},
+		{"player-killed-ATierNPC", "ATierNPC", models.EventObjective},
+		{"player-killed-minion", "minion", ""},
+		{"player-used-ability", "ability", ""},
+	}
+	for _, tt := range tests {
+		if got := normaliseEventType(tt.gridType, tt.targetType); got != tt.want {
+			t.Errorf("normaliseEventType(%q) = %q, want %q", tt.gridType, got, tt.want)
+		}
+	}
+}
+
+func TestParseEventStreamLoLTempo(t *testing.T) {
+	stream := `{"occurredAt":"2024-07-01T12:00:00Z","events":[{"type":"series-started-game","actor":{"type":"series","id":"s1"},"target":{"type":"game","id":"g1","state":{"sequenceNumber":1}}}]}
+{"occurredAt":"2024-07-01T12:09:00Z","events":[{"type":"team-killed-ATierNPC","actor":{"type":"team","id":"t1"},"target":{"type":"ATierNPC","id":"oceanDrake"},"seriesState":{"games":[{"sequenceNumber":1,"clock":{"currentSeconds":540},"teams":[{"id":"t1","netWorth":15000},{"id":"t2","netWorth":14000}]}]}}]}
+{"occurredAt":"2024-07-01T12:10:05Z","events":[{"type":"player-destroyed-towerPlate","actor":{"type":"player","id":"p1","state":{"teamId":"t2"}},"target":{"type":"towerPlate","id":"plate-1"},"seriesState":{"games":[{"sequenceNumber":1,"clock":{"currentSeconds":605},"teams":[{"id":"t1","netWorth":16500},{"id":"t2","netWorth":16800}]}]}}]}
+{"occurredAt":"2024-07-01T12:10:30Z","events":[{"type":"player-used-ability","actor":{"type":"player","id":"p1"},"target":{"type":"ability","id":"flash"},"seriesState":{"games":[{"sequenceNumber":1,"clock":{"currentSeconds":630},"teams":[{"id":"t1","netWorth":17000},{"id":"t2","netWorth":17000}]}]}}]}
+{"occurredAt":"2024-07-01T12:31:40Z","events":[{"type":"series-ended-game","actor":{"type":"series","id":"s1"},"target":{"type":"game","id":"g1"},"seriesState":{"games":[{"sequenceNumber":1,"clock":{"currentSeconds":1900},"teams":[{"id":"t1","won":false,"netWorth":50000},{"id":"t2","won":true,"netWorth":60000}]}]}}]}
+`
+	events, err := parseEventStream(strings.NewReader(stream), "s1")
+	if err != nil {
+		t.Fatalf("parseEventStream: %v", err)
+	}
+
+	want := []string{
+		models.EventGameStart,
+		models.EventObjective,
+		models.EventObjective,
+		models.EventGold, models.EventGold, // 10:00 passed with the plate
+		models.EventGameEnd,
+		models.EventGold, models.EventGold, // 15:00 first seen at game end
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		if events[i].Type != w {
+			t.Errorf("event %d = %s, want %s", i, events[i].Type, w)
+		}
+	}
+
+	if plate := events[2]; plate.ActorTeamID != "t2" || plate.SourceType != "player-destroyed-towerPlate" {
+		t.Errorf("plate = %+v", plate)
+	}
+	if gold := events[3]; gold.Detail != "10" || gold.ActorTeamID != "t1" || gold.Value != 16500 {
+		t.Errorf("gold at 10 = %+v, want t1 at 16500", gold)
+	}
+	if end := events[5]; end.ActorTeamID != "t2" || end.Value != 1900 {
+		t.Errorf("game end = %+v, want won by t2 at 1900s", end)
+	}
+	if gold := events[7]; gold.Detail != "15" || gold.Value != 60000 {
+		t.Errorf("gold at 15 = %+v", gold)
+	}
+}
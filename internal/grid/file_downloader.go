@@ -37,83 +37,94 @@ type FileStatus struct {
 // DownloadAndParseSeriesData downloads end-state JSON file and parses it into team stats
 func (fd *FileDownloader) DownloadAndParseSeriesData(ctx context.Context, seriesID string, title string) (map[string]*models.SeriesStats, error) {
 	// Step 1: Check if file is ready using the list endpoint
+	if err := fd.checkFileReady(ctx, seriesID, "end-state"); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Download the end-state file
+	body, err := fd.download(ctx, fmt.Sprintf("https://api.grid.gg/file-download/end-state/grid/series/%s", seriesID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	// Step 3: Parse the JSON end-state file
+	return fd.parseEndState(body)
+}
+
+// checkFileReady uses the list endpoint to check that the series file whose ID
+// contains fileID can be downloaded, explaining why not when it can't
+func (fd *FileDownloader) checkFileReady(ctx context.Context, seriesID, fileID string) error {
 	listURL := fmt.Sprintf("https://api.grid.gg/file-download/list/%s", seriesID)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", listURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create list request: %w", err)
+		return fmt.Errorf("failed to create list request: %w", err)
 	}
 	req.Header.Set("x-api-key", fd.apiKey)
 
 	resp, err := fd.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check file status: %w", err)
+		return fmt.Errorf("failed to check file status: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("series %s not found or no files available", seriesID)
+		return fmt.Errorf("series %s not found or no files available", seriesID)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("file list check failed with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("file list check failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var fileStatus FileStatus
 	if err := json.NewDecoder(resp.Body).Decode(&fileStatus); err != nil {
-		return nil, fmt.Errorf("failed to parse file status: %w", err)
+		return fmt.Errorf("failed to parse file status: %w", err)
 	}
 
-	// Check if end-state file is ready
-	var endStateReady bool
 	for _, file := range fileStatus.Files {
-		if strings.Contains(file.ID, "end-state") && file.Status == "ready" {
-			endStateReady = true
-			break
+		if strings.Contains(file.ID, fileID) && file.Status == "ready" {
+			return nil
 		}
 	}
 
-	if !endStateReady {
-		// Check for status messages
-		if len(fileStatus.Files) > 0 {
-			status := fileStatus.Files[0].Status
-			switch status {
-			case "match-not-started":
-				return nil, fmt.Errorf("series has not started yet")
-			case "match-in-progress":
-				return nil, fmt.Errorf("series is still in progress")
-			case "processing":
-				return nil, fmt.Errorf("series data is being processed, try again in a few minutes")
-			case "file-not-available":
-				return nil, fmt.Errorf("no data available for this series")
-			}
+	// Check for status messages
+	if len(fileStatus.Files) > 0 {
+		status := fileStatus.Files[0].Status
+		switch status {
+		case "match-not-started":
+			return fmt.Errorf("series has not started yet")
+		case "match-in-progress":
+			return fmt.Errorf("series is still in progress")
+		case "processing":
+			return fmt.Errorf("series data is being processed, try again in a few minutes")
+		case "file-not-available":
+			return fmt.Errorf("no data available for this series")
 		}
-		return nil, fmt.Errorf("end-state file not ready for series %s", seriesID)
 	}
+	return fmt.Errorf("%s file not ready for series %s", fileID, seriesID)
+}
 
-	// Step 2: Download the end-state file
-	downloadURL := fmt.Sprintf("https://api.grid.gg/file-download/end-state/grid/series/%s", seriesID)
-
-	downloadReq, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
+// download opens a file download; the caller closes the body
+func (fd *FileDownloader) download(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create download request: %w", err)
 	}
-	downloadReq.Header.Set("x-api-key", fd.apiKey)
+	req.Header.Set("x-api-key", fd.apiKey)
 
-	downloadResp, err := fd.httpClient.Do(downloadReq)
+	resp, err := fd.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download file: %w", err)
 	}
-	defer downloadResp.Body.Close()
 
-	if downloadResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(downloadResp.Body)
-		return nil, fmt.Errorf("download failed with status %d: %s", downloadResp.StatusCode, string(body))
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("download failed with status %d: %s", resp.StatusCode, string(body))
 	}
-
-	// Step 3: Parse the JSON end-state file
-	return fd.parseEndState(downloadResp.Body)
+	return resp.Body, nil
 }
 
 // parseEndState parses the end-state JSON format
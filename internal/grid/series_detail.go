@@ -0,0 +1,219 @@
+package grid
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// GetSeriesDetail fetches the full Series State of one series: both teams, each
+// game's map, score and winner, and every player's K/D/A per game and in total.
+// Unlike GetSeriesOutcome it also returns series that are still running.
+func (c *Client) GetSeriesDetail(ctx context.Context, seriesID string) (*models.SeriesDetail, error) {
+	query := `
+		query($seriesId: ID!) {
+			seriesState(id: $seriesId) {
+				id
+				finished
+				teams {
+					id
+					name
+					won
+					score
+				}
+				games {
+					sequenceNumber
+					finished
+					map {
+						name
+					}
+					teams {
+						id
+						name
+						won
+						score
+						players {
+							id
+							name
+							kills
+							deaths
+							killAssistsGiven
+						}
+					}
+				}
+			}
+		}
+	`
+
+	req := c.newRequest(query)
+	req.Var("seriesId", seriesID)
+
+	var resp struct {
+		SeriesState *struct {
+			ID       string `json:"id"`
+			Finished bool   `json:"finished"`
+			Teams    []struct {
+				ID    string `json:"id"`
+				Name  string `json:"name"`
+				Won   bool   `json:"won"`
+				Score int    `json:"score"`
+			} `json:"teams"`
+			Games []struct {
+				SequenceNumber int  `json:"sequenceNumber"`
+				Finished       bool `json:"finished"`
+				Map            struct {
+					Name string `json:"name"`
+				} `json:"map"`
+				Teams []struct {
+					ID      string `json:"id"`
+					Name    string `json:"name"`
+					Won     bool   `json:"won"`
+					Score   int    `json:"score"`
+					Players []struct {
+						ID               string `json:"id"`
+						Name             string `json:"name"`
+						Kills            int    `json:"kills"`
+						Deaths           int    `json:"deaths"`
+						KillAssistsGiven int    `json:"killAssistsGiven"`
+					} `json:"players"`
+				} `json:"teams"`
+			} `json:"games"`
+		} `json:"seriesState"`
+	}
+
+	if err := c.statsClient.Run(ctx, req, &resp); err != nil {
+		return nil, fmt.Errorf("series state API error: %w", err)
+	}
+	if resp.SeriesState == nil {
+		return nil, &SeriesNotFoundError{SeriesID: seriesID}
+	}
+
+	state := resp.SeriesState
+	detail := &models.SeriesDetail{
+		SeriesID: seriesID,
+		Finished: state.Finished,
+		Teams:    []models.SeriesTeamDetail{},
+		Games:    []models.GameDetail{},
+	}
+
+	teamIndex := make(map[string]int)
+	playerTotals := make(map[string]map[string]*models.PlayerLine) // team ID -> player ID
+	for _, team := range state.Teams {
+		teamIndex[team.ID] = len(detail.Teams)
+		detail.Teams = append(detail.Teams, models.SeriesTeamDetail{
+			ID:    team.ID,
+			Name:  team.Name,
+			Won:   team.Won,
+			Score: team.Score,
+		})
+		playerTotals[team.ID] = make(map[string]*models.PlayerLine)
+		if team.Won {
+			detail.WinnerID = team.ID
+		}
+	}
+
+	for i, game := range state.Games {
+		number := game.SequenceNumber
+		if number == 0 {
+			number = i + 1
+		}
+		gd := models.GameDetail{
+			Number:   number,
+			Map:      game.Map.Name,
+			Finished: game.Finished,
+			Teams:    []models.GameTeamDetail{},
+		}
+
+		for _, team := range game.Teams {
+			gt := models.GameTeamDetail{
+				ID:      team.ID,
+				Name:    team.Name,
+				Won:     team.Won,
+				Score:   team.Score,
+				Players: []models.PlayerLine{},
+			}
+			if team.Won {
+				gd.WinnerID = team.ID
+			}
+
+			for _, player := range team.Players {
+				line := models.PlayerLine{
+					ID:      player.ID,
+					Name:    player.Name,
+					Kills:   player.Kills,
+					Deaths:  player.Deaths,
+					Assists: player.KillAssistsGiven,
+				}
+				line.KDRatio = kdRatio(line.Kills, line.Deaths)
+				gt.Players = append(gt.Players, line)
+				gt.Kills += line.Kills
+				gt.Deaths += line.Deaths
+				gt.Assists += line.Assists
+
+				totals, ok := playerTotals[team.ID]
+				if !ok {
+					continue
+				}
+				total := totals[player.ID]
+				if total == nil {
+					total = &models.PlayerLine{ID: player.ID, Name: player.Name}
+					totals[player.ID] = total
+				}
+				total.Kills += line.Kills
+				total.Deaths += line.Deaths
+				total.Assists += line.Assists
+			}
+			sortPlayerLines(gt.Players)
+			gd.Teams = append(gd.Teams, gt)
+
+			if idx, ok := teamIndex[team.ID]; ok {
+				detail.Teams[idx].Kills += gt.Kills
+				detail.Teams[idx].Deaths += gt.Deaths
+				detail.Teams[idx].Assists += gt.Assists
+			}
+		}
+		detail.Games = append(detail.Games, gd)
+	}
+
+	for i := range detail.Teams {
+		team := &detail.Teams[i]
+		team.KDRatio = kdRatio(team.Kills, team.Deaths)
+		team.Players = []models.PlayerLine{}
+		for _, total := range playerTotals[team.ID] {
+			total.KDRatio = kdRatio(total.Kills, total.Deaths)
+			team.Players = append(team.Players, *total)
+		}
+		sortPlayerLines(team.Players)
+	}
+
+	return detail, nil
+}
+
+// SeriesNotFoundError is returned when Grid has no state for a series ID
+type SeriesNotFoundError struct {
+	SeriesID string
+}
+
+func (e *SeriesNotFoundError) Error() string {
+	return fmt.Sprintf("series %s not found", e.SeriesID)
+}
+
+// kdRatio is kills/deaths, or kills when there were no deaths
+func kdRatio(kills, deaths int) float64 {
+	if deaths == 0 {
+		return float64(kills)
+	}
+	return float64(kills) / float64(deaths)
+}
+
+// sortPlayerLines orders players by kills, then name, so responses are stable
+func sortPlayerLines(players []models.PlayerLine) {
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Kills != players[j].Kills {
+			return players[i].Kills > players[j].Kills
+		}
+		return players[i].Name < players[j].Name
+	})
+}
@@ -0,0 +1,379 @@
+package grid
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// DefaultTournamentIDs returns the hackathon tournaments used when a request does
+// not name any, or nil if the title has none configured
+func DefaultTournamentIDs(title string) []string {
+	switch strings.ToLower(title) {
+	case "valorant":
+		return []string{
+			"757371", "757481", "774782", // 2024
+			"775516", "800675", "826660", // 2025
+		}
+	case "lol", "leagueoflegends":
+		return []string{
+			"758024", "774794", "825490", "826679", // LCK
+			"758043", "774888", // LCS
+			"758077", "774622", "825468", "826906", // LEC
+			"758054", "774845", "775662", "825450", // LPL
+		}
+	default:
+		return nil
+	}
+}
+
+// TournamentSeries is a scheduled series as listed by the Central Data API
+type TournamentSeries struct {
+	ID           string
+	TournamentID string
+	StartTime    time.Time
+	Format       string
+	Teams        []SeriesTeam
+}
+
+// SeriesTeam identifies one side of a series
+type SeriesTeam struct {
+	ID   string
+	Name string
+}
+
+// ListTournamentSeries pages through every series in the given tournaments, oldest first
+func (c *Client) ListTournamentSeries(ctx context.Context, tournamentIDs []string) ([]TournamentSeries, error) {
+	query := `
+		query($tournamentIds: [ID!]!, $after: Cursor) {
+			allSeries(
+				filter: {
+					tournament: { id: { in: $tournamentIds }, includeChildren: { equals: true } }
+					types: ESPORTS
+				}
+				orderBy: StartTimeScheduled
+				orderDirection: ASC
+				first: 50
+				after: $after
+			) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				edges {
+					node {
+						id
+						startTimeScheduled
+						format {
+							nameShortened
+						}
+						tournament {
+							id
+						}
+						teams {
+							baseInfo {
+								id
+								name
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	var series []TournamentSeries
+	var cursor *string
+
+	for {
+		req := c.newRequest(query)
+		req.Var("tournamentIds", tournamentIDs)
+		req.Var("after", cursor)
+
+		var resp struct {
+			AllSeries struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Edges []struct {
+					Node struct {
+						ID                 string    `json:"id"`
+						StartTimeScheduled time.Time `json:"startTimeScheduled"`
+						Format             struct {
+							NameShortened string `json:"nameShortened"`
+						} `json:"format"`
+						Tournament struct {
+							ID string `json:"id"`
+						} `json:"tournament"`
+						Teams []struct {
+							BaseInfo struct {
+								ID   string `json:"id"`
+								Name string `json:"name"`
+							} `json:"baseInfo"`
+						} `json:"teams"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"allSeries"`
+		}
+
+		if err := c.gqlClient.Run(ctx, req, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list tournament series: %w", err)
+		}
+
+		for _, edge := range resp.AllSeries.Edges {
+			node := edge.Node
+			s := TournamentSeries{
+				ID:           node.ID,
+				TournamentID: node.Tournament.ID,
+				StartTime:    node.StartTimeScheduled,
+				Format:       strings.ToUpper(node.Format.NameShortened),
+			}
+			for _, team := range node.Teams {
+				s.Teams = append(s.Teams, SeriesTeam{ID: team.BaseInfo.ID, Name: team.BaseInfo.Name})
+			}
+			series = append(series, s)
+		}
+
+		if !resp.AllSeries.PageInfo.HasNextPage || resp.AllSeries.PageInfo.EndCursor == "" {
+			break
+		}
+		next := resp.AllSeries.PageInfo.EndCursor
+		cursor = &next
+	}
+
+	fmt.Printf("[DEBUG] Listed %d series across %d tournaments\n", len(series), len(tournamentIDs))
+	return series, nil
+}
+
+// TournamentStart returns the scheduled start of the earliest series in a tournament
+// (children included), used to anchor date ranges to "since tournament X"
+func (c *Client) TournamentStart(ctx context.Context, tournamentID string) (time.Time, error) {
+	query := `
+		query($tournamentIds: [ID!]!) {
+			allSeries(
+				filter: {
+					tournament: { id: { in: $tournamentIds }, includeChildren: { equals: true } }
+					types: ESPORTS
+				}
+				orderBy: StartTimeScheduled
+				orderDirection: ASC
+				first: 1
+			) {
+				edges {
+					node {
+						startTimeScheduled
+					}
+				}
+			}
+		}
+	`
+
+	req := c.newRequest(query)
+	req.Var("tournamentIds", []string{tournamentID})
+
+	var resp struct {
+		AllSeries struct {
+			Edges []struct {
+				Node struct {
+					StartTimeScheduled time.Time `json:"startTimeScheduled"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"allSeries"`
+	}
+
+	if err := c.gqlClient.Run(ctx, req, &resp); err != nil {
+		return time.Time{}, fmt.Errorf("failed to look up tournament %s: %w", tournamentID, err)
+	}
+	if len(resp.AllSeries.Edges) == 0 {
+		return time.Time{}, &TournamentNotFoundError{TournamentID: tournamentID}
+	}
+	return resp.AllSeries.Edges[0].Node.StartTimeScheduled, nil
+}
+
+// TournamentNotFoundError is returned when a tournament has no series to anchor to
+type TournamentNotFoundError struct {
+	TournamentID string
+}
+
+func (e *TournamentNotFoundError) Error() string {
+	return fmt.Sprintf("no series found for tournament %s", e.TournamentID)
+}
+
+// SeriesOutcome is the final state of a finished series
+type SeriesOutcome struct {
+	WinnerID string
+	Games    []models.SeriesGame
+	Stats    map[string]*models.SeriesStats   // keyed by team ID
+	Lineups  map[string][]models.RosterPlayer // keyed by team ID
+}
+
+// GetSeriesOutcome fetches the winner, per-game results and team stats of a
+// finished series. Games carry each team's side and, for LoL, the draft;
+// Lineups lists the players each team fielded.
+func (c *Client) GetSeriesOutcome(ctx context.Context, seriesID string) (*SeriesOutcome, error) {
+	query := `
+		query($seriesId: ID!) {
+			seriesState(id: $seriesId) {
+				finished
+				teams {
+					id
+					name
+					won
+					players {
+						id
+						name
+					}
+				}
+				games {
+					finished
+					map {
+						name
+					}
+					draftActions {
+						type
+						drafter {
+							id
+						}
+						draftable {
+							name
+						}
+					}
+					teams {
+						id
+						side
+						won
+						players {
+							kills
+							deaths
+						}
+					}
+				}
+			}
+		}
+	`
+
+	req := c.newRequest(query)
+	req.Var("seriesId", seriesID)
+
+	var resp struct {
+		SeriesState struct {
+			Finished bool `json:"finished"`
+			Teams    []struct {
+				ID      string `json:"id"`
+				Name    string `json:"name"`
+				Won     bool   `json:"won"`
+				Players []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"players"`
+			} `json:"teams"`
+			Games []struct {
+				Finished bool `json:"finished"`
+				Map      struct {
+					Name string `json:"name"`
+				} `json:"map"`
+				DraftActions []struct {
+					Type    string `json:"type"`
+					Drafter struct {
+						ID string `json:"id"`
+					} `json:"drafter"`
+					Draftable struct {
+						Name string `json:"name"`
+					} `json:"draftable"`
+				} `json:"draftActions"`
+				Teams []struct {
+					ID      string `json:"id"`
+					Side    string `json:"side"`
+					Won     bool   `json:"won"`
+					Players []struct {
+						Kills  int `json:"kills"`
+						Deaths int `json:"deaths"`
+					} `json:"players"`
+				} `json:"teams"`
+			} `json:"games"`
+		} `json:"seriesState"`
+	}
+
+	if err := c.statsClient.Run(ctx, req, &resp); err != nil {
+		return nil, fmt.Errorf("series state API error: %w", err)
+	}
+
+	if !resp.SeriesState.Finished {
+		return nil, fmt.Errorf("series has not finished yet")
+	}
+
+	outcome := &SeriesOutcome{
+		Stats:   make(map[string]*models.SeriesStats),
+		Lineups: make(map[string][]models.RosterPlayer),
+	}
+	for _, team := range resp.SeriesState.Teams {
+		if team.Won {
+			outcome.WinnerID = team.ID
+		}
+		for _, player := range team.Players {
+			if player.ID != "" {
+				outcome.Lineups[team.ID] = append(outcome.Lineups[team.ID], models.RosterPlayer{ID: player.ID, Name: player.Name})
+			}
+		}
+		outcome.Stats[team.ID] = &models.SeriesStats{
+			SeriesID: seriesID,
+			TeamID:   team.ID,
+			TeamName: team.Name,
+			Won:      team.Won,
+		}
+	}
+
+	if outcome.WinnerID == "" {
+		return nil, fmt.Errorf("series %s has no winner", seriesID)
+	}
+
+	for i, game := range resp.SeriesState.Games {
+		if !game.Finished {
+			continue
+		}
+
+		result := models.SeriesGame{SeriesID: seriesID, Number: i + 1, Map: game.Map.Name}
+		for _, action := range game.DraftActions {
+			kind := strings.ToLower(action.Type)
+			if (kind != models.DraftPick && kind != models.DraftBan) || action.Draftable.Name == "" {
+				continue
+			}
+			result.Draft = append(result.Draft, models.DraftAction{
+				Sequence: len(result.Draft) + 1,
+				TeamID:   action.Drafter.ID,
+				Action:   kind,
+				Champion: action.Draftable.Name,
+			})
+		}
+		for _, team := range game.Teams {
+			if team.Won {
+				result.WinnerID = team.ID
+			}
+			if team.Side != "" {
+				if result.Sides == nil {
+					result.Sides = make(map[string]string)
+				}
+				result.Sides[team.ID] = strings.ToLower(team.Side)
+			}
+			stats, ok := outcome.Stats[team.ID]
+			if !ok {
+				continue
+			}
+			stats.GamesPlayed++
+			if team.Won {
+				stats.Wins++
+			}
+			for _, player := range team.Players {
+				stats.Kills += player.Kills
+				stats.Deaths += player.Deaths
+			}
+		}
+		outcome.Games = append(outcome.Games, result)
+	}
+
+	return outcome, nil
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+type annotationRequest struct {
+	Title   string   `json:"title"`
+	Note    string   `json:"note"`
+	Tags    []string `json:"tags"`
+	Insight *struct {
+		Priority string `json:"priority"`
+		Message  string `json:"message"`
+	} `json:"insight"`
+}
+
+// ListTeamAnnotations returns the organisation's notes on a team
+func (h *Handler) ListTeamAnnotations(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	annotations, err := h.pgRepo.ListTeamAnnotations(principal.OrgID, c.Param("name"), strings.ToLower(c.Query("title")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team":        c.Param("name"),
+		"annotations": annotations,
+		"count":       len(annotations),
+	})
+}
+
+// CreateTeamAnnotation attaches a note, tags and/or a custom insight to a team
+func (h *Handler) CreateTeamAnnotation(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	annotation, ok := bindAnnotation(c)
+	if !ok {
+		return
+	}
+	annotation.OrgID = principal.OrgID
+	annotation.Author = principal.Actor()
+	annotation.TeamName = c.Param("name")
+
+	if err := h.pgRepo.CreateAnnotation(annotation); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, annotation)
+}
+
+// ListReportAnnotations returns the organisation's notes on a saved report
+func (h *Handler) ListReportAnnotations(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	id, valid := reportID(c)
+	if !valid {
+		return
+	}
+
+	annotations, err := h.pgRepo.ListReportAnnotations(principal.OrgID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reportId":    id,
+		"annotations": annotations,
+		"count":       len(annotations),
+	})
+}
+
+// CreateReportAnnotation attaches a note, tags and/or a custom insight to a saved report
+func (h *Handler) CreateReportAnnotation(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	id, valid := reportID(c)
+	if !valid {
+		return
+	}
+
+	// Make sure the report exists and belongs to the caller's organisation
+	saved, err := h.pgRepo.GetReport(principal.OrgID, id)
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	annotation, ok := bindAnnotation(c)
+	if !ok {
+		return
+	}
+	annotation.OrgID = principal.OrgID
+	annotation.Author = principal.Actor()
+	annotation.ReportID = saved.ID
+	if annotation.Title == "" {
+		annotation.Title = saved.Title
+	}
+
+	if err := h.pgRepo.CreateAnnotation(annotation); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, annotation)
+}
+
+// DeleteAnnotation removes a note. Only its author or an admin may delete it.
+func (h *Handler) DeleteAnnotation(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid annotation id"})
+		return
+	}
+
+	annotation, err := h.pgRepo.GetAnnotation(principal.OrgID, id)
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+	if annotation.Author != principal.Actor() && !principal.HasRole(models.RoleAdmin) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the author or an admin can delete this note"})
+		return
+	}
+
+	if err := h.pgRepo.DeleteAnnotation(principal.OrgID, id); err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bindAnnotation validates the request body. On failure it writes a 400 and returns false.
+func bindAnnotation(c *gin.Context) (*models.Annotation, bool) {
+	var req annotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON body"})
+		return nil, false
+	}
+
+	hasInsight := req.Insight != nil && strings.TrimSpace(req.Insight.Message) != ""
+	if strings.TrimSpace(req.Note) == "" && len(req.Tags) == 0 && !hasInsight {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "note, tags or insight is required",
+			"example": gin.H{
+				"title":   "valorant",
+				"note":    "Default to fast A-site executes on Ascent",
+				"tags":    []string{"fast A-site executes", "weak on Lotus"},
+				"insight": gin.H{"priority": "HIGH", "message": "Expect an A-site rush in pistol rounds"},
+			},
+		})
+		return nil, false
+	}
+
+	annotation := &models.Annotation{
+		ID:        uuid.New().String(),
+		Title:     strings.ToLower(req.Title),
+		Note:      strings.TrimSpace(req.Note),
+		Tags:      []string{},
+		CreatedAt: time.Now().UTC(),
+	}
+	for _, tag := range req.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			annotation.Tags = append(annotation.Tags, tag)
+		}
+	}
+
+	if hasInsight {
+		priority := strings.ToUpper(req.Insight.Priority)
+		if priority != "HIGH" && priority != "MEDIUM" && priority != "LOW" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "insight priority must be HIGH, MEDIUM or LOW"})
+			return nil, false
+		}
+		annotation.Insight = &models.KeyInsight{Priority: priority, Message: strings.TrimSpace(req.Insight.Message)}
+	}
+
+	return annotation, true
+}
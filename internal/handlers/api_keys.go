@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// APIKeyContextKey is where the auth middleware stores the authenticated *models.APIKey
+const APIKeyContextKey = "apiKey"
+
+// GetAPIKeyUsage returns request counts for the calling API key
+func (h *Handler) GetAPIKeyUsage(c *gin.Context) {
+	value, exists := c.Get(APIKeyContextKey)
+	apiKey, ok := value.(*models.APIKey)
+	if !exists || !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API key required"})
+		return
+	}
+
+	days := 1
+	if d, err := strconv.Atoi(c.Query("days")); err == nil && d > 0 && d <= 90 {
+		days = d
+	}
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -(days - 1))
+
+	usage, err := h.pgRepo.GetAPIKeyUsage(apiKey.ID, since)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	usedToday := 0
+	for _, u := range usage {
+		if u.Day.Format("2006-01-02") == now.Format("2006-01-02") {
+			usedToday += u.Requests
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"key":        apiKey.Prefix,
+		"name":       apiKey.Name,
+		"rateLimit":  apiKey.RateLimit,
+		"burst":      apiKey.Burst,
+		"dailyQuota": apiKey.DailyQuota,
+		"usedToday":  usedToday,
+		"usage":      usage,
+	})
+}
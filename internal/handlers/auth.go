@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/esports-scouting-backend/internal/auth"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// PrincipalContextKey is where the auth middleware stores the authenticated *auth.Principal
+const PrincipalContextKey = "principal"
+
+// principalFrom returns the authenticated caller, or nil
+func principalFrom(c *gin.Context) *auth.Principal {
+	value, _ := c.Get(PrincipalContextKey)
+	principal, _ := value.(*auth.Principal)
+	return principal
+}
+
+// requireOrg returns the caller if they belong to an organisation, otherwise responds 403
+func requireOrg(c *gin.Context) (*auth.Principal, bool) {
+	principal := principalFrom(c)
+	if !principal.HasOrg() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "organisation membership required",
+			"message": "Log in as an organisation user or use an API key bound to an organisation",
+		})
+		return nil, false
+	}
+	return principal, true
+}
+
+// requireEditor returns the caller if they may create or change organisation prep, otherwise responds 403
+func requireEditor(c *gin.Context) (*auth.Principal, bool) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return nil, false
+	}
+	if !principal.CanEdit() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "insufficient permissions",
+			"message": "Players have read-only access",
+		})
+		return nil, false
+	}
+	return principal, true
+}
+
+// Login exchanges email and password for a session token
+func (h *Handler) Login(c *gin.Context) {
+	var req struct {
+		Email    string `json:"email" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "email and password are required",
+			"example": gin.H{"email": "coach@team.gg", "password": "********"},
+		})
+		return
+	}
+
+	user, err := h.pgRepo.GetUserByEmail(strings.TrimSpace(req.Email))
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		log.Printf("[ERROR] Failed to look up user for login: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to look up user"})
+		return
+	}
+	if user == nil {
+		// Unknown email: spend the same bcrypt time as a wrong password
+		auth.RejectPassword(req.Password)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+		return
+	}
+	if !auth.CheckPassword(user.PasswordHash, req.Password) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+		return
+	}
+
+	token, expiresAt, err := h.tokens.Issue(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":     token,
+		"expiresAt": expiresAt.Format(time.RFC3339),
+		"user":      user,
+	})
+}
+
+// GetCurrentPrincipal describes the authenticated caller and their organisation
+func (h *Handler) GetCurrentPrincipal(c *gin.Context) {
+	principal := principalFrom(c)
+	response := gin.H{"principal": principal}
+
+	if principal.HasOrg() {
+		if org, err := h.pgRepo.GetOrganization(principal.OrgID); err == nil {
+			response["organization"] = org
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListOrgUsers lists members of the caller's organisation (admin only)
+func (h *Handler) ListOrgUsers(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	users, err := h.pgRepo.ListUsers(principal.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users": users,
+		"count": len(users),
+	})
+}
+
+// CreateOrgUser adds a member to the caller's organisation (admin only)
+func (h *Handler) CreateOrgUser(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Email    string      `json:"email" binding:"required"`
+		Name     string      `json:"name" binding:"required"`
+		Password string      `json:"password" binding:"required"`
+		Role     models.Role `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "email, name, password and role are required",
+			"example": gin.H{"email": "player@team.gg", "name": "Player One", "password": "at-least-10-chars", "role": "player"},
+		})
+		return
+	}
+	if !models.ValidRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      "invalid role",
+			"validRoles": []models.Role{models.RoleAnalyst, models.RoleCoach, models.RolePlayer, models.RoleAdmin},
+		})
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := &models.User{
+		ID:           uuid.New().String(),
+		OrgID:        principal.OrgID,
+		Email:        strings.ToLower(strings.TrimSpace(req.Email)),
+		Name:         req.Name,
+		Role:         req.Role,
+		PasswordHash: hash,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := h.pgRepo.CreateUser(user); err != nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "failed to create user",
+			"message": "A user with this email may already exist",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// UpdateOrgUserRole changes a member's role (admin only)
+func (h *Handler) UpdateOrgUserRole(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	userID := c.Param("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var req struct {
+		Role models.Role `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || !models.ValidRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      "a valid role is required",
+			"validRoles": []models.Role{models.RoleAnalyst, models.RoleCoach, models.RolePlayer, models.RoleAdmin},
+		})
+		return
+	}
+
+	if userID == principal.UserID && req.Role != models.RoleAdmin {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "admins cannot remove their own admin role"})
+		return
+	}
+
+	if err := h.pgRepo.UpdateUserRole(principal.OrgID, userID, req.Role); err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": userID, "role": req.Role})
+}
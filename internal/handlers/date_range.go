@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+const dateParamLayout = "2006-01-02"
+
+// dateRangeFromQuery reads from/to/sinceTournament/sincePatch, or a single patch,
+// into an explicit date range. It returns nil when none are given so callers keep
+// using timeWindow. On invalid input it writes the error response and returns false.
+func (h *Handler) dateRangeFromQuery(c *gin.Context, title string) (*models.DateRange, bool) {
+	fromParam := c.Query("from")
+	toParam := c.Query("to")
+	tournamentID := c.Query("sinceTournament")
+	sincePatch := c.Query("sincePatch")
+	patchVersion := c.Query("patch")
+
+	if patchVersion != "" {
+		if fromParam != "" || toParam != "" || tournamentID != "" || sincePatch != "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "patch cannot be combined with from, to, sinceTournament or sincePatch",
+				"message": "patch already fixes the range to the days that patch was live",
+			})
+			return nil, false
+		}
+		from, to, ok := h.patchRange(c, title, patchVersion)
+		if !ok {
+			return nil, false
+		}
+		if to.IsZero() {
+			to = models.OpenRangeEnd(time.Now())
+		}
+		return &models.DateRange{From: from, To: to, Anchor: fmt.Sprintf("patch %s", patchVersion)}, true
+	}
+
+	if fromParam == "" && toParam == "" && tournamentID == "" && sincePatch == "" {
+		return nil, true
+	}
+
+	anchors := 0
+	for _, param := range []string{fromParam, tournamentID, sincePatch} {
+		if param != "" {
+			anchors++
+		}
+	}
+	if anchors > 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "from, sinceTournament and sincePatch are mutually exclusive",
+			"message": "give either an explicit from date, a tournament or a patch to start from",
+		})
+		return nil, false
+	}
+
+	dateRange := &models.DateRange{To: models.OpenRangeEnd(time.Now())}
+
+	if toParam != "" {
+		to, err := parseDateParam(toParam, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":    "invalid to parameter",
+				"message":  "to must be YYYY-MM-DD or RFC3339",
+				"provided": toParam,
+			})
+			return nil, false
+		}
+		dateRange.To = to
+	}
+
+	switch {
+	case fromParam != "":
+		from, err := parseDateParam(fromParam, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":    "invalid from parameter",
+				"message":  "from must be YYYY-MM-DD or RFC3339",
+				"provided": fromParam,
+			})
+			return nil, false
+		}
+		dateRange.From = from
+	case tournamentID != "":
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		from, err := h.gridClient.TournamentStart(ctx, tournamentID)
+		if err != nil {
+			var notFound *grid.TournamentNotFoundError
+			if errors.As(err, &notFound) {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error":    notFound.Error(),
+					"message":  "sinceTournament must be a tournament ID with scheduled series",
+					"provided": tournamentID,
+				})
+				return nil, false
+			}
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return nil, false
+		}
+		dateRange.From = from
+		dateRange.Anchor = fmt.Sprintf("tournament %s", tournamentID)
+	case sincePatch != "":
+		from, _, ok := h.patchRange(c, title, sincePatch)
+		if !ok {
+			return nil, false
+		}
+		dateRange.From = from
+		dateRange.Anchor = fmt.Sprintf("since patch %s", sincePatch)
+	default:
+		dateRange.From = models.Last3Months.Cutoff(dateRange.To)
+	}
+
+	if !dateRange.From.Before(dateRange.To) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid date range",
+			"message": "from must be before to",
+			"from":    dateRange.From.Format(time.RFC3339),
+			"to":      dateRange.To.Format(time.RFC3339),
+		})
+		return nil, false
+	}
+
+	return dateRange, true
+}
+
+// patchRange looks up when a patch was live. Unknown versions get a 400 listing
+// the known ones; on failure it writes the error response and returns false.
+func (h *Handler) patchRange(c *gin.Context, title, version string) (from, to time.Time, ok bool) {
+	from, to, ok = h.patches.Range(title, version)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":     "unknown patch",
+			"message":   "patch must be a version from the patch calendar (see /api/v1/patches)",
+			"provided":  version,
+			"available": h.patches.Versions(title),
+		})
+	}
+	return from, to, ok
+}
+
+// checkTimeWindow accepts an empty (defaulted) or known time window. Otherwise it
+// writes a 400 listing the valid windows and returns false.
+func checkTimeWindow(c *gin.Context, timeWindow models.TimeWindow) bool {
+	if timeWindow == "" || timeWindow.Valid() {
+		return true
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":    "invalid timeWindow parameter",
+		"message":  "timeWindow must be one of the valid windows",
+		"provided": timeWindow,
+		"valid":    []models.TimeWindow{models.LastWeek, models.LastMonth, models.Last3Months, models.Last6Months, models.LastYear},
+	})
+	return false
+}
+
+// strictFromQuery reads strict=true|false, which stops the time window being
+// widened when it has too few matches. On invalid input it writes the error
+// response and returns false.
+func strictFromQuery(c *gin.Context) (bool, bool) {
+	param := c.Query("strict")
+	if param == "" {
+		return false, true
+	}
+	strict, err := strconv.ParseBool(param)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid strict parameter",
+			"message":  "strict must be true or false",
+			"provided": param,
+		})
+		return false, false
+	}
+	return strict, true
+}
+
+// parseDateParam accepts a calendar date or an RFC3339 timestamp. A bare date used
+// as an upper bound covers that whole day, since ranges exclude their end.
+func parseDateParam(value string, upper bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	day, err := time.Parse(dateParamLayout, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if upper {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
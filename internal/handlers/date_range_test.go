@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
+)
+
+const testPatchCalendar = `{
+	"valorant": [
+		{"version": "9.0", "start": "2024-06-25"},
+		{"version": "9.01", "start": "2024-07-09"}
+	]
+}`
+
+func day(s string) time.Time {
+	t, err := time.Parse(dateParamLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestDateRangeFromQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	patches, err := patch.Parse([]byte(testPatchCalendar))
+	if err != nil {
+		t.Fatalf("patch.Parse: %v", err)
+	}
+	h := &Handler{patches: patches}
+
+	// The zero time stands for "the defaulted end of an open range"
+	open := time.Time{}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int // 0 = accepted
+		wantNil    bool
+		wantFrom   time.Time
+		wantTo     time.Time
+		wantAnchor string
+	}{
+		{name: "no range", query: "", wantNil: true},
+		{name: "from only", query: "from=2024-06-01", wantFrom: day("2024-06-01"), wantTo: open},
+		{name: "from and to", query: "from=2024-06-01&to=2024-06-30", wantFrom: day("2024-06-01"), wantTo: day("2024-07-01")},
+		{name: "rfc3339 bounds", query: "from=2024-06-01T12:00:00Z&to=2024-06-02T08:00:00%2B02:00", wantFrom: day("2024-06-01").Add(12 * time.Hour), wantTo: day("2024-06-02").Add(6 * time.Hour)},
+		{name: "to only", query: "to=2024-06-30", wantFrom: day("2024-07-01").AddDate(0, -3, 0), wantTo: day("2024-07-01")},
+		{name: "since patch", query: "sincePatch=9.0", wantFrom: day("2024-06-25"), wantTo: open, wantAnchor: "since patch 9.0"},
+		{name: "past patch", query: "patch=9.0", wantFrom: day("2024-06-25"), wantTo: day("2024-07-09"), wantAnchor: "patch 9.0"},
+		{name: "current patch", query: "patch=9.01", wantFrom: day("2024-07-09"), wantTo: open, wantAnchor: "patch 9.01"},
+		{name: "bad from", query: "from=June", wantStatus: http.StatusBadRequest},
+		{name: "bad to", query: "from=2024-06-01&to=2024-13-01", wantStatus: http.StatusBadRequest},
+		{name: "from after to", query: "from=2024-07-01&to=2024-06-01", wantStatus: http.StatusBadRequest},
+		{name: "empty range", query: "from=2024-06-02T00:00:00Z&to=2024-06-02T00:00:00Z", wantStatus: http.StatusBadRequest},
+		{name: "from with sincePatch", query: "from=2024-06-01&sincePatch=9.0", wantStatus: http.StatusBadRequest},
+		{name: "from with sinceTournament", query: "from=2024-06-01&sinceTournament=123", wantStatus: http.StatusBadRequest},
+		{name: "sinceTournament with sincePatch", query: "sinceTournament=123&sincePatch=9.0", wantStatus: http.StatusBadRequest},
+		{name: "patch with from", query: "patch=9.0&from=2024-06-01", wantStatus: http.StatusBadRequest},
+		{name: "patch with to", query: "patch=9.0&to=2024-07-01", wantStatus: http.StatusBadRequest},
+		{name: "unknown patch", query: "patch=1.0", wantStatus: http.StatusBadRequest},
+		{name: "unknown sincePatch", query: "sincePatch=1.0", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+
+			before := time.Now()
+			got, ok := h.dateRangeFromQuery(c, "valorant")
+
+			if tt.wantStatus != 0 {
+				if ok || rec.Code != tt.wantStatus {
+					t.Fatalf("ok = %v, status = %d; want rejected with %d", ok, rec.Code, tt.wantStatus)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("rejected with %d: %s", rec.Code, rec.Body)
+			}
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("dateRange = %+v, want nil", got)
+				}
+				return
+			}
+
+			wantTo := tt.wantTo
+			if wantTo.IsZero() {
+				wantTo = models.OpenRangeEnd(before)
+			}
+			if !got.From.Equal(tt.wantFrom) || !got.To.Equal(wantTo) || got.Anchor != tt.wantAnchor {
+				t.Errorf("dateRange = %s..%s %q, want %s..%s %q", got.From, got.To, got.Anchor, tt.wantFrom, wantTo, tt.wantAnchor)
+			}
+		})
+	}
+}
+
+func TestParseDateParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		upper   bool
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "date as lower bound", value: "2024-06-01", want: day("2024-06-01")},
+		{name: "date as upper bound covers the day", value: "2024-06-01", upper: true, want: day("2024-06-02")},
+		{name: "rfc3339 is exact", value: "2024-06-01T10:30:00Z", upper: true, want: day("2024-06-01").Add(10*time.Hour + 30*time.Minute)},
+		{name: "rfc3339 offset normalised to UTC", value: "2024-06-01T10:30:00+02:00", want: day("2024-06-01").Add(8*time.Hour + 30*time.Minute)},
+		{name: "empty", value: "", wantErr: true},
+		{name: "wrong layout", value: "01/06/2024", wantErr: true},
+		{name: "impossible date", value: "2024-02-30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDateParam(tt.value, tt.upper)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateParam(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && (!got.Equal(tt.want) || got.Location() != time.UTC) {
+				t.Errorf("parseDateParam(%q, %v) = %s, want %s UTC", tt.value, tt.upper, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// GetTeamDraft returns a LoL team's priority picks, the bans used against it and
+// its record by side, from stored drafts
+func (h *Handler) GetTeamDraft(c *gin.Context) {
+	title := models.CanonicalTitle(c.DefaultQuery("title", "lol"))
+	if title != "lol" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "draft analysis is only available for lol",
+			"provided": title,
+			"example":  "/api/v1/teams/T1/draft?title=lol",
+		})
+		return
+	}
+
+	report, err := h.drafts.TeamDraft(title, c.Param("name"), nil)
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
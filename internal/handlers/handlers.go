@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/auth"
 	"github.com/yourusername/esports-scouting-backend/internal/grid"
 	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
 	"github.com/yourusername/esports-scouting-backend/internal/repository"
 	"github.com/yourusername/esports-scouting-backend/internal/services"
 	"github.com/yourusername/esports-scouting-backend/pkg/cache"
@@ -19,23 +22,37 @@ import (
 
 type Handler struct {
 	pgRepo        *repository.PostgresRepo
+	tokens        *auth.TokenManager
 	redisCache    *cache.RedisClient
 	gridClient    *grid.Client
 	compService   *services.ComparisonService
 	trendsService *services.TrendsService
 	metaService   *services.MetaService   // ✅ NEW
 	reportService *services.ReportService // ✅ NEW
+	predictions   *services.PredictionService
+	ratings       *services.RatingService
+	sites         *services.SiteService
+	drafts        *services.DraftService
+	rosters       *services.RosterService
+	patches       *patch.Calendar
 }
 
-func NewHandler(pg *repository.PostgresRepo, redis *cache.RedisClient, grid *grid.Client) *Handler {
+func NewHandler(pg *repository.PostgresRepo, redis *cache.RedisClient, grid *grid.Client, tokens *auth.TokenManager, alpha, halfLifeDays float64, patches *patch.Calendar) *Handler {
 	return &Handler{
 		pgRepo:        pg,
+		tokens:        tokens,
 		redisCache:    redis,
 		gridClient:    grid,
-		compService:   services.NewComparisonService(grid, redis, pg),
-		trendsService: services.NewTrendsService(grid, redis),
-		metaService:   services.NewMetaService(grid, redis),       //  NEW
-		reportService: services.NewReportService(grid, redis, pg), //  NEW
+		compService:   services.NewComparisonService(grid, redis, pg, alpha, halfLifeDays, patches),
+		trendsService: services.NewTrendsService(grid, redis, pg, patches),
+		metaService:   services.NewMetaService(grid, redis, pg, patches),                        //  NEW
+		reportService: services.NewReportService(grid, redis, pg, alpha, halfLifeDays, patches), //  NEW
+		predictions:   services.NewPredictionService(grid, redis, pg),
+		ratings:       services.NewRatingService(pg),
+		sites:         services.NewSiteService(pg),
+		drafts:        services.NewDraftService(pg),
+		rosters:       services.NewRosterService(pg),
+		patches:       patches,
 	}
 }
 
@@ -88,8 +105,58 @@ func (h *Handler) CompareTeams(c *gin.Context) {
 		return
 	}
 
+	if !checkTimeWindow(c, timeWindow) {
+		return
+	}
+
+	opts := models.CompareOptions{Weighting: c.DefaultQuery("weighting", models.WeightingUniform)}
+	if opts.Weighting != models.WeightingUniform && opts.Weighting != models.WeightingRecency {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid weighting parameter",
+			"message":  "weighting must be 'uniform' or 'recency'",
+			"provided": opts.Weighting,
+		})
+		return
+	}
+
+	halfLifeParam := c.Query("halfLifeDays")
+	if halfLifeParam != "" {
+		halfLife, err := strconv.ParseFloat(halfLifeParam, 64)
+		if err != nil || halfLife < 1 || halfLife > 365 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":    "invalid halfLifeDays parameter",
+				"message":  "halfLifeDays must be a number of days between 1 and 365",
+				"provided": halfLifeParam,
+			})
+			return
+		}
+		opts.HalfLifeDays = halfLife
+	}
+
+	dateRange, ok := h.dateRangeFromQuery(c, title)
+	if !ok {
+		return
+	}
+	opts.DateRange = dateRange
+
+	sinceRosterChange, ok := sinceRosterChangeFromQuery(c, dateRange)
+	if !ok {
+		return
+	}
+	opts.SinceRosterChange = sinceRosterChange
+
+	strict, ok := strictFromQuery(c)
+	if !ok {
+		return
+	}
+	opts.Strict = strict
+
 	if timeWindow == "" {
 		timeWindow = models.Last3Months
+		// Decay does the windowing, so look further back by default
+		if opts.Weighting == models.WeightingRecency {
+			timeWindow = models.LastYear
+		}
 	}
 
 	var tournamentIDs []string
@@ -100,7 +167,7 @@ func (h *Handler) CompareTeams(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 45*time.Second)
 	defer cancel()
 
-	cacheKey := fmt.Sprintf("compare:%s:%s:%s:%s:%s", team1, team2, title, timeWindow, tournamentIDsParam)
+	cacheKey := fmt.Sprintf("compare:%s:%s:%s:%s:%s:%s:%s:%s:%t:%t", team1, team2, title, timeWindow, tournamentIDsParam, opts.Weighting, halfLifeParam, dateRange.Key(), strict, sinceRosterChange)
 	var cachedReport models.ComparisonReport
 	err := h.redisCache.Get(ctx, cacheKey, &cachedReport)
 	if err == nil {
@@ -109,7 +176,7 @@ func (h *Handler) CompareTeams(c *gin.Context) {
 		return
 	}
 
-	report, err := h.compService.CompareTeams(ctx, team1, team2, title, timeWindow, tournamentIDs)
+	report, err := h.compService.CompareTeams(ctx, team1, team2, title, timeWindow, tournamentIDs, opts)
 	if err != nil {
 		log.Printf("[ERROR] Comparison failed: %v", err)
 
@@ -155,6 +222,12 @@ func (h *Handler) CompareTeams(c *gin.Context) {
 			return
 		}
 
+		// sinceRosterChange needs the team and its lineups stored by sync-series
+		if errors.Is(err, repository.ErrNotFound) {
+			respondRepoError(c, err)
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -178,6 +251,20 @@ func (h *Handler) GetTeamTrends(c *gin.Context) {
 		return
 	}
 
+	dateRange, ok := h.dateRangeFromQuery(c, title)
+	if !ok {
+		return
+	}
+	sinceRosterChange, ok := sinceRosterChangeFromQuery(c, dateRange)
+	if !ok {
+		return
+	}
+	if sinceRosterChange {
+		if dateRange, ok = h.lineupRange(c, title, teamName); !ok {
+			return
+		}
+	}
+
 	var tournamentIDs []string
 	if tournamentIDsParam != "" {
 		tournamentIDs = strings.Split(tournamentIDsParam, ",")
@@ -186,7 +273,7 @@ func (h *Handler) GetTeamTrends(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	cacheKey := fmt.Sprintf("trends:%s:%s:%s", teamName, title, tournamentIDsParam)
+	cacheKey := fmt.Sprintf("trends:%s:%s:%s:%s", teamName, title, tournamentIDsParam, dateRange.Key())
 	var cachedTrends models.TrendReport
 	err := h.redisCache.Get(ctx, cacheKey, &cachedTrends)
 	if err == nil {
@@ -195,51 +282,10 @@ func (h *Handler) GetTeamTrends(c *gin.Context) {
 		return
 	}
 
-	trends, err := h.trendsService.AnalyzeTrends(ctx, teamName, title, tournamentIDs)
+	trends, err := h.trendsService.AnalyzeTrends(ctx, teamName, title, tournamentIDs, dateRange)
 	if err != nil {
 		log.Printf("[ERROR] Trends analysis failed: %v", err)
-
-		// ✅ Check for InsufficientDataError (404 - team exists but no data)
-		var dataErr *grid.InsufficientDataError
-		if errors.As(err, &dataErr) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   dataErr.Error(),
-				"team":    dataErr.TeamName,
-				"reason":  dataErr.Reason,
-				"message": "Team has insufficient data available. Try a team with recent matches.",
-			})
-			return
-		}
-
-		// Check for TeamNotFoundError (404 - team doesn't exist)
-		var teamErr *grid.TeamNotFoundError
-		if errors.As(err, &teamErr) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":          teamErr.Error(),
-				"team":           teamErr.TeamName,
-				"availableTeams": teamErr.AvailableTeams,
-				"message":        fmt.Sprintf("Team '%s' did not play in the available tournaments. Check the title val or lol and try again", teamErr.TeamName),
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "no teams found matching name") {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   err.Error(),
-				"message": "Team not found in this game title. Check the team name and title parameter.",
-			})
-			return
-		}
-
-		if errors.Is(err, context.DeadlineExceeded) {
-			c.JSON(http.StatusGatewayTimeout, gin.H{
-				"error":   "Request timeout",
-				"message": "The analysis took too long to complete. Try again later.",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTrendsError(c, err)
 		return
 	}
 
@@ -257,6 +303,7 @@ func (h *Handler) GetTeamTrends(c *gin.Context) {
 func (h *Handler) GetMeta(c *gin.Context) {
 	title := c.Query("title")
 	tournamentID := c.Query("tournamentId")
+	patchVersion := c.Query("patch")
 
 	if title == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -266,14 +313,20 @@ func (h *Handler) GetMeta(c *gin.Context) {
 		return
 	}
 
+	if patchVersion != "" {
+		if _, _, ok := h.patchRange(c, title, patchVersion); !ok {
+			return
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
 	defer cancel()
 
-	report, err := h.metaService.AnalyzeMeta(ctx, title, tournamentID)
+	report, err := h.metaService.AnalyzeMeta(ctx, title, tournamentID, patchVersion)
 	if err != nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{
 			"error":   err.Error(),
-			"message": "Meta analysis requires Grid.gg pick/ban data API (not available in current tier)",
+			"message": "Pick data requires Grid.gg pick/ban data API (not available in current tier); map pools per patch need series synced with `admin sync-series`",
 			"note":    "Use team statistics endpoints for performance analysis",
 		})
 		return
@@ -299,10 +352,28 @@ func (h *Handler) GenerateScoutingReport(c *gin.Context) {
 		return
 	}
 
+	if !checkTimeWindow(c, timeWindow) {
+		return
+	}
 	if timeWindow == "" {
 		timeWindow = models.Last3Months
 	}
 
+	dateRange, ok := h.dateRangeFromQuery(c, title)
+	if !ok {
+		return
+	}
+
+	sinceRosterChange, ok := sinceRosterChangeFromQuery(c, dateRange)
+	if !ok {
+		return
+	}
+
+	strict, ok := strictFromQuery(c)
+	if !ok {
+		return
+	}
+
 	var tournamentIDs []string
 	if tournamentIDsParam != "" {
 		tournamentIDs = strings.Split(tournamentIDsParam, ",")
@@ -311,7 +382,7 @@ func (h *Handler) GenerateScoutingReport(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
 	defer cancel()
 
-	report, err := h.reportService.GenerateScoutingReport(ctx, opponent, myTeam, title, timeWindow, tournamentIDs)
+	report, err := h.reportService.GenerateScoutingReport(ctx, opponent, myTeam, title, timeWindow, tournamentIDs, dateRange, strict, sinceRosterChange)
 	if err != nil {
 		log.Printf("[ERROR] Scouting report generation failed: %v", err)
 
@@ -333,10 +404,18 @@ func (h *Handler) GenerateScoutingReport(c *gin.Context) {
 			return
 		}
 
+		// sinceRosterChange needs the teams and their lineups stored by sync-series
+		if errors.Is(err, repository.ErrNotFound) {
+			respondRepoError(c, err)
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	h.finalizeReport(c, report, timeWindow)
+
 	log.Printf("[SUCCESS] Generated scouting report in %v (cached: %v)", time.Since(start), report.CacheStatus.FromCache)
 	c.JSON(http.StatusOK, report)
 }
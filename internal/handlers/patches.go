@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
+)
+
+// GetPatches lists a title's patch calendar, the versions accepted by patch= and sincePatch=
+func (h *Handler) GetPatches(c *gin.Context) {
+	title := strings.ToLower(c.Query("title"))
+
+	if title != "valorant" && title != "lol" && title != "leagueoflegends" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid title parameter",
+			"message":  "title must be 'valorant' or 'lol'",
+			"provided": title,
+			"example":  "/api/v1/patches?title=valorant",
+		})
+		return
+	}
+
+	patches := h.patches.Patches(title)
+	if patches == nil {
+		patches = []patch.Patch{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"title":   title,
+		"patches": patches,
+		"count":   len(patches),
+	})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/services"
+)
+
+// PredictMatch returns a calibrated series win probability for team1 against team2
+func (h *Handler) PredictMatch(c *gin.Context) {
+	start := time.Now()
+	team1 := c.Query("team1")
+	team2 := c.Query("team2")
+	title := strings.ToLower(c.Query("title"))
+
+	if team1 == "" || team2 == "" || title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "team1, team2, and title are required",
+			"example": "/api/v1/predict?team1=Cloud9&team2=Sentinels&title=valorant",
+		})
+		return
+	}
+
+	if title != "valorant" && title != "lol" && title != "leagueoflegends" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid title parameter",
+			"message":  "title must be 'valorant' or 'lol'",
+			"provided": title,
+		})
+		return
+	}
+
+	var tournamentIDs []string
+	if param := c.Query("tournamentIds"); param != "" {
+		tournamentIDs = strings.Split(param, ",")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 45*time.Second)
+	defer cancel()
+
+	prediction, err := h.predictions.Predict(ctx, team1, team2, title, tournamentIDs)
+	if err != nil {
+		log.Printf("[ERROR] Prediction failed: %v", err)
+
+		if errors.Is(err, services.ErrNoRatingHistory) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   err.Error(),
+				"title":   title,
+				"message": "Predictions use series stored in the database. Check the team names or sync the title's tournaments first.",
+			})
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.JSON(http.StatusGatewayTimeout, gin.H{
+				"error":   "Request timeout",
+				"message": "The request took too long to complete. Try again.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Printf("PredictMatch took %v", time.Since(start))
+	c.JSON(http.StatusOK, prediction)
+}
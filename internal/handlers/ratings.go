@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRatings returns the Glicko-2 leaderboard for a title
+func (h *Handler) GetRatings(c *gin.Context) {
+	title := strings.ToLower(c.Query("title"))
+	if title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "title is required",
+			"example": "/api/v1/ratings?title=valorant&limit=25",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	ratings, err := h.ratings.Leaderboard(title, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(ratings) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "no ratings for " + title,
+			"message": "Ratings are computed from stored series. Sync the title's tournaments first.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"title":     title,
+		"ratings":   ratings,
+		"count":     len(ratings),
+		"updatedAt": ratings[0].UpdatedAt,
+	})
+}
+
+// GetRatingHistory returns a team's rating after every stored series
+func (h *Handler) GetRatingHistory(c *gin.Context) {
+	title := strings.ToLower(c.Query("title"))
+	if title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "title is required",
+			"example": "/api/v1/teams/Cloud9/rating-history?title=valorant",
+		})
+		return
+	}
+
+	team, history, err := h.ratings.History(title, c.Param("name"))
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team":    team,
+		"history": history,
+		"count":   len(history),
+	})
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+	"github.com/yourusername/esports-scouting-backend/internal/services"
+)
+
+// GetSavedReport returns a saved scouting report by ID (permalink target)
+func (h *Handler) GetSavedReport(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	id, valid := reportID(c)
+	if !valid {
+		return
+	}
+
+	saved, err := h.pgRepo.GetReport(principal.OrgID, id)
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+	saved.Permalink = models.ReportPermalink(saved.ID)
+	h.annotateSavedReport(principal.OrgID, saved.Report)
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// ListSavedReports lists the organisation's saved reports, optionally filtered by team
+func (h *Handler) ListSavedReports(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	filter := models.SavedReportFilter{
+		Team:            c.Query("team"),
+		Title:           c.Query("title"),
+		PinnedOnly:      c.Query("pinned") == "true",
+		IncludeArchived: c.Query("archived") == "true",
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil {
+		filter.Limit = limit
+	}
+
+	reports, err := h.pgRepo.ListReports(principal.OrgID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	for i := range reports {
+		reports[i].Permalink = models.ReportPermalink(reports[i].ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reports": reports,
+		"count":   len(reports),
+	})
+}
+
+// UpdateSavedReport pins/unpins or archives/unarchives a saved report
+func (h *Handler) UpdateSavedReport(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	id, valid := reportID(c)
+	if !valid {
+		return
+	}
+
+	var req struct {
+		Pinned   *bool `json:"pinned"`
+		Archived *bool `json:"archived"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Pinned == nil && req.Archived == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "pinned and/or archived is required",
+			"example": gin.H{"pinned": true},
+		})
+		return
+	}
+
+	if err := h.pgRepo.UpdateReportFlags(principal.OrgID, id, req.Pinned, req.Archived); err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	saved, err := h.pgRepo.GetReport(principal.OrgID, id)
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+	saved.Report = nil
+	saved.Permalink = models.ReportPermalink(saved.ID)
+
+	c.JSON(http.StatusOK, saved)
+}
+
+func reportID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report id"})
+		return "", false
+	}
+	return id, true
+}
+
+// DiffSavedReport compares a saved report (or ?against=<id>, another saved report) with a fresh regeneration
+func (h *Handler) DiffSavedReport(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	id, valid := reportID(c)
+	if !valid {
+		return
+	}
+
+	base, err := h.pgRepo.GetReport(principal.OrgID, id)
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+	h.annotateSavedReport(principal.OrgID, base.Report)
+
+	var current *models.ScoutingReport
+	if againstID := c.Query("against"); againstID != "" {
+		if _, err := uuid.Parse(againstID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid against report id"})
+			return
+		}
+		against, err := h.pgRepo.GetReport(principal.OrgID, againstID)
+		if err != nil {
+			respondRepoError(c, err)
+			return
+		}
+		h.annotateSavedReport(principal.OrgID, against.Report)
+		current = against.Report
+	} else {
+		current, ok = h.regenerateReport(c, base.Report.Matchup, base.TimeWindow)
+		if !ok {
+			return
+		}
+	}
+
+	diff, err := services.DiffReports(base.Report, current)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// DiffPostedReport compares a report posted back by the client with a fresh regeneration
+func (h *Handler) DiffPostedReport(c *gin.Context) {
+	var req struct {
+		Base *models.ScoutingReport `json:"base" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Base.Matchup.Opponent == "" || req.Base.Matchup.YourTeam == "" || req.Base.Matchup.Title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "base must be a scouting report with a matchup",
+			"example": gin.H{"base": gin.H{"matchup": gin.H{"opponent": "G2 Esports", "yourTeam": "Cloud9", "title": "valorant"}}},
+		})
+		return
+	}
+
+	// Regenerate over what the base asked for; reports saved before
+	// requestedTimeRange existed only have the window in timeRange
+	timeWindow := models.TimeWindow(c.Query("timeWindow"))
+	if timeWindow == "" {
+		timeWindow = req.Base.Comparison.DataQuality.RequestedTimeRange
+	}
+	if timeWindow == "" {
+		timeWindow = req.Base.Comparison.DataQuality.TimeRange
+	}
+	if !checkTimeWindow(c, timeWindow) {
+		return
+	}
+
+	current, ok := h.regenerateReport(c, req.Base.Matchup, timeWindow)
+	if !ok {
+		return
+	}
+
+	diff, err := services.DiffReports(req.Base, current)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// regenerateReport builds a fresh report for a matchup with the organisation's
+// notes merged in. It is not saved, so viewing a diff changes nothing. On failure
+// it writes the error response and returns false.
+func (h *Handler) regenerateReport(c *gin.Context, matchup models.MatchupInfo, timeWindow models.TimeWindow) (*models.ScoutingReport, bool) {
+	if timeWindow == "" {
+		timeWindow = models.Last3Months
+	}
+
+	var tournamentIDs []string
+	if param := c.Query("tournamentIds"); param != "" {
+		tournamentIDs = strings.Split(param, ",")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	report, err := h.reportService.GenerateScoutingReport(ctx, matchup.Opponent, matchup.YourTeam, matchup.Title, timeWindow, tournamentIDs, matchup.DateRange, matchup.Strict, matchup.SinceRosterChange)
+	if err != nil {
+		log.Printf("[ERROR] Report regeneration failed: %v", err)
+
+		var teamErr *grid.TeamNotFoundError
+		if errors.As(err, &teamErr) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":          teamErr.Error(),
+				"team":           teamErr.TeamName,
+				"availableTeams": teamErr.AvailableTeams,
+			})
+			return nil, false
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.JSON(http.StatusGatewayTimeout, gin.H{
+				"error":   "Request timeout",
+				"message": "Report regeneration took too long. Try again later.",
+			})
+			return nil, false
+		}
+
+		if errors.Is(err, repository.ErrNotFound) {
+			respondRepoError(c, err)
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	h.annotateReport(c, report)
+	return report, true
+}
+
+// annotateReport merges the organisation's analyst notes into a freshly generated
+// report. Callers without an organisation get the report as-is.
+func (h *Handler) annotateReport(c *gin.Context, report *models.ScoutingReport) {
+	principal := principalFrom(c)
+	if !principal.HasOrg() {
+		return
+	}
+	if err := h.reportService.ApplyAnnotations(principal.OrgID, report); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+}
+
+// finalizeReport saves a freshly generated report with a permalink, then merges the
+// organisation's analyst notes into the response. The snapshot is stored without
+// notes; they are merged again whenever it is read. Callers without an organisation
+// get the report as-is.
+func (h *Handler) finalizeReport(c *gin.Context, report *models.ScoutingReport, timeWindow models.TimeWindow) {
+	principal := principalFrom(c)
+	if !principal.HasOrg() {
+		return
+	}
+
+	if _, err := h.reportService.SaveReport(principal.OrgID, principal.Actor(), timeWindow, report); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+	h.annotateReport(c, report)
+}
+
+// annotateSavedReport merges the organisation's current notes into a saved report
+// as it is read, so notes added or deleted since it was saved are reflected
+func (h *Handler) annotateSavedReport(orgID string, report *models.ScoutingReport) {
+	if err := h.reportService.ApplyAnnotations(orgID, report); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+}
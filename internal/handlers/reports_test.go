@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDiffPostedReportRejectsBadTimeWindow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	const matchup = `"matchup": {"opponent": "G2 Esports", "yourTeam": "Cloud9", "title": "valorant"}`
+
+	tests := []struct {
+		name  string
+		query string
+		body  string
+	}{
+		{"bad query window", "?timeWindow=FOREVER", `{"base": {` + matchup + `}}`},
+		{"bad window in posted report", "", `{"base": {` + matchup + `, "comparison": {"dataQuality": {"timeRange": "FOREVER"}}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/reports/diff", h.DiffPostedReport)
+
+			req := httptest.NewRequest(http.MethodPost, "/reports/diff"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400 (body %s)", rec.Code, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), "invalid timeWindow") {
+				t.Errorf("body = %s, want an invalid timeWindow error", rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// sinceRosterChangeFromQuery reads sinceRosterChange=true|false, which limits
+// stats to the series played with a team's current lineup. It cannot be combined
+// with an explicit date range. On invalid input it writes the error response and
+// returns false.
+func sinceRosterChangeFromQuery(c *gin.Context, dateRange *models.DateRange) (bool, bool) {
+	param := c.Query("sinceRosterChange")
+	if param == "" {
+		return false, true
+	}
+	since, err := strconv.ParseBool(param)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid sinceRosterChange parameter",
+			"message":  "sinceRosterChange must be true or false",
+			"provided": param,
+		})
+		return false, false
+	}
+	if since && dateRange != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "sinceRosterChange cannot be combined with from, to, sinceTournament, sincePatch or patch",
+			"message": "sinceRosterChange already starts the range at the team's latest lineup change",
+		})
+		return false, false
+	}
+	return since, true
+}
+
+// lineupRange resolves sinceRosterChange for one team into a date range. On
+// failure it writes the error response and returns false.
+func (h *Handler) lineupRange(c *gin.Context, title, teamName string) (*models.DateRange, bool) {
+	dateRange, err := h.rosters.CurrentLineupRange(title, teamName)
+	if err != nil {
+		respondRepoError(c, err)
+		return nil, false
+	}
+	return dateRange, true
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// GetSeries returns the full breakdown of one series: teams, winner, per-game map
+// and score, per-player K/D/A, and links to each team's trends and reports
+func (h *Handler) GetSeries(c *gin.Context) {
+	start := time.Now()
+	seriesID := c.Param("id")
+	title := strings.ToLower(c.Query("title"))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 20*time.Second)
+	defer cancel()
+
+	cacheKey := fmt.Sprintf("series:%s:%s", seriesID, title)
+	var cached models.SeriesDetail
+	if err := h.redisCache.Get(ctx, cacheKey, &cached); err == nil {
+		log.Printf("[CACHE HIT] GetSeries took %v", time.Since(start))
+		c.JSON(http.StatusOK, cached)
+		return
+	}
+
+	detail, err := h.gridClient.GetSeriesDetail(ctx, seriesID)
+	if err != nil {
+		log.Printf("[ERROR] Series drill-down failed: %v", err)
+
+		var notFound *grid.SeriesNotFoundError
+		if errors.As(err, &notFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   notFound.Error(),
+				"message": "Series IDs are listed in comparison and trend responses (seriesIds)",
+			})
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.JSON(http.StatusGatewayTimeout, gin.H{
+				"error":   "Request timeout",
+				"message": "The series took too long to load. Try again later.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Synced series add their schedule and patch, and fix the title for links
+	stored, err := h.pgRepo.GetSeries(seriesID)
+	switch {
+	case err == nil:
+		startTime := stored.StartTime
+		detail.StartTime = &startTime
+		detail.TournamentID = stored.TournamentID
+		detail.Format = stored.Format
+		detail.Patch = stored.Patch
+		title = stored.Title
+	case !errors.Is(err, repository.ErrNotFound):
+		log.Printf("[WARN] Failed to load stored series %s: %v", seriesID, err)
+	}
+
+	if title != "" {
+		detail.Title = models.CanonicalTitle(title)
+		for i := range detail.Teams {
+			detail.Teams[i].Links = models.NewTeamLinks(detail.Teams[i].Name, detail.Title)
+		}
+	}
+
+	// Finished series don't change; live ones are only cached briefly
+	ttl := 1 * time.Minute
+	if detail.Finished {
+		ttl = 24 * time.Hour
+	}
+	if err := h.redisCache.Set(ctx, cacheKey, detail, ttl); err != nil {
+		log.Printf("Warning: Failed to cache series: %v", err)
+	}
+
+	log.Printf("[CACHE MISS] GetSeries took %v", time.Since(start))
+	c.JSON(http.StatusOK, detail)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTeamSites returns a Valorant team's attack site preferences, plant timings
+// and retakes per map, from stored series events
+func (h *Handler) GetTeamSites(c *gin.Context) {
+	title := strings.ToLower(c.DefaultQuery("title", "valorant"))
+	if title != "valorant" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "site tendencies are only available for valorant",
+			"provided": title,
+			"example":  "/api/v1/teams/FNATIC/sites?map=ascent",
+		})
+		return
+	}
+
+	report, err := h.sites.TeamSites(title, c.Param("name"), c.Query("map"), nil)
+	if err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
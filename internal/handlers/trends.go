@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+const (
+	maxTrendWindows    = 5
+	maxTrendWindowDays = 365
+	maxTrendStepDays   = 90
+)
+
+// GetTrendSeries returns rolling win rate and K/D for charting a team's form over time
+func (h *Handler) GetTrendSeries(c *gin.Context) {
+	start := time.Now()
+	teamName := c.Query("name")
+	title := strings.ToLower(c.Query("title"))
+	lookback := models.TimeWindow(c.DefaultQuery("lookback", string(models.Last6Months)))
+	windowsParam := c.DefaultQuery("windows", "14,30")
+	stepParam := c.DefaultQuery("step", "7")
+	tournamentIDsParam := c.Query("tournamentIds")
+
+	if teamName == "" || title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "name and title are required",
+			"example": "/api/v1/trends/series?name=Cloud9&title=valorant&windows=14,30&step=7",
+		})
+		return
+	}
+
+	if title != "valorant" && title != "lol" && title != "leagueoflegends" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid title parameter",
+			"message":  "title must be 'valorant' or 'lol'",
+			"provided": title,
+		})
+		return
+	}
+
+	switch lookback {
+	case models.LastMonth, models.Last3Months, models.Last6Months, models.LastYear:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid lookback parameter",
+			"message":  "lookback must be LAST_MONTH, LAST_3_MONTHS, LAST_6_MONTHS or LAST_YEAR",
+			"provided": lookback,
+		})
+		return
+	}
+
+	var windowDays []int
+	for _, part := range strings.Split(windowsParam, ",") {
+		days, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || days < 1 || days > maxTrendWindowDays {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":    "invalid windows parameter",
+				"message":  fmt.Sprintf("windows must be comma-separated day counts between 1 and %d", maxTrendWindowDays),
+				"provided": windowsParam,
+			})
+			return
+		}
+		windowDays = append(windowDays, days)
+	}
+	if len(windowDays) > maxTrendWindows {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "too many windows",
+			"message": fmt.Sprintf("at most %d window sizes per request", maxTrendWindows),
+		})
+		return
+	}
+
+	stepDays, err := strconv.Atoi(stepParam)
+	if err != nil || stepDays < 1 || stepDays > maxTrendStepDays {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "invalid step parameter",
+			"message":  fmt.Sprintf("step must be a day count between 1 and %d", maxTrendStepDays),
+			"provided": stepParam,
+		})
+		return
+	}
+
+	dateRange, ok := h.dateRangeFromQuery(c, title)
+	if !ok {
+		return
+	}
+	sinceRosterChange, ok := sinceRosterChangeFromQuery(c, dateRange)
+	if !ok {
+		return
+	}
+	if sinceRosterChange {
+		if dateRange, ok = h.lineupRange(c, title, teamName); !ok {
+			return
+		}
+	}
+
+	var tournamentIDs []string
+	if tournamentIDsParam != "" {
+		tournamentIDs = strings.Split(tournamentIDsParam, ",")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	cacheKey := fmt.Sprintf("trends-series:%s:%s:%s:%s:%d:%s:%s", teamName, title, lookback, windowsParam, stepDays, tournamentIDsParam, dateRange.Key())
+	var cached models.TrendSeries
+	if err := h.redisCache.Get(ctx, cacheKey, &cached); err == nil {
+		log.Printf("[CACHE HIT] GetTrendSeries took %v", time.Since(start))
+		c.JSON(http.StatusOK, cached)
+		return
+	}
+
+	trends, err := h.trendsService.RollingTrends(ctx, teamName, title, tournamentIDs, lookback, dateRange, windowDays, stepDays)
+	if err != nil {
+		log.Printf("[ERROR] Trend series failed: %v", err)
+		respondTrendsError(c, err)
+		return
+	}
+
+	if err := h.redisCache.Set(ctx, cacheKey, trends, 3*time.Hour); err != nil {
+		log.Printf("Warning: Failed to cache trend series: %v", err)
+	}
+
+	log.Printf("[CACHE MISS] GetTrendSeries took %v", time.Since(start))
+	c.JSON(http.StatusOK, trends)
+}
+
+// respondTrendsError maps team and data lookup failures to 404/504 responses
+func respondTrendsError(c *gin.Context, err error) {
+	// ✅ Check for InsufficientDataError (404 - team exists but no data)
+	var dataErr *grid.InsufficientDataError
+	if errors.As(err, &dataErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   dataErr.Error(),
+			"team":    dataErr.TeamName,
+			"reason":  dataErr.Reason,
+			"message": "Team has insufficient data available. Try a team with recent matches.",
+		})
+		return
+	}
+
+	// Check for TeamNotFoundError (404 - team doesn't exist)
+	var teamErr *grid.TeamNotFoundError
+	if errors.As(err, &teamErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":          teamErr.Error(),
+			"team":           teamErr.TeamName,
+			"availableTeams": teamErr.AvailableTeams,
+			"message":        fmt.Sprintf("Team '%s' did not play in the available tournaments. Check the title val or lol and try again", teamErr.TeamName),
+		})
+		return
+	}
+
+	if strings.Contains(err.Error(), "no teams found matching name") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   err.Error(),
+			"message": "Team not found in this game title. Check the team name and title parameter.",
+		})
+		return
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(http.StatusGatewayTimeout, gin.H{
+			"error":   "Request timeout",
+			"message": "The analysis took too long to complete. Try again later.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
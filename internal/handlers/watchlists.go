@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// ListWatchlists returns the caller's organisation watchlists
+func (h *Handler) ListWatchlists(c *gin.Context) {
+	principal, ok := requireOrg(c)
+	if !ok {
+		return
+	}
+
+	lists, err := h.pgRepo.ListWatchlists(principal.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"watchlists": lists,
+		"count":      len(lists),
+	})
+}
+
+// CreateWatchlist creates an organisation watchlist
+func (h *Handler) CreateWatchlist(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name  string   `json:"name" binding:"required"`
+		Title string   `json:"title" binding:"required"`
+		Teams []string `json:"teams"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "name and title are required",
+			"example": gin.H{"name": "Playoff opponents", "title": "valorant", "teams": []string{"Sentinels", "G2 Esports"}},
+		})
+		return
+	}
+
+	list := &models.Watchlist{
+		ID:        uuid.New().String(),
+		OrgID:     principal.OrgID,
+		Name:      req.Name,
+		Title:     strings.ToLower(req.Title),
+		Teams:     req.Teams,
+		CreatedBy: principal.Actor(),
+		CreatedAt: time.Now().UTC(),
+	}
+	if list.Teams == nil {
+		list.Teams = []string{}
+	}
+
+	if err := h.pgRepo.CreateWatchlist(list); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// DeleteWatchlist removes an organisation watchlist
+func (h *Handler) DeleteWatchlist(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	id, valid := watchlistID(c)
+	if !valid {
+		return
+	}
+
+	if err := h.pgRepo.DeleteWatchlist(principal.OrgID, id); err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddWatchlistTeam adds a team to a watchlist
+func (h *Handler) AddWatchlistTeam(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	id, valid := watchlistID(c)
+	if !valid {
+		return
+	}
+
+	var req struct {
+		Team string `json:"team" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "team is required"})
+		return
+	}
+
+	if err := h.pgRepo.AddWatchlistTeam(principal.OrgID, id, req.Team); err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"watchlistId": id, "team": req.Team})
+}
+
+// RemoveWatchlistTeam removes a team from a watchlist
+func (h *Handler) RemoveWatchlistTeam(c *gin.Context) {
+	principal, ok := requireEditor(c)
+	if !ok {
+		return
+	}
+
+	id, valid := watchlistID(c)
+	if !valid {
+		return
+	}
+
+	if err := h.pgRepo.RemoveWatchlistTeam(principal.OrgID, id, c.Param("team")); err != nil {
+		respondRepoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func watchlistID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watchlist id"})
+		return "", false
+	}
+	return id, true
+}
+
+// respondRepoError maps repository errors to 404 or 500
+func respondRepoError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
 
 type TimeWindow string
 
@@ -12,6 +17,70 @@ const (
 	LastYear    TimeWindow = "LAST_YEAR"
 )
 
+// Valid reports whether w is one of the known windows
+func (w TimeWindow) Valid() bool {
+	switch w {
+	case LastWeek, LastMonth, Last3Months, Last6Months, LastYear:
+		return true
+	}
+	return false
+}
+
+// Cutoff returns the start of the window ending at now. Unknown windows
+// default to three months.
+func (w TimeWindow) Cutoff(now time.Time) time.Time {
+	switch w {
+	case LastWeek:
+		return now.AddDate(0, 0, -7)
+	case LastMonth:
+		return now.AddDate(0, -1, 0)
+	case Last6Months:
+		return now.AddDate(0, -6, 0)
+	case LastYear:
+		return now.AddDate(-1, 0, 0)
+	default:
+		return now.AddDate(0, -3, 0)
+	}
+}
+
+// DateRange is an explicit analysis period, From inclusive and To exclusive
+type DateRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// What From was anchored to when not given directly, e.g. "tournament 757371"
+	Anchor string `json:"anchor,omitempty"`
+}
+
+// Contains reports whether t falls inside the range
+func (r DateRange) Contains(t time.Time) bool {
+	return !t.Before(r.From) && t.Before(r.To)
+}
+
+// Key identifies the range in cache keys; nil ranges give ""
+func (r *DateRange) Key() string {
+	if r == nil {
+		return ""
+	}
+	return r.From.UTC().Format(time.RFC3339) + "/" + r.To.UTC().Format(time.RFC3339)
+}
+
+// OpenRangeEnd is the end of a range with no explicit to: the start of the next
+// hour. It covers everything played so far while keeping Key(), and so cache
+// keys, the same for an hour instead of changing on every request.
+func OpenRangeEnd(now time.Time) time.Time {
+	return now.UTC().Truncate(time.Hour).Add(time.Hour)
+}
+
+// CanonicalTitle lower-cases a title and folds aliases ("leagueoflegends" -> "lol")
+// so stored rows and cache keys agree
+func CanonicalTitle(title string) string {
+	title = strings.ToLower(strings.TrimSpace(title))
+	if title == "leagueoflegends" {
+		return "lol"
+	}
+	return title
+}
+
 type ConfidenceLevel string
 
 const (
@@ -27,6 +96,13 @@ type Confidence struct {
 	ReliabilityScore int             `json:"reliabilityScore"` // 0-100
 }
 
+// Interval is a confidence interval around a point estimate
+type Interval struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Level float64 `json:"level"` // coverage, e.g. 0.95
+}
+
 type Team struct {
 	ID      string `json:"id"`
 	Name    string `json:"name"`
@@ -43,8 +119,11 @@ type CommonStats struct {
 
 type TeamStats struct {
 	WinRate          float64    `json:"winRate"`
+	WinRateInterval  Interval   `json:"winRateInterval"`
 	KDRatio          float64    `json:"kdRatio"`
+	KDRatioInterval  Interval   `json:"kdRatioInterval"`
 	MatchesPlayed    int        `json:"matchesPlayed"`
+	Wins             int        `json:"wins"`
 	Kills            int        `json:"kills"`
 	Deaths           int        `json:"deaths"`
 	Assists          int        `json:"assists"`
@@ -55,6 +134,342 @@ type TeamStats struct {
 	SampleSize       int        `json:"sampleSize"`
 	Confidence       Confidence `json:"confidence"`
 	ActualTimeWindow TimeWindow `json:"actualTimeWindow,omitempty"` // ✅ ADDED
+	DateRange        *DateRange `json:"dateRange,omitempty"`        // set instead of a window for explicit ranges
+	// Which period the stats really cover and why it differs from the request
+	Window *WindowDisclosure `json:"window,omitempty"`
+	// Win rate and K/D corrected for opponent strength, when opponents' records are known
+	ScheduleAdjusted *ScheduleAdjustedStats `json:"scheduleAdjusted,omitempty"`
+	// Time-decayed win rate and K/D
+	Form *RecencyForm `json:"form,omitempty"`
+	// Record on each patch in the window, in release order
+	Patches []PatchStats `json:"patches,omitempty"`
+	// First kills, opening duels and trades, from stored event data
+	Openings *OpeningStats `json:"openings,omitempty"`
+	// Valorant round results by buy type, from stored event data
+	Economy *EconomyStats `json:"economy,omitempty"`
+	// LoL objective control and game tempo, from stored event data
+	Objectives *ObjectiveStats `json:"objectives,omitempty"`
+	// Record per map side: blue/red in LoL, attack/defense in Valorant
+	Side []SideSplit `json:"side,omitempty"`
+	// Per-series results behind the aggregates, newest first. Used for
+	// significance tests; not part of the API response.
+	Series []SeriesResult `json:"-"`
+}
+
+// WindowDisclosure records the period a team's stats actually cover. A window
+// with too few matches is widened unless strict mode is on.
+type WindowDisclosure struct {
+	Requested  TimeWindow `json:"requested,omitempty"`
+	Actual     TimeWindow `json:"actual,omitempty"`
+	Expanded   bool       `json:"expanded"`
+	Reason     string     `json:"reason,omitempty"` // why the window was widened
+	Strict     bool       `json:"strict,omitempty"` // widening was refused
+	FirstMatch time.Time  `json:"firstMatch"`       // date span of the series used
+	LastMatch  time.Time  `json:"lastMatch"`
+}
+
+// SeriesResult is one series from a team's perspective
+type SeriesResult struct {
+	SeriesID  string    `json:"seriesId"`
+	TeamID    string    `json:"teamId,omitempty"` // Grid ID of the team these results belong to
+	StartTime time.Time `json:"startTime"`
+	Opponent  string    `json:"opponent"`
+	Won       bool      `json:"won"`
+	HasStats  bool      `json:"hasStats"` // kills/deaths were downloaded for this series
+	Kills     int       `json:"kills"`
+	Deaths    int       `json:"deaths"`
+	Games     int       `json:"games"`
+	Patch     string    `json:"patch,omitempty"` // game patch live at StartTime, if the calendar knows it
+	// Opponent's record against other teams in the fetched tournaments
+	OpponentWins   int `json:"opponentWins"`
+	OpponentSeries int `json:"opponentSeries"`
+}
+
+// OpeningStats is a team's first-kill and trade record from stored series events.
+// A round is a Valorant round, or a whole game in LoL (first blood). Rates are
+// 0-1 fractions.
+type OpeningStats struct {
+	Series         int     `json:"series"` // series with event data
+	Rounds         int     `json:"rounds"`
+	FirstKills     int     `json:"firstKills"`
+	FirstDeaths    int     `json:"firstDeaths"`
+	FirstKillRate  float64 `json:"firstKillRate"`
+	FirstDeathRate float64 `json:"firstDeathRate"`
+	// Share of the round's first duel won, with its 95% interval
+	OpeningDuelWinRate  float64  `json:"openingDuelWinRate"`
+	OpeningDuelInterval Interval `json:"openingDuelInterval"`
+	// Mean time into the round of the team's first kills
+	AvgFirstKillSeconds float64 `json:"avgFirstKillSeconds,omitempty"`
+	Deaths              int     `json:"deaths"`
+	TradedDeaths        int     `json:"tradedDeaths"`
+	// Share of deaths avenged by a teammate within TradeWindowSeconds
+	TradeRate          float64          `json:"tradeRate"`
+	TradeWindowSeconds float64          `json:"tradeWindowSeconds"`
+	Players            []PlayerOpenings `json:"players"` // most opening duels first
+}
+
+// PlayerOpenings is one player's opening and trade record
+type PlayerOpenings struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Rounds              int      `json:"rounds"`
+	FirstKills          int      `json:"firstKills"`
+	FirstDeaths         int      `json:"firstDeaths"`
+	FirstKillRate       float64  `json:"firstKillRate"`
+	FirstDeathRate      float64  `json:"firstDeathRate"`
+	OpeningDuelWinRate  float64  `json:"openingDuelWinRate"`
+	OpeningDuelInterval Interval `json:"openingDuelInterval"`
+	Deaths              int      `json:"deaths"`
+	TradedDeaths        int      `json:"tradedDeaths"`
+	TradeRate           float64  `json:"tradeRate"`
+	TradeKills          int      `json:"tradeKills"` // kills avenging a teammate
+}
+
+// Valorant buy types, from a team's total loadout value at the start of a round
+const (
+	BuyPistol = "pistol"
+	BuyEco    = "eco"
+	BuyForce  = "force"
+	BuyFull   = "full"
+)
+
+// RoundRecord is a team's win record over a set of rounds
+type RoundRecord struct {
+	Rounds          int      `json:"rounds"`
+	Wins            int      `json:"wins"`
+	WinRate         float64  `json:"winRate"`
+	WinRateInterval Interval `json:"winRateInterval"`
+}
+
+// BuyTypeStats is a team's record on the rounds it played on one buy type
+type BuyTypeStats struct {
+	Type string `json:"type"`
+	RoundRecord
+}
+
+// EconomyStats is a Valorant team's round record by buy type, from stored events
+type EconomyStats struct {
+	Series int            `json:"series"` // series with buy data
+	Rounds int            `json:"rounds"`
+	ByBuy  []BuyTypeStats `json:"byBuy"` // pistol, eco, force, full; types never played are left out
+	// Full buys against an eco buy
+	AntiEco RoundRecord `json:"antiEco"`
+	// The round after each pistol: the bonus round after winning it, and the
+	// round against the opponent's bonus after losing it
+	Bonus          RoundRecord `json:"bonus"`
+	PostPistolLoss RoundRecord `json:"postPistolLoss"`
+}
+
+// ObjectiveStats is a LoL team's objective control and game tempo, from stored
+// events. Gold differences are the team's net worth minus the opponent's.
+type ObjectiveStats struct {
+	Games         int            `json:"games"` // games with event data
+	FirstDragon   FirstObjective `json:"firstDragon"`
+	FirstHerald   FirstObjective `json:"firstHerald"`
+	FirstBaron    FirstObjective `json:"firstBaron"`
+	PlatesPerGame float64        `json:"platesPerGame"`
+	GoldDiff10    float64        `json:"goldDiff10"` // average over games that reached 10:00
+	GoldDiff15    float64        `json:"goldDiff15"`
+	// Average length of finished games
+	AvgGameLength float64 `json:"avgGameLengthSeconds"`
+	// Games won when ahead or behind in gold at 15:00 (Rounds counts games)
+	AheadAt15  RoundRecord `json:"aheadAt15"`
+	BehindAt15 RoundRecord `json:"behindAt15"`
+	// Gold difference at 15:00 per game, for significance tests
+	GoldDiffs15 []float64 `json:"-"`
+}
+
+// FirstObjective is how often a team took an objective first, over the games
+// where either team took it
+type FirstObjective struct {
+	Games        int      `json:"games"`
+	Firsts       int      `json:"firsts"`
+	Rate         float64  `json:"rate"`
+	RateInterval Interval `json:"rateInterval"`
+}
+
+// Map sides
+const (
+	SideBlue    = "blue"
+	SideRed     = "red"
+	SideAttack  = "attack"
+	SideDefense = "defense"
+)
+
+// SideSplit is a team's record on one map side
+type SideSplit struct {
+	Side string `json:"side"`
+	// LoL games played on the side, or Valorant maps started on it (Rounds counts games)
+	Games RoundRecord `json:"games"`
+	// Valorant regulation rounds and pistol rounds played on the side
+	Rounds  *RoundRecord `json:"rounds,omitempty"`
+	Pistols *RoundRecord `json:"pistols,omitempty"`
+	// LoL objective control in games on the side
+	Objectives *ObjectiveStats `json:"objectives,omitempty"`
+}
+
+// SideChoice recommends which side to take when holding side selection
+type SideChoice struct {
+	Side   string  `json:"side"`
+	Edge   float64 `json:"edge"` // our win rate on it minus the opponent's on the other side, compared with the reverse
+	Reason string  `json:"reason"`
+}
+
+// EconomyMatchup is the economy section of a Valorant scouting report
+type EconomyMatchup struct {
+	Opponent *EconomyStats `json:"opponent,omitempty"`
+	YourTeam *EconomyStats `json:"yourTeam,omitempty"`
+}
+
+// SiteReport is a Valorant team's attack plants and defensive retakes per map,
+// from stored series events
+type SiteReport struct {
+	Team   string     `json:"team"`
+	TeamID string     `json:"teamId"`
+	Series int        `json:"series"` // series with event data
+	Maps   []MapSites `json:"maps"`   // most plants first
+}
+
+// MapSites is a team's site tendencies on one map. Attack rounds are the rounds
+// of the halves the team attacked, known from which side planted.
+type MapSites struct {
+	Map          string           `json:"map"`
+	AttackRounds int              `json:"attackRounds"`
+	Plants       int              `json:"plants"`
+	PlantRate    float64          `json:"plantRate"` // plants per attack round
+	Sites        []SiteTendency   `json:"sites"`     // where the team plants, most first
+	PlantTiming  []TimingBucket   `json:"plantTiming"`
+	MedianPlant  float64          `json:"medianPlantSeconds,omitempty"` // time from round start
+	Retakes      RoundRecord      `json:"retakes"`                      // rounds won after the opponent planted
+	Conceded     []SiteConcession `json:"conceded"`                     // sites opponents plant on, most first
+}
+
+// SiteTendency is how often a team plants on one site, and how those rounds end
+type SiteTendency struct {
+	Site    string  `json:"site"`
+	Plants  int     `json:"plants"`
+	Share   float64 `json:"share"` // of the team's plants on the map
+	Wins    int     `json:"wins"`
+	WinRate float64 `json:"winRate"`
+}
+
+// SiteConcession is how often opponents get a plant down on one site against the
+// team, and how often the team retakes it
+type SiteConcession struct {
+	Site       string  `json:"site"`
+	Plants     int     `json:"plants"`
+	Share      float64 `json:"share"` // of plants conceded on the map
+	Retakes    int     `json:"retakes"`
+	RetakeRate float64 `json:"retakeRate"`
+}
+
+// TimingBucket counts plants landing in one slice of the round
+type TimingBucket struct {
+	Label  string  `json:"label"` // e.g. "30-60s"
+	Plants int     `json:"plants"`
+	Share  float64 `json:"share"`
+}
+
+// DraftReport is a LoL team's draft habits over its stored series
+type DraftReport struct {
+	Team          string          `json:"team"`
+	TeamID        string          `json:"teamId"`
+	Games         int             `json:"games"`         // games with draft data
+	PriorityPicks []ChampionPicks `json:"priorityPicks"` // most picked first
+	BansAgainst   []ChampionBans  `json:"bansAgainst"`   // opponents' bans, most first
+	Blind         RoundRecord     `json:"blind"`         // blind picks, each with its game's result
+	Counter       RoundRecord     `json:"counter"`       // picks made with more of the opponent's picks shown
+	Sides         []SideRecord    `json:"sides"`
+	BlueShare     float64         `json:"blueShare"` // share of games played on blue side
+}
+
+// ChampionPicks is how often a team drafted one champion. A pick is blind when
+// the opponent had not shown more picks at the time, and a first pick is the
+// team's first pick of a game.
+type ChampionPicks struct {
+	Champion   string  `json:"champion"`
+	Picks      int     `json:"picks"`
+	FirstPicks int     `json:"firstPicks"`
+	BlindPicks int     `json:"blindPicks"`
+	Decided    int     `json:"decided"` // picks in games with a known winner
+	Wins       int     `json:"wins"`
+	WinRate    float64 `json:"winRate"`  // of decided picks
+	PickRate   float64 `json:"pickRate"` // of the team's drafted games
+}
+
+// ChampionBans is how often one champion was banned
+type ChampionBans struct {
+	Champion string  `json:"champion"`
+	Bans     int     `json:"bans"`
+	BanRate  float64 `json:"banRate"` // of the team's drafted games
+}
+
+// SideRecord is a team's game record on one map side. Rounds count games here.
+type SideRecord struct {
+	Side string `json:"side"`
+	RoundRecord
+}
+
+// SuggestedBan is a champion worth banning against an opponent
+type SuggestedBan struct {
+	Champion string `json:"champion"`
+	Reason   string `json:"reason"`
+}
+
+// DraftMatchup is the draft section of a LoL scouting report
+type DraftMatchup struct {
+	Opponent      *DraftReport   `json:"opponent"`
+	YourTeam      *DraftReport   `json:"yourTeam,omitempty"`
+	SuggestedBans []SuggestedBan `json:"suggestedBans"`
+}
+
+// PatchStats is a team's record on one game patch
+type PatchStats struct {
+	Version         string   `json:"version"`
+	MatchesPlayed   int      `json:"matchesPlayed"`
+	Wins            int      `json:"wins"`
+	WinRate         float64  `json:"winRate"`
+	WinRateInterval Interval `json:"winRateInterval"`
+	KDRatio         float64  `json:"kdRatio,omitempty"` // over series with downloaded stats
+	Kills           int      `json:"kills"`
+	Deaths          int      `json:"deaths"`
+	SeriesIDs       []string `json:"seriesIds"` // in the order the team's series were given
+}
+
+// RecencyForm weights every series by 0.5^(age/half-life), so a series one
+// half-life old counts half as much as one played today
+type RecencyForm struct {
+	HalfLifeDays    float64   `json:"halfLifeDays"`
+	AsOf            time.Time `json:"asOf"` // ages are measured from here
+	WinRate         float64   `json:"winRate"`
+	KDRatio         float64   `json:"kdRatio,omitempty"`
+	EffectiveSeries float64   `json:"effectiveSeries"` // (Σw)²/Σw², the sample size the weighted record is worth
+}
+
+// Weighting modes for CompareOptions
+const (
+	WeightingUniform = "uniform" // every series in the window counts equally
+	WeightingRecency = "recency" // series are weighted by RecencyForm
+)
+
+// CompareOptions tune which series a comparison uses and how it weighs them
+type CompareOptions struct {
+	Weighting    string
+	HalfLifeDays float64    // 0 uses the configured default
+	DateRange    *DateRange // replaces the time window when set
+	Strict       bool       // never widen the time window
+	// Limits each team to the series played with its current lineup
+	SinceRosterChange bool
+}
+
+// ScheduleAdjustedStats corrects a team's record for the strength of the opponents it faced
+type ScheduleAdjustedStats struct {
+	WinRate          float64 `json:"winRate"`
+	KDRatio          float64 `json:"kdRatio,omitempty"`
+	ExpectedWinRate  float64 `json:"expectedWinRate"`  // what an average team would win against the same schedule
+	OpponentStrength float64 `json:"opponentStrength"` // mean opponent win rate against other teams
+	RatedSeries      int     `json:"ratedSeries"`      // series whose opponent has a known record
+	Sufficient       bool    `json:"sufficient"`       // enough rated series to compare teams on
 }
 
 type PlayerStats struct {
@@ -81,12 +496,26 @@ type ComparisonTeamData struct {
 }
 
 type ComparisonStats struct {
-	WinRate       float64    `json:"winRate"`
-	KDRatio       float64    `json:"kdRatio"`
-	Kills         StatVal    `json:"kills"`
-	Deaths        StatVal    `json:"deaths"`
-	CurrentStreak Streak     `json:"currentStreak"`
-	Confidence    Confidence `json:"confidence"`
+	WinRate         float64    `json:"winRate"`
+	WinRateInterval Interval   `json:"winRateInterval"`
+	KDRatio         float64    `json:"kdRatio"`
+	KDRatioInterval Interval   `json:"kdRatioInterval"`
+	Kills           StatVal    `json:"kills"`
+	Deaths          StatVal    `json:"deaths"`
+	CurrentStreak   Streak     `json:"currentStreak"`
+	Confidence      Confidence `json:"confidence"`
+
+	ScheduleAdjusted *ScheduleAdjustedStats `json:"scheduleAdjusted,omitempty"`
+	Form             *RecencyForm           `json:"form,omitempty"`
+	Patches          []PatchStats           `json:"patches,omitempty"`
+	Openings         *OpeningStats          `json:"openings,omitempty"`
+	Economy          *EconomyStats          `json:"economy,omitempty"`
+	Objectives       *ObjectiveStats        `json:"objectives,omitempty"`
+	Side             []SideSplit            `json:"side,omitempty"`
+
+	// Series behind the record and behind K/D, newest first (see /series/:id)
+	SeriesIDs   []string `json:"seriesIds"`
+	KDSeriesIDs []string `json:"kdSeriesIds"`
 }
 
 type StatVal struct {
@@ -95,14 +524,61 @@ type StatVal struct {
 }
 
 type Advantages struct {
-	Team1 []string `json:"team1"`
-	Team2 []string `json:"team2"`
+	Team1 []Advantage `json:"team1"`
+	Team2 []Advantage `json:"team2"`
+	// Differences that looked like an edge but did not pass the significance test
+	Inconclusive []Advantage `json:"inconclusive,omitempty"`
+	Alpha        float64     `json:"alpha,omitempty"` // significance level used
+}
+
+// Advantage metrics
+const (
+	MetricWinRate     = "winRate"
+	MetricKDRatio     = "kdRatio"
+	MetricStreak      = "streak"
+	MetricGoldDiff15  = "goldDiff15"
+	MetricFirstDragon = "firstDragon"
+	MetricFirstHerald = "firstHerald"
+	MetricFirstBaron  = "firstBaron"
+)
+
+// Advantage is one edge a team holds over the other, with the test backing it
+type Advantage struct {
+	Team       string   `json:"team,omitempty"`
+	Metric     string   `json:"metric"`
+	Message    string   `json:"message"`
+	Difference float64  `json:"difference"`           // raw gap in the metric
+	EffectSize float64  `json:"effectSize,omitempty"` // Cohen's h for win rate, Cohen's d for K/D
+	PValue     *float64 `json:"pValue,omitempty"`
+	Test       string   `json:"test,omitempty"`
+	// Compared on opponent-adjusted values rather than the raw record
+	ScheduleAdjusted bool `json:"scheduleAdjusted,omitempty"`
+	// Compared on recency-weighted form rather than the raw record
+	RecencyWeighted bool `json:"recencyWeighted,omitempty"`
+}
+
+// UnmarshalJSON also accepts the plain strings older saved reports stored
+func (a *Advantage) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*a = Advantage{Message: message}
+		return nil
+	}
+
+	type plain Advantage
+	return json.Unmarshal(data, (*plain)(a))
 }
 
 type DataQuality struct {
-	Team1Matches int        `json:"team1Matches"`
-	Team2Matches int        `json:"team2Matches"`
-	TimeRange    TimeWindow `json:"timeRange"`
+	Team1Matches       int        `json:"team1Matches"`
+	Team2Matches       int        `json:"team2Matches"`
+	TimeRange          TimeWindow `json:"timeRange,omitempty"`          // widest window the data came from; empty for a dateRange
+	RequestedTimeRange TimeWindow `json:"requestedTimeRange,omitempty"` // as requested, before any widening
+	DateRange          *DateRange `json:"dateRange,omitempty"`
+	Strict             bool       `json:"strict,omitempty"`
+
+	Team1Window *WindowDisclosure `json:"team1Window,omitempty"`
+	Team2Window *WindowDisclosure `json:"team2Window,omitempty"`
 }
 
 type PickRates map[string]float64
@@ -123,6 +599,7 @@ const (
 	AlertNegativeShift   AlertType = "NEGATIVE_SHIFT"
 	AlertPlaystyleChange AlertType = "PLAYSTYLE_CHANGE"
 	AlertConsistency     AlertType = "CONSISTENCY"
+	AlertRosterChange    AlertType = "ROSTER_CHANGE"
 )
 
 type TrendAlert struct {
@@ -130,13 +607,61 @@ type TrendAlert struct {
 	Severity AlertSeverity `json:"severity"`
 	Message  string        `json:"message"`
 	Context  string        `json:"context"`
+	Shift    *TrendShift   `json:"shift,omitempty"`
+	Roster   *RosterChange `json:"roster,omitempty"` // set on ROSTER_CHANGE alerts
+}
+
+// TrendShift locates a change point in a team's per-series results
+type TrendShift struct {
+	Metric       string    `json:"metric"`   // MetricWinRate or MetricKDRatio
+	SeriesID     string    `json:"seriesId"` // first series at the new level
+	Opponent     string    `json:"opponent"`
+	At           time.Time `json:"at"`
+	Before       float64   `json:"before"`
+	After        float64   `json:"after"`
+	BeforeSeries int       `json:"beforeSeries"`
+	AfterSeries  int       `json:"afterSeries"`
+	PValue       float64   `json:"pValue"`
 }
 
 type PeriodStats struct {
-	TimeWindow TimeWindow `json:"timeWindow"`
+	TimeWindow TimeWindow `json:"timeWindow,omitempty"` // empty for an explicit date range
 	WinRate    float64    `json:"winRate"`
 	KDRatio    float64    `json:"kdRatio"`
 	Matches    int        `json:"matches"`
+	// Bounds of the series counted. Recent and overall periods do not overlap.
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	SeriesIDs []string  `json:"seriesIds"` // oldest first
+}
+
+// RollingPoint is a team's form over the window ending at End
+type RollingPoint struct {
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	Matches         int       `json:"matches"`
+	Wins            int       `json:"wins"`
+	WinRate         float64   `json:"winRate"`
+	WinRateInterval Interval  `json:"winRateInterval"`
+	KDRatio         float64   `json:"kdRatio,omitempty"`
+	StatsSeries     int       `json:"statsSeries"` // series in the window with kill/death data
+}
+
+// RollingWindow is one window size's rolling points, oldest first
+type RollingWindow struct {
+	Days   int            `json:"days"`
+	Points []RollingPoint `json:"points"`
+}
+
+// TrendSeries charts a team's form over time
+type TrendSeries struct {
+	Team     string          `json:"team"`
+	Title    string          `json:"title"`
+	From     time.Time       `json:"from"`
+	To       time.Time       `json:"to"`
+	StepDays int             `json:"stepDays"`
+	Windows  []RollingWindow `json:"windows"`
+	Series   []SeriesResult  `json:"series"` // per-series points, oldest first
 }
 
 type TrendReport struct {
@@ -146,6 +671,8 @@ type TrendReport struct {
 	Recent     PeriodStats  `json:"recent"`
 	Alerts     []TrendAlert `json:"alerts"`
 	Confidence Confidence   `json:"confidence"`
+	DateRange  *DateRange   `json:"dateRange,omitempty"`
+	Patches    []PatchStats `json:"patches,omitempty"` // record per patch over the baseline period
 }
 
 type RecentTrends struct {
@@ -157,6 +684,7 @@ type RecentTrends struct {
 
 type SeriesRecord struct {
 	ID             string
+	TournamentID   string
 	Team1ID        string
 	Team2ID        string
 	Team1Name      string
@@ -165,14 +693,68 @@ type SeriesRecord struct {
 	StartTime      time.Time
 	Team1Won       bool
 	Format         string
+	Patch          string // game patch live at StartTime; empty when unknown
 	DataDownloaded bool
 }
 
-// JSONL Event structure (simplified for kill/death events)
+// SeriesGame is the result of one game (map) within a series
+type SeriesGame struct {
+	SeriesID string            `json:"seriesId"`
+	Number   int               `json:"number"`
+	Map      string            `json:"map"`
+	WinnerID string            `json:"winnerId"`
+	Sides    map[string]string `json:"sides,omitempty"` // team ID to side, e.g. "blue"/"red" in LoL
+	Draft    []DraftAction     `json:"draft,omitempty"` // picks and bans in draft order; champions in LoL
+}
+
+// Draft actions
+const (
+	DraftPick = "pick"
+	DraftBan  = "ban"
+)
+
+// DraftAction is one pick or ban of a game's draft
+type DraftAction struct {
+	Sequence int    `json:"sequence"` // order within the draft, from 1
+	TeamID   string `json:"teamId"`
+	Action   string `json:"action"` // DraftPick or DraftBan
+	Champion string `json:"champion"`
+}
+
+// Normalised event types stored from Grid's series event stream
+const (
+	EventGameStart  = "game-start"  // Detail is the map
+	EventRoundStart = "round-start" // Valorant only
+	EventRoundEnd   = "round-end"   // actor is the team that won the round
+	EventKill       = "kill"
+	EventBombPlant  = "bomb-plant"
+	EventBombDefuse = "bomb-defuse"
+	EventObjective  = "objective" // LoL towers, inhibitors and epic monsters; Detail names it
+	EventBuy        = "buy"       // Valorant: actor team's loadout Value when the round's first kill lands
+	EventGold       = "gold"      // LoL: actor team's net worth Value as the game clock passes Detail minutes
+	EventGameEnd    = "game-end"  // actor is the team that won the game; Value is the game clock in seconds
+)
+
+// GridEvent is one normalised event from a series' JSONL event file. Actor and
+// target fields are empty when the event has none; RoundNumber is 0 outside
+// rounds and for LoL.
 type GridEvent struct {
-	Type       string                 `json:"type"`
-	OccurredAt string                 `json:"occurredAt"`
-	Payload    map[string]interface{} `json:"payload"`
+	SeriesID     string    `json:"seriesId"`
+	Sequence     int       `json:"sequence"` // order within the series
+	GameNumber   int       `json:"gameNumber"`
+	RoundNumber  int       `json:"roundNumber,omitempty"`
+	Type         string    `json:"type"`
+	SourceType   string    `json:"sourceType"` // Grid's own type, e.g. player-killed-player
+	OccurredAt   time.Time `json:"occurredAt"`
+	ActorTeamID  string    `json:"actorTeamId,omitempty"`
+	ActorID      string    `json:"actorId,omitempty"`
+	ActorName    string    `json:"actorName,omitempty"`
+	TargetTeamID string    `json:"targetTeamId,omitempty"`
+	TargetID     string    `json:"targetId,omitempty"`
+	TargetName   string    `json:"targetName,omitempty"`
+	Weapon       string    `json:"weapon,omitempty"`
+	Detail       string    `json:"detail,omitempty"` // map, bomb site or objective
+	Value        int       `json:"value,omitempty"`  // loadout value of buy events
 }
 
 type SeriesStats struct {
@@ -219,6 +801,23 @@ type MetaReport struct {
 	MetaShifts  []MetaShift `json:"metaShifts"`
 	GeneratedAt time.Time   `json:"generatedAt"`
 	SampleSize  int         `json:"sampleSize"`
+	Patch       string      `json:"patch,omitempty"`   // set when the report is limited to one patch
+	Patches     []PatchMeta `json:"patches,omitempty"` // map pool per patch, from stored series
+}
+
+// PatchMeta summarises the stored series played on one patch
+type PatchMeta struct {
+	Version string     `json:"version"`
+	Series  int        `json:"series"`
+	Games   int        `json:"games"`
+	Maps    []MapShare `json:"maps"` // most played first
+}
+
+// MapShare is how often a map was played on a patch
+type MapShare struct {
+	Map      string  `json:"map"`
+	Games    int     `json:"games"`
+	PlayRate float64 `json:"playRate"` // share of the patch's games
 }
 
 // MetaContext provides meta-related context for a team
@@ -230,11 +829,18 @@ type MetaContext struct {
 
 // KeyInsight represents a prioritized insight
 type KeyInsight struct {
-	Priority string `json:"priority"` // "HIGH", "MEDIUM", "LOW"
-	Icon     string `json:"icon"`     // "🔴", "🟡", "🟢"
-	Message  string `json:"message"`
+	Priority     string   `json:"priority"` // "HIGH", "MEDIUM", "LOW"
+	Icon         string   `json:"icon"`     // "🔴", "🟡", "🟢"
+	Message      string   `json:"message"`
+	Source       string   `json:"source,omitempty"` // "analyst" for annotations; empty for generated insights
+	Author       string   `json:"author,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	AnnotationID string   `json:"annotationId,omitempty"`
 }
 
+// InsightSourceAnalyst marks insights that come from analyst annotations
+const InsightSourceAnalyst = "analyst"
+
 // CacheStatus tracks cache performance
 type CacheStatus struct {
 	FromCache bool   `json:"fromCache"`
@@ -250,15 +856,26 @@ type ScoutingReport struct {
 	Trends      TrendsInfo       `json:"trends"`
 	MetaContext MetaContext      `json:"metaContext,omitempty"`
 	KeyInsights []KeyInsight     `json:"keyInsights"`
-	Confidence  Confidence       `json:"confidence"`
-	CacheStatus CacheStatus      `json:"cacheStatus"`
+	Prediction  *Prediction      `json:"prediction,omitempty"`
+	Economy     *EconomyMatchup  `json:"economy,omitempty"` // Valorant, once events are synced
+	// Opponent's plant and retake tendencies (Valorant, once events are synced)
+	SiteTendencies *SiteReport   `json:"siteTendencies,omitempty"`
+	Draft          *DraftMatchup `json:"draft,omitempty"` // LoL, once drafts are synced
+	SideChoice     *SideChoice   `json:"sideChoice,omitempty"`
+	Confidence     Confidence    `json:"confidence"`
+	CacheStatus    CacheStatus   `json:"cacheStatus"`
+	Permalink      string        `json:"permalink,omitempty"` // set when the report is saved for an organisation
 }
 
 // MatchupInfo describes the teams being compared
 type MatchupInfo struct {
-	Opponent string `json:"opponent"`
-	YourTeam string `json:"yourTeam"`
-	Title    string `json:"title"`
+	Opponent  string     `json:"opponent"`
+	YourTeam  string     `json:"yourTeam"`
+	Title     string     `json:"title"`
+	DateRange *DateRange `json:"dateRange,omitempty"` // explicit period the report covers, if any
+	Strict    bool       `json:"strict,omitempty"`    // time window was not widened
+	// Each team's stats and trends start at its current lineup
+	SinceRosterChange bool `json:"sinceRosterChange,omitempty"`
 }
 
 // TrendsInfo contains trend analysis for both teams
@@ -274,3 +891,345 @@ type TeamSearchResult struct {
 	Title       string `json:"title"`
 	Relevance   int    `json:"relevance"`
 }
+
+// APIKey is a partner credential for /api/v1. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	OrgID      string     `json:"orgId,omitempty"` // empty = not bound to an organisation
+	Prefix     string     `json:"prefix"`
+	RateLimit  float64    `json:"rateLimit"` // requests per second
+	Burst      int        `json:"burst"`
+	DailyQuota int        `json:"dailyQuota"` // 0 = unlimited
+	CreatedAt  time.Time  `json:"createdAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// APIKeyUsage is the request count for one key, day and endpoint
+type APIKeyUsage struct {
+	KeyID    string    `json:"keyId"`
+	KeyName  string    `json:"keyName"`
+	Day      time.Time `json:"day"`
+	Endpoint string    `json:"endpoint"`
+	Requests int       `json:"requests"`
+	Rejected int       `json:"rejected"`
+}
+
+// Role controls what a user can do inside their organisation
+type Role string
+
+const (
+	RoleAdmin   Role = "admin"   // manages users, full access
+	RoleCoach   Role = "coach"   // creates and edits prep
+	RoleAnalyst Role = "analyst" // creates and edits prep
+	RolePlayer  Role = "player"  // read-only access to the org's prep
+)
+
+// ValidRole reports whether r is one of the known roles
+func ValidRole(r Role) bool {
+	switch r {
+	case RoleAdmin, RoleCoach, RoleAnalyst, RolePlayer:
+		return true
+	}
+	return false
+}
+
+// Organization is a tenant: a team sharing the deployment
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// User belongs to exactly one organisation
+type User struct {
+	ID           string    `json:"id"`
+	OrgID        string    `json:"orgId"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	Role         Role      `json:"role"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Watchlist is an organisation's list of teams to keep an eye on
+type Watchlist struct {
+	ID        string    `json:"id"`
+	OrgID     string    `json:"orgId"`
+	Name      string    `json:"name"`
+	Title     string    `json:"title"`
+	Teams     []string  `json:"teams"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SavedReport is a scouting report persisted for an organisation.
+// Report is omitted in list responses.
+type SavedReport struct {
+	ID         string          `json:"id"`
+	OrgID      string          `json:"-"`
+	Opponent   string          `json:"opponent"`
+	YourTeam   string          `json:"yourTeam"`
+	Title      string          `json:"title"`
+	TimeWindow TimeWindow      `json:"timeWindow"`
+	Pinned     bool            `json:"pinned"`
+	Archived   bool            `json:"archived"`
+	CreatedBy  string          `json:"createdBy,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Permalink  string          `json:"permalink"`
+	Report     *ScoutingReport `json:"report,omitempty"`
+}
+
+// ReportPermalink is the API path of a saved report
+func ReportPermalink(id string) string {
+	return "/api/v1/reports/" + id
+}
+
+// SeriesPermalink is the API path of a series drill-down
+func SeriesPermalink(id string) string {
+	return "/api/v1/series/" + id
+}
+
+// SeriesDetail is the full breakdown of one series
+type SeriesDetail struct {
+	SeriesID     string     `json:"seriesId"`
+	Title        string     `json:"title,omitempty"`
+	TournamentID string     `json:"tournamentId,omitempty"`
+	StartTime    *time.Time `json:"startTime,omitempty"` // known once the series is synced
+	Format       string     `json:"format,omitempty"`
+	Patch        string     `json:"patch,omitempty"`
+	Finished     bool       `json:"finished"`
+	WinnerID     string     `json:"winnerId,omitempty"`
+
+	Teams []SeriesTeamDetail `json:"teams"`
+	Games []GameDetail       `json:"games"`
+}
+
+// SeriesTeamDetail is one team's totals over a series
+type SeriesTeamDetail struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Won     bool         `json:"won"`
+	Score   int          `json:"score"` // games won
+	Kills   int          `json:"kills"`
+	Deaths  int          `json:"deaths"`
+	Assists int          `json:"assists"`
+	KDRatio float64      `json:"kdRatio"`
+	Players []PlayerLine `json:"players"` // summed over games, most kills first
+	Links   *TeamLinks   `json:"links,omitempty"`
+}
+
+// GameDetail is one game (map) of a series
+type GameDetail struct {
+	Number   int              `json:"number"`
+	Map      string           `json:"map"`
+	Finished bool             `json:"finished"`
+	WinnerID string           `json:"winnerId,omitempty"`
+	Teams    []GameTeamDetail `json:"teams"`
+}
+
+// GameTeamDetail is one team's side of a game
+type GameTeamDetail struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Won     bool         `json:"won"`
+	Score   int          `json:"score"` // rounds (Valorant) or kills (LoL)
+	Kills   int          `json:"kills"`
+	Deaths  int          `json:"deaths"`
+	Assists int          `json:"assists"`
+	Players []PlayerLine `json:"players"`
+}
+
+// RosterPlayer is a player who played for a team in a series
+type RosterPlayer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SeriesLineup is the players a team fielded in a series
+type SeriesLineup struct {
+	SeriesID  string         `json:"seriesId"`
+	TeamID    string         `json:"teamId"`
+	StartTime time.Time      `json:"startTime"`
+	Players   []RosterPlayer `json:"players"`
+}
+
+// RosterChange is a switch from one lineup to another, dated to the first
+// series played with the new lineup
+type RosterChange struct {
+	SeriesID string         `json:"seriesId"`
+	At       time.Time      `json:"at"`
+	Joined   []RosterPlayer `json:"joined"`
+	Left     []RosterPlayer `json:"left"`
+}
+
+// PlayerLine is a player's K/D/A over a game or series
+type PlayerLine struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Kills   int     `json:"kills"`
+	Deaths  int     `json:"deaths"`
+	Assists int     `json:"assists"`
+	KDRatio float64 `json:"kdRatio"`
+}
+
+// TeamLinks point from a series to the endpoints covering one of its teams
+type TeamLinks struct {
+	Trends        string `json:"trends"`
+	RatingHistory string `json:"ratingHistory"`
+	Reports       string `json:"reports"` // saved reports mentioning the team
+}
+
+// NewTeamLinks builds the links for a team in a title
+func NewTeamLinks(team, title string) *TeamLinks {
+	name, t := url.QueryEscape(team), url.QueryEscape(title)
+	return &TeamLinks{
+		Trends:        "/api/v1/trends?name=" + name + "&title=" + t,
+		RatingHistory: "/api/v1/teams/" + url.PathEscape(team) + "/rating-history?title=" + t,
+		Reports:       "/api/v1/reports?team=" + name + "&title=" + t,
+	}
+}
+
+// SavedReportFilter narrows a saved report listing
+type SavedReportFilter struct {
+	Team            string // matches opponent or your team (case-insensitive, partial)
+	Title           string
+	PinnedOnly      bool
+	IncludeArchived bool
+	Limit           int
+}
+
+// StatDelta is a before/after pair for a numeric stat
+type StatDelta struct {
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	Delta  float64 `json:"delta"`
+}
+
+// ConfidenceDelta describes how confidence changed between two reports
+type ConfidenceDelta struct {
+	LevelBefore       ConfidenceLevel `json:"levelBefore"`
+	LevelAfter        ConfidenceLevel `json:"levelAfter"`
+	ReliabilityBefore int             `json:"reliabilityBefore"`
+	ReliabilityAfter  int             `json:"reliabilityAfter"`
+	ReliabilityDelta  int             `json:"reliabilityDelta"`
+}
+
+// TeamStatsDiff holds the stat changes for one side of a matchup
+type TeamStatsDiff struct {
+	Name         string          `json:"name"`
+	WinRate      StatDelta       `json:"winRate"`
+	KDRatio      StatDelta       `json:"kdRatio"`
+	KillsAvg     StatDelta       `json:"killsAvg"`
+	DeathsAvg    StatDelta       `json:"deathsAvg"`
+	Matches      StatDelta       `json:"matches"`
+	StreakBefore Streak          `json:"streakBefore"`
+	StreakAfter  Streak          `json:"streakAfter"`
+	Confidence   ConfidenceDelta `json:"confidence"`
+}
+
+// AlertChanges lists trend alerts that appeared or disappeared for one team
+type AlertChanges struct {
+	New      []TrendAlert `json:"new"`
+	Resolved []TrendAlert `json:"resolved"`
+}
+
+// ReportDiff describes how a matchup evolved between two scouting reports
+type ReportDiff struct {
+	Matchup            MatchupInfo     `json:"matchup"`
+	BaseReportID       string          `json:"baseReportId"`
+	BaseGeneratedAt    time.Time       `json:"baseGeneratedAt"`
+	CurrentReportID    string          `json:"currentReportId"`
+	CurrentGeneratedAt time.Time       `json:"currentGeneratedAt"`
+	Opponent           TeamStatsDiff   `json:"opponent"`
+	YourTeam           TeamStatsDiff   `json:"yourTeam"`
+	NewInsights        []KeyInsight    `json:"newInsights"`
+	RemovedInsights    []KeyInsight    `json:"removedInsights"`
+	OpponentAlerts     AlertChanges    `json:"opponentAlerts"`
+	YourTeamAlerts     AlertChanges    `json:"yourTeamAlerts"`
+	Confidence         ConfidenceDelta `json:"confidence"`
+	Summary            []string        `json:"summary"`
+}
+
+// Annotation is an analyst note attached to a team or a saved report.
+// Insight, when set, is shown in ScoutingReport.KeyInsights as written.
+type Annotation struct {
+	ID        string      `json:"id"`
+	OrgID     string      `json:"-"`
+	TeamName  string      `json:"teamName,omitempty"`
+	Title     string      `json:"title,omitempty"`
+	ReportID  string      `json:"reportId,omitempty"`
+	Author    string      `json:"author"`
+	Note      string      `json:"note,omitempty"`
+	Tags      []string    `json:"tags"`
+	Insight   *KeyInsight `json:"insight,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// Prediction is a calibrated win probability for a series between two teams
+type Prediction struct {
+	Team1               string              `json:"team1"`
+	Team2               string              `json:"team2"`
+	Title               string              `json:"title"`
+	Team1WinProbability float64             `json:"team1WinProbability"`
+	Team2WinProbability float64             `json:"team2WinProbability"`
+	Favorite            string              `json:"favorite"`
+	Team1Rating         float64             `json:"team1Rating"`
+	Team2Rating         float64             `json:"team2Rating"`
+	Team1RatedSeries    int                 `json:"team1RatedSeries"`
+	Team2RatedSeries    int                 `json:"team2RatedSeries"`
+	Team1Form           float64             `json:"team1Form"` // recent win rate minus 3-month win rate
+	Team2Form           float64             `json:"team2Form"`
+	Maps                []MapPrediction     `json:"maps,omitempty"`
+	Model               PredictionModelInfo `json:"model"`
+	Warnings            []string            `json:"warnings,omitempty"`
+	GeneratedAt         time.Time           `json:"generatedAt"`
+}
+
+// MapPrediction is team 1's chance of winning a single game on a map
+type MapPrediction struct {
+	Map                 string  `json:"map"`
+	Team1WinProbability float64 `json:"team1WinProbability"`
+	Team1Games          int     `json:"team1Games"`
+	Team2Games          int     `json:"team2Games"`
+}
+
+// PredictionModelInfo describes the fitted model behind a prediction
+type PredictionModelInfo struct {
+	Method         string    `json:"method"`
+	FormSource     string    `json:"formSource"` // "trends" or "stored-series"
+	SeriesUsed     int       `json:"seriesUsed"`
+	Calibrated     bool      `json:"calibrated"`
+	RatingWeight   float64   `json:"ratingWeight"`
+	FormWeight     float64   `json:"formWeight"`
+	TrainedThrough time.Time `json:"trainedThrough"`
+}
+
+// TeamRating is a team's current Glicko-2 rating within a title
+type TeamRating struct {
+	Rank         int       `json:"rank,omitempty"`
+	Title        string    `json:"title"`
+	TeamID       string    `json:"teamId"`
+	TeamName     string    `json:"teamName"`
+	Rating       float64   `json:"rating"`
+	RD           float64   `json:"rd"` // rating deviation; ~95% of true strength lies within ±2 RD
+	Volatility   float64   `json:"volatility"`
+	SeriesPlayed int       `json:"seriesPlayed"`
+	Wins         int       `json:"wins"`
+	Losses       int       `json:"losses"`
+	LastPlayed   time.Time `json:"lastPlayed"`
+	Provisional  bool      `json:"provisional"` // RD still too wide to rank confidently
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// RatingHistoryEntry is a team's rating change from one series
+type RatingHistoryEntry struct {
+	SeriesID       string    `json:"seriesId"`
+	PlayedAt       time.Time `json:"playedAt"`
+	Opponent       string    `json:"opponent"`
+	OpponentRating float64   `json:"opponentRating"`
+	Won            bool      `json:"won"`
+	RatingBefore   float64   `json:"ratingBefore"`
+	RatingAfter    float64   `json:"ratingAfter"`
+	RD             float64   `json:"rd"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpenRangeEnd(t *testing.T) {
+	now := time.Date(2024, 6, 1, 14, 7, 31, 500, time.FixedZone("CEST", 2*60*60))
+
+	end := OpenRangeEnd(now)
+	if want := time.Date(2024, 6, 1, 13, 0, 0, 0, time.UTC); !end.Equal(want) || end.Location() != time.UTC {
+		t.Errorf("OpenRangeEnd(%s) = %s, want %s", now, end, want)
+	}
+	if !end.After(now) {
+		t.Errorf("OpenRangeEnd(%s) = %s excludes now", now, end)
+	}
+	if later := OpenRangeEnd(now.Add(40 * time.Minute)); !later.Equal(end) {
+		t.Errorf("end moved from %s to %s within the same hour", end, later)
+	}
+}
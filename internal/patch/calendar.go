@@ -0,0 +1,123 @@
+// Package patch maps dates to game patches so stats can be split or filtered by
+// the balance state they were played on.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+const dateLayout = "2006-01-02"
+
+// Patch is one game version and the day it went live
+type Patch struct {
+	Version string    `json:"version"`
+	Start   time.Time `json:"start"`
+}
+
+// Calendar holds each title's patches in release order. A nil Calendar knows
+// no patches, so callers don't need to check whether one was configured.
+type Calendar struct {
+	titles map[string][]Patch
+}
+
+// Load reads a calendar from a JSON file of the form
+//
+//	{"valorant": [{"version": "9.0", "start": "2024-06-25"}, ...], "lol": [...]}
+//
+// An empty path gives an empty calendar.
+func Load(path string) (*Calendar, error) {
+	if path == "" {
+		return &Calendar{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch calendar: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a calendar in the format Load reads
+func Parse(data []byte) (*Calendar, error) {
+	var raw map[string][]struct {
+		Version string `json:"version"`
+		Start   string `json:"start"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid patch calendar: %w", err)
+	}
+
+	cal := &Calendar{titles: make(map[string][]Patch)}
+	for title, entries := range raw {
+		title = models.CanonicalTitle(title)
+		seen := make(map[string]bool)
+		for _, e := range entries {
+			if e.Version == "" {
+				return nil, fmt.Errorf("invalid patch calendar: %s patch without a version", title)
+			}
+			if seen[e.Version] {
+				return nil, fmt.Errorf("invalid patch calendar: %s patch %s listed twice", title, e.Version)
+			}
+			seen[e.Version] = true
+
+			start, err := time.Parse(dateLayout, e.Start)
+			if err != nil {
+				return nil, fmt.Errorf("invalid patch calendar: %s patch %s start %q must be YYYY-MM-DD", title, e.Version, e.Start)
+			}
+			cal.titles[title] = append(cal.titles[title], Patch{Version: e.Version, Start: start})
+		}
+		patches := cal.titles[title]
+		sort.SliceStable(patches, func(i, j int) bool { return patches[i].Start.Before(patches[j].Start) })
+	}
+	return cal, nil
+}
+
+// Patches returns a title's patches, oldest first
+func (c *Calendar) Patches(title string) []Patch {
+	if c == nil {
+		return nil
+	}
+	return c.titles[models.CanonicalTitle(title)]
+}
+
+// At returns the patch live at t, or "" when t is before the first known patch
+func (c *Calendar) At(title string, t time.Time) string {
+	patches := c.Patches(title)
+	// First patch starting after t; the one before it was live
+	i := sort.Search(len(patches), func(i int) bool { return patches[i].Start.After(t) })
+	if i == 0 {
+		return ""
+	}
+	return patches[i-1].Version
+}
+
+// Range returns when a patch was live. to is the next patch's start, or zero
+// for the current patch. ok is false for unknown versions.
+func (c *Calendar) Range(title, version string) (from, to time.Time, ok bool) {
+	patches := c.Patches(title)
+	for i, p := range patches {
+		if p.Version != version {
+			continue
+		}
+		if i+1 < len(patches) {
+			to = patches[i+1].Start
+		}
+		return p.Start, to, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// Versions lists a title's patch versions, oldest first
+func (c *Calendar) Versions(title string) []string {
+	patches := c.Patches(title)
+	versions := make([]string, len(patches))
+	for i, p := range patches {
+		versions[i] = p.Version
+	}
+	return versions
+}
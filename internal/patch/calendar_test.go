@@ -0,0 +1,105 @@
+package patch
+
+import (
+	"testing"
+	"time"
+)
+
+const testCalendar = `{
+	"valorant": [
+		{"version": "9.0", "start": "2024-06-25"},
+		{"version": "8.11", "start": "2024-06-11"},
+		{"version": "9.01", "start": "2024-07-09"}
+	],
+	"leagueoflegends": [
+		{"version": "14.1", "start": "2024-01-10"}
+	]
+}`
+
+func day(s string) time.Time {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCalendarAt(t *testing.T) {
+	cal, err := Parse([]byte(testCalendar))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		title string
+		at    time.Time
+		want  string
+	}{
+		{"before first patch", "valorant", day("2024-06-10"), ""},
+		{"on release day", "valorant", day("2024-06-25"), "9.0"},
+		{"mid patch", "valorant", day("2024-07-01"), "9.0"},
+		{"current patch", "valorant", day("2025-01-01"), "9.01"},
+		{"alias title", "lol", day("2024-03-01"), "14.1"},
+		{"unknown title", "cs2", day("2024-03-01"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cal.At(tt.title, tt.at); got != tt.want {
+				t.Errorf("At(%s, %s) = %q, want %q", tt.title, tt.at.Format(dateLayout), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarRange(t *testing.T) {
+	cal, err := Parse([]byte(testCalendar))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	from, to, ok := cal.Range("valorant", "9.0")
+	if !ok || !from.Equal(day("2024-06-25")) || !to.Equal(day("2024-07-09")) {
+		t.Errorf("Range(9.0) = %v, %v, %v", from, to, ok)
+	}
+
+	_, to, ok = cal.Range("valorant", "9.01")
+	if !ok || !to.IsZero() {
+		t.Errorf("current patch should be open-ended, got to=%v ok=%v", to, ok)
+	}
+
+	if _, _, ok := cal.Range("valorant", "7.0"); ok {
+		t.Error("unknown version should not be found")
+	}
+
+	if got := cal.Versions("valorant"); len(got) != 3 || got[0] != "8.11" || got[2] != "9.01" {
+		t.Errorf("Versions = %v, want release order", got)
+	}
+}
+
+func TestParseRejectsBadCalendars(t *testing.T) {
+	tests := map[string]string{
+		"bad date":      `{"valorant": [{"version": "9.0", "start": "June 25"}]}`,
+		"no version":    `{"valorant": [{"start": "2024-06-25"}]}`,
+		"duplicate":     `{"valorant": [{"version": "9.0", "start": "2024-06-25"}, {"version": "9.0", "start": "2024-07-09"}]}`,
+		"not an object": `[]`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestNilCalendar(t *testing.T) {
+	var cal *Calendar
+	if got := cal.At("valorant", time.Now()); got != "" {
+		t.Errorf("nil calendar At = %q", got)
+	}
+	if _, _, ok := cal.Range("valorant", "9.0"); ok {
+		t.Error("nil calendar should know no patches")
+	}
+}
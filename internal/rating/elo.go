@@ -0,0 +1,69 @@
+// Package rating implements team rating systems fitted on series results.
+package rating
+
+import "math"
+
+const (
+	// DefaultEloRating is the rating every team starts from
+	DefaultEloRating = 1500.0
+	// DefaultEloK controls how far a single series moves a rating
+	DefaultEloK = 32.0
+)
+
+// Elo keeps series-level Elo ratings keyed by team ID. The zero value is not
+// usable; create one with NewElo.
+type Elo struct {
+	K       float64
+	Initial float64
+	ratings map[string]float64
+	games   map[string]int
+}
+
+// NewElo creates an Elo table with the given K factor
+func NewElo(k float64) *Elo {
+	return &Elo{
+		K:       k,
+		Initial: DefaultEloRating,
+		ratings: make(map[string]float64),
+		games:   make(map[string]int),
+	}
+}
+
+// Rating returns the team's current rating, or the initial rating if unseen
+func (e *Elo) Rating(team string) float64 {
+	if r, ok := e.ratings[team]; ok {
+		return r
+	}
+	return e.Initial
+}
+
+// Games returns how many series the team has been rated on
+func (e *Elo) Games(team string) int {
+	return e.games[team]
+}
+
+// WinProbability is the expected score of team a against team b
+func (e *Elo) WinProbability(a, b string) float64 {
+	return EloExpected(e.Rating(a), e.Rating(b))
+}
+
+// Update applies the result of one series
+func (e *Elo) Update(a, b string, aWon bool) {
+	ra, rb := e.Rating(a), e.Rating(b)
+	expected := EloExpected(ra, rb)
+
+	score := 0.0
+	if aWon {
+		score = 1
+	}
+
+	e.ratings[a] = ra + e.K*(score-expected)
+	e.ratings[b] = rb - e.K*(score-expected)
+	e.games[a]++
+	e.games[b]++
+}
+
+// EloExpected is the logistic Elo expectation for rating ra against rb
+func EloExpected(ra, rb float64) float64 {
+	return 1 / (1 + math.Pow(10, (rb-ra)/400))
+}
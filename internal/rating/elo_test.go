@@ -0,0 +1,35 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEloExpected(t *testing.T) {
+	if p := EloExpected(1500, 1500); p != 0.5 {
+		t.Errorf("equal ratings: got %.3f, want 0.5", p)
+	}
+	if p := EloExpected(1900, 1500); math.Abs(p-0.909) > 0.001 {
+		t.Errorf("400 point gap: got %.3f, want 0.909", p)
+	}
+}
+
+func TestEloUpdate(t *testing.T) {
+	e := NewElo(DefaultEloK)
+	e.Update("a", "b", true)
+
+	if got := e.Rating("a"); got != 1516 {
+		t.Errorf("winner rating = %.1f, want 1516", got)
+	}
+	if got := e.Rating("b"); got != 1484 {
+		t.Errorf("loser rating = %.1f, want 1484", got)
+	}
+	if e.Games("a") != 1 || e.Games("c") != 0 {
+		t.Errorf("unexpected game counts: a=%d c=%d", e.Games("a"), e.Games("c"))
+	}
+
+	// Ratings are zero-sum
+	if total := e.Rating("a") + e.Rating("b"); total != 3000 {
+		t.Errorf("rating total = %.1f, want 3000", total)
+	}
+}
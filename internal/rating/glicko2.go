@@ -0,0 +1,136 @@
+package rating
+
+import "math"
+
+// Glicko-2 defaults from Glickman's "Example of the Glicko-2 system"
+const (
+	DefaultGlickoRating     = 1500.0
+	DefaultGlickoRD         = 350.0
+	DefaultGlickoVolatility = 0.06
+	DefaultGlickoTau        = 0.5
+
+	glickoScale = 173.7178
+	convergence = 0.000001
+)
+
+// Glicko2Player is a rating on the familiar Glicko scale (1500 / 350)
+type Glicko2Player struct {
+	Rating     float64
+	RD         float64
+	Volatility float64
+}
+
+// NewGlicko2Player returns an unrated player
+func NewGlicko2Player() Glicko2Player {
+	return Glicko2Player{Rating: DefaultGlickoRating, RD: DefaultGlickoRD, Volatility: DefaultGlickoVolatility}
+}
+
+// Glicko2 holds the system constant tau, which limits how fast volatility can change
+type Glicko2 struct {
+	Tau float64
+}
+
+// Inflate grows a player's rating deviation for periods rating periods without
+// games, capped at the unrated deviation
+func (g Glicko2) Inflate(p Glicko2Player, periods float64) Glicko2Player {
+	if periods <= 0 {
+		return p
+	}
+	phi := p.RD / glickoScale
+	phi = math.Sqrt(phi*phi + periods*p.Volatility*p.Volatility)
+	p.RD = math.Min(phi*glickoScale, DefaultGlickoRD)
+	return p
+}
+
+// Update rates a player on one rating period of results against opponents.
+// scores are 1 for a win, 0 for a loss and 0.5 for a draw.
+func (g Glicko2) Update(p Glicko2Player, opponents []Glicko2Player, scores []float64) Glicko2Player {
+	mu := (p.Rating - DefaultGlickoRating) / glickoScale
+	phi := p.RD / glickoScale
+
+	if len(opponents) == 0 {
+		return g.Inflate(p, 1)
+	}
+
+	// Step 3-4: estimated variance and improvement
+	vInv, deltaSum := 0.0, 0.0
+	for i, o := range opponents {
+		muJ := (o.Rating - DefaultGlickoRating) / glickoScale
+		gJ := gPhi(o.RD / glickoScale)
+		e := expectation(mu, muJ, gJ)
+		vInv += gJ * gJ * e * (1 - e)
+		deltaSum += gJ * (scores[i] - e)
+	}
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	// Step 5: new volatility via the Illinois algorithm
+	sigma := g.newVolatility(phi, v, delta, p.Volatility)
+
+	// Step 6-7: new deviation and rating
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*deltaSum
+
+	return Glicko2Player{
+		Rating:     newMu*glickoScale + DefaultGlickoRating,
+		RD:         newPhi * glickoScale,
+		Volatility: sigma,
+	}
+}
+
+// Glicko2Expected is the probability that a beats b, accounting for both deviations
+func Glicko2Expected(a, b Glicko2Player) float64 {
+	phiA, phiB := a.RD/glickoScale, b.RD/glickoScale
+	combined := gPhi(math.Sqrt(phiA*phiA + phiB*phiB))
+	return expectation((a.Rating-DefaultGlickoRating)/glickoScale, (b.Rating-DefaultGlickoRating)/glickoScale, combined)
+}
+
+func (g Glicko2) newVolatility(phi, v, delta, sigma float64) float64 {
+	tau := g.Tau
+	if tau <= 0 {
+		tau = DefaultGlickoTau
+	}
+
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		num := ex * (delta*delta - phi*phi - v - ex)
+		den := 2 * (phi*phi + v + ex) * (phi*phi + v + ex)
+		return num/den - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
+
+func gPhi(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func expectation(mu, muJ, gJ float64) float64 {
+	return 1 / (1 + math.Exp(-gJ*(mu-muJ)))
+}
@@ -0,0 +1,56 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+// Worked example from Glickman, "Example of the Glicko-2 system"
+func TestGlicko2Update(t *testing.T) {
+	g := Glicko2{Tau: 0.5}
+	player := Glicko2Player{Rating: 1500, RD: 200, Volatility: 0.06}
+	opponents := []Glicko2Player{
+		{Rating: 1400, RD: 30, Volatility: 0.06},
+		{Rating: 1550, RD: 100, Volatility: 0.06},
+		{Rating: 1700, RD: 300, Volatility: 0.06},
+	}
+
+	got := g.Update(player, opponents, []float64{1, 0, 0})
+
+	if math.Abs(got.Rating-1464.06) > 0.05 {
+		t.Errorf("rating = %.2f, want 1464.06", got.Rating)
+	}
+	if math.Abs(got.RD-151.52) > 0.05 {
+		t.Errorf("RD = %.2f, want 151.52", got.RD)
+	}
+	if math.Abs(got.Volatility-0.05999) > 0.00001 {
+		t.Errorf("volatility = %.5f, want 0.05999", got.Volatility)
+	}
+}
+
+func TestGlicko2Inflate(t *testing.T) {
+	g := Glicko2{Tau: 0.5}
+	p := Glicko2Player{Rating: 1600, RD: 50, Volatility: 0.06}
+
+	inflated := g.Inflate(p, 10)
+	if inflated.RD <= p.RD || inflated.Rating != p.Rating {
+		t.Errorf("inactivity should only widen RD: %+v -> %+v", p, inflated)
+	}
+	if capped := g.Inflate(p, 1e9); capped.RD != DefaultGlickoRD {
+		t.Errorf("RD should cap at %.0f, got %.1f", DefaultGlickoRD, capped.RD)
+	}
+}
+
+func TestGlicko2Expected(t *testing.T) {
+	a := Glicko2Player{Rating: 1700, RD: 50, Volatility: 0.06}
+	b := Glicko2Player{Rating: 1500, RD: 50, Volatility: 0.06}
+	if p := Glicko2Expected(a, b); p < 0.7 || p > 0.77 {
+		t.Errorf("200 point favourite = %.3f, want about 0.75", p)
+	}
+
+	// More uncertainty pulls the expectation towards 50%
+	uncertain := Glicko2Player{Rating: 1700, RD: 300, Volatility: 0.06}
+	if Glicko2Expected(uncertain, b) >= Glicko2Expected(a, b) {
+		t.Error("higher RD should give a less confident expectation")
+	}
+}
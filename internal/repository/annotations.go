@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// CreateAnnotation stores an analyst note for a team or a saved report
+func (r *PostgresRepo) CreateAnnotation(a *models.Annotation) error {
+	tags, err := json.Marshal(a.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	var priority, message sql.NullString
+	if a.Insight != nil {
+		priority = sql.NullString{String: a.Insight.Priority, Valid: true}
+		message = sql.NullString{String: a.Insight.Message, Valid: true}
+	}
+
+	query := `INSERT INTO annotations (id, org_id, team_name, title, report_id, author, note, tags, insight_priority, insight_message, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, '')::uuid, $6, NULLIF($7, ''), $8, $9, $10, $11)`
+	_, err = r.DB.Exec(query, a.ID, a.OrgID, a.TeamName, a.Title, a.ReportID, a.Author, a.Note, tags, priority, message, a.CreatedAt)
+	return err
+}
+
+// ListTeamAnnotations returns an organisation's notes on a team (case-insensitive).
+// An empty title matches notes for any title.
+func (r *PostgresRepo) ListTeamAnnotations(orgID, teamName, title string) ([]models.Annotation, error) {
+	query := annotationSelect + `
+		WHERE org_id = $1 AND LOWER(team_name) = LOWER($2) AND ($3 = '' OR title IS NULL OR title = $3)
+		ORDER BY created_at DESC`
+	return r.queryAnnotations(query, orgID, teamName, title)
+}
+
+// ListReportAnnotations returns an organisation's notes on a saved report
+func (r *PostgresRepo) ListReportAnnotations(orgID, reportID string) ([]models.Annotation, error) {
+	query := annotationSelect + `
+		WHERE org_id = $1 AND report_id = $2
+		ORDER BY created_at DESC`
+	return r.queryAnnotations(query, orgID, reportID)
+}
+
+// GetAnnotation fetches one note within an organisation
+func (r *PostgresRepo) GetAnnotation(orgID, id string) (*models.Annotation, error) {
+	annotations, err := r.queryAnnotations(annotationSelect+` WHERE org_id = $1 AND id = $2`, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(annotations) == 0 {
+		return nil, fmt.Errorf("annotation %s: %w", id, ErrNotFound)
+	}
+	return &annotations[0], nil
+}
+
+// DeleteAnnotation removes a note within an organisation
+func (r *PostgresRepo) DeleteAnnotation(orgID, id string) error {
+	result, err := r.DB.Exec(`DELETE FROM annotations WHERE org_id = $1 AND id = $2`, orgID, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("annotation %s: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+const annotationSelect = `SELECT id, org_id, COALESCE(team_name, ''), COALESCE(title, ''), COALESCE(report_id::text, ''),
+		author, COALESCE(note, ''), tags, insight_priority, insight_message, created_at
+		FROM annotations`
+
+func (r *PostgresRepo) queryAnnotations(query string, args ...interface{}) ([]models.Annotation, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	annotations := []models.Annotation{}
+	for rows.Next() {
+		var a models.Annotation
+		var tags []byte
+		var priority, message sql.NullString
+		if err := rows.Scan(&a.ID, &a.OrgID, &a.TeamName, &a.Title, &a.ReportID, &a.Author, &a.Note, &tags, &priority, &message, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(tags, &a.Tags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags for annotation %s: %w", a.ID, err)
+		}
+		if message.Valid {
+			a.Insight = &models.KeyInsight{Priority: priority.String, Message: message.String}
+		}
+		annotations = append(annotations, a)
+	}
+	return annotations, rows.Err()
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// CreateAPIKey stores a new key. Only the hash is persisted.
+func (r *PostgresRepo) CreateAPIKey(key *models.APIKey, keyHash string) error {
+	query := `INSERT INTO api_keys (id, name, key_hash, key_prefix, rate_limit, burst, daily_quota, created_at, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid)`
+	_, err := r.DB.Exec(query, key.ID, key.Name, keyHash, key.Prefix, key.RateLimit, key.Burst, key.DailyQuota, key.CreatedAt, key.OrgID)
+	return err
+}
+
+// GetAPIKeyByHash looks up an active (non-revoked) key by its hash
+func (r *PostgresRepo) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	query := `SELECT id, name, COALESCE(org_id::text, ''), key_prefix, rate_limit, burst, daily_quota, created_at, revoked_at
+		FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	key, err := scanAPIKey(r.DB.QueryRow(query, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("api key: %w", ErrNotFound)
+	}
+	return key, err
+}
+
+// ListAPIKeys returns all keys, including revoked ones
+func (r *PostgresRepo) ListAPIKeys() ([]models.APIKey, error) {
+	query := `SELECT id, name, COALESCE(org_id::text, ''), key_prefix, rate_limit, burst, daily_quota, created_at, revoked_at
+		FROM api_keys ORDER BY created_at`
+
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey disables a key immediately
+func (r *PostgresRepo) RevokeAPIKey(id string) error {
+	result, err := r.DB.Exec(`UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("no active api key with id %s", id)
+	}
+	return nil
+}
+
+// GetAPIKeyDailyUsage returns the number of accepted requests made with a key on the given day
+func (r *PostgresRepo) GetAPIKeyDailyUsage(keyID string, day time.Time) (int, error) {
+	var total int
+	err := r.DB.QueryRow(`SELECT COALESCE(SUM(requests), 0) FROM api_key_usage WHERE key_id = $1 AND day = $2`,
+		keyID, day.Format("2006-01-02")).Scan(&total)
+	return total, err
+}
+
+// RecordAPIKeyUsage increments the accepted or rejected counter for a key/day/endpoint
+func (r *PostgresRepo) RecordAPIKeyUsage(keyID string, day time.Time, endpoint string, rejected bool) error {
+	accepted, denied := 1, 0
+	if rejected {
+		accepted, denied = 0, 1
+	}
+
+	query := `INSERT INTO api_key_usage (key_id, day, endpoint, requests, rejected)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key_id, day, endpoint) DO UPDATE SET
+			requests = api_key_usage.requests + EXCLUDED.requests,
+			rejected = api_key_usage.rejected + EXCLUDED.rejected`
+	_, err := r.DB.Exec(query, keyID, day.Format("2006-01-02"), endpoint, accepted, denied)
+	return err
+}
+
+// GetAPIKeyUsage returns per-endpoint usage since the given day (newest first).
+// An empty keyID returns usage for every key.
+func (r *PostgresRepo) GetAPIKeyUsage(keyID string, since time.Time) ([]models.APIKeyUsage, error) {
+	query := `SELECT u.key_id, k.name, u.day, u.endpoint, u.requests, u.rejected
+		FROM api_key_usage u
+		JOIN api_keys k ON k.id = u.key_id
+		WHERE u.day >= $1 AND ($2 = '' OR u.key_id::text = $2)
+		ORDER BY u.day DESC, k.name, u.endpoint`
+
+	rows, err := r.DB.Query(query, since.Format("2006-01-02"), keyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []models.APIKeyUsage
+	for rows.Next() {
+		var u models.APIKeyUsage
+		if err := rows.Scan(&u.KeyID, &u.KeyName, &u.Day, &u.Endpoint, &u.Requests, &u.Rejected); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	var revokedAt sql.NullTime
+	if err := row.Scan(&key.ID, &key.Name, &key.OrgID, &key.Prefix, &key.RateLimit, &key.Burst, &key.DailyQuota, &key.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
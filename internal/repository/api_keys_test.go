@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+)
+
+var apiKeyColumns = []string{"id", "name", "org_id", "key_prefix", "rate_limit", "burst", "daily_quota", "created_at", "revoked_at"}
+
+func TestGetAPIKeyByHash(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	outage := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		res          stubResult
+		wantID       string
+		wantNotFound bool
+		wantErr      error
+	}{
+		{
+			name: "active key",
+			res: stubResult{columns: apiKeyColumns, rows: [][]driver.Value{
+				{"key-1", "partner", "org-1", "esk_abcd1234", float64(5), int64(10), int64(1000), created, nil},
+			}},
+			wantID: "key-1",
+		},
+		{
+			name:         "unknown or revoked key",
+			res:          stubResult{columns: apiKeyColumns},
+			wantNotFound: true,
+		},
+		{
+			name:    "database outage",
+			res:     stubResult{err: outage},
+			wantErr: outage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := openStub(t, tt.res)
+			key, err := repo.GetAPIKeyByHash("hash")
+
+			if got := errors.Is(err, ErrNotFound); got != tt.wantNotFound {
+				t.Fatalf("errors.Is(%v, ErrNotFound) = %v, want %v", err, got, tt.wantNotFound)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantID == "" {
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAPIKeyByHash: %v", err)
+			}
+			if key.ID != tt.wantID || key.OrgID != "org-1" || key.Burst != 10 || key.RevokedAt != nil {
+				t.Errorf("GetAPIKeyByHash = %+v", key)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// SaveSeriesEvents replaces the normalised events stored for a series and marks
+// its event file as downloaded
+func (r *PostgresRepo) SaveSeriesEvents(seriesID string, events []models.GridEvent) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM series_events WHERE series_id = $1`, seriesID); err != nil {
+		return fmt.Errorf("failed to clear series events: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO series_events (series_id, sequence, game_number, round_number,
+			event_type, source_type, occurred_at, actor_team_id, actor_id, actor_name,
+			target_team_id, target_id, target_name, weapon, detail, value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''),
+			NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''), NULLIF($16, 0))`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare event insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		var occurredAt sql.NullTime
+		if !e.OccurredAt.IsZero() {
+			occurredAt = sql.NullTime{Time: e.OccurredAt, Valid: true}
+		}
+		_, err := stmt.Exec(seriesID, e.Sequence, e.GameNumber, e.RoundNumber, e.Type, e.SourceType, occurredAt,
+			e.ActorTeamID, e.ActorID, e.ActorName, e.TargetTeamID, e.TargetID, e.TargetName, e.Weapon, e.Detail, e.Value)
+		if err != nil {
+			return fmt.Errorf("failed to save event %d: %w", e.Sequence, err)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE series SET events_downloaded = true WHERE id = $1`, seriesID); err != nil {
+		return fmt.Errorf("failed to mark series events downloaded: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// EventsDownloadedSeriesIDs returns which of the given series already have their events stored
+func (r *PostgresRepo) EventsDownloadedSeriesIDs(ids []string) (map[string]bool, error) {
+	downloaded := make(map[string]bool)
+	if len(ids) == 0 {
+		return downloaded, nil
+	}
+
+	rows, err := r.DB.Query(`SELECT id FROM series WHERE id = ANY($1) AND events_downloaded = true`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stored events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		downloaded[id] = true
+	}
+	return downloaded, rows.Err()
+}
+
+// ListSeriesEvents returns the stored events of the given series, in series
+// order. With eventTypes set only those types are returned.
+func (r *PostgresRepo) ListSeriesEvents(seriesIDs []string, eventTypes ...string) ([]models.GridEvent, error) {
+	if len(seriesIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT series_id, sequence, game_number, round_number, event_type, source_type, occurred_at,
+			COALESCE(actor_team_id, ''), COALESCE(actor_id, ''), COALESCE(actor_name, ''),
+			COALESCE(target_team_id, ''), COALESCE(target_id, ''), COALESCE(target_name, ''),
+			COALESCE(weapon, ''), COALESCE(detail, ''), COALESCE(value, 0)
+		FROM series_events
+		WHERE series_id = ANY($1)`
+	args := []interface{}{seriesIDs}
+	if len(eventTypes) > 0 {
+		query += ` AND event_type = ANY($2)`
+		args = append(args, eventTypes)
+	}
+	query += ` ORDER BY series_id, sequence`
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.GridEvent
+	for rows.Next() {
+		var e models.GridEvent
+		var occurredAt sql.NullTime
+		if err := rows.Scan(&e.SeriesID, &e.Sequence, &e.GameNumber, &e.RoundNumber, &e.Type, &e.SourceType, &occurredAt,
+			&e.ActorTeamID, &e.ActorID, &e.ActorName, &e.TargetTeamID, &e.TargetID, &e.TargetName,
+			&e.Weapon, &e.Detail, &e.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan series event: %w", err)
+		}
+		e.OccurredAt = occurredAt.Time
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
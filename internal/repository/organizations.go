@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// CreateOrganization stores a new tenant
+func (r *PostgresRepo) CreateOrganization(org *models.Organization) error {
+	_, err := r.DB.Exec(`INSERT INTO organizations (id, name, created_at) VALUES ($1, $2, $3)`,
+		org.ID, org.Name, org.CreatedAt)
+	return err
+}
+
+// GetOrganization fetches an organisation by id
+func (r *PostgresRepo) GetOrganization(id string) (*models.Organization, error) {
+	var org models.Organization
+	err := r.DB.QueryRow(`SELECT id, name, created_at FROM organizations WHERE id = $1`, id).
+		Scan(&org.ID, &org.Name, &org.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("organization %s: %w", id, ErrNotFound)
+	}
+	return &org, err
+}
+
+// ListOrganizations returns every tenant
+func (r *PostgresRepo) ListOrganizations() ([]models.Organization, error) {
+	rows, err := r.DB.Query(`SELECT id, name, created_at FROM organizations ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []models.Organization
+	for rows.Next() {
+		var org models.Organization
+		if err := rows.Scan(&org.ID, &org.Name, &org.CreatedAt); err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, rows.Err()
+}
+
+// CreateUser stores a new user. Email must be unique across organisations.
+func (r *PostgresRepo) CreateUser(u *models.User) error {
+	query := `INSERT INTO users (id, org_id, email, name, role, password_hash, created_at)
+		VALUES ($1, $2, LOWER($3), $4, $5, $6, $7)`
+	_, err := r.DB.Exec(query, u.ID, u.OrgID, u.Email, u.Name, string(u.Role), u.PasswordHash, u.CreatedAt)
+	return err
+}
+
+// GetUserByEmail is used for login
+func (r *PostgresRepo) GetUserByEmail(email string) (*models.User, error) {
+	query := `SELECT id, org_id, email, name, role, password_hash, created_at FROM users WHERE email = LOWER($1)`
+	user, err := scanUser(r.DB.QueryRow(query, email))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user %s: %w", email, ErrNotFound)
+	}
+	return user, err
+}
+
+// GetUser fetches a user inside an organisation
+func (r *PostgresRepo) GetUser(orgID, id string) (*models.User, error) {
+	query := `SELECT id, org_id, email, name, role, password_hash, created_at FROM users WHERE org_id = $1 AND id = $2`
+	user, err := scanUser(r.DB.QueryRow(query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user %s: %w", id, ErrNotFound)
+	}
+	return user, err
+}
+
+// ListUsers returns the members of an organisation
+func (r *PostgresRepo) ListUsers(orgID string) ([]models.User, error) {
+	query := `SELECT id, org_id, email, name, role, password_hash, created_at FROM users WHERE org_id = $1 ORDER BY email`
+	rows, err := r.DB.Query(query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, rows.Err()
+}
+
+// UpdateUserRole changes a member's role inside their organisation
+func (r *PostgresRepo) UpdateUserRole(orgID, id string, role models.Role) error {
+	result, err := r.DB.Exec(`UPDATE users SET role = $3 WHERE org_id = $1 AND id = $2`, orgID, id, string(role))
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("user %s: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func scanUser(row rowScanner) (*models.User, error) {
+	var u models.User
+	var role string
+	if err := row.Scan(&u.ID, &u.OrgID, &u.Email, &u.Name, &role, &u.PasswordHash, &u.CreatedAt); err != nil {
+		return nil, err
+	}
+	u.Role = models.Role(role)
+	return &u, nil
+}
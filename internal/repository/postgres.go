@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/yourusername/esports-scouting-backend/internal/models"
 )
 
+// ErrNotFound is returned (wrapped) when a row does not exist or belongs to another organisation
+var ErrNotFound = errors.New("not found")
+
 type PostgresRepo struct {
 	DB *sql.DB
 }
@@ -71,6 +75,187 @@ func (r *PostgresRepo) RunMigrations() error {
 		CREATE INDEX IF NOT EXISTS idx_series_title ON series(title);
 		CREATE INDEX IF NOT EXISTS idx_series_start_time ON series(start_time);
 		CREATE INDEX IF NOT EXISTS idx_stats_team ON series_stats(team_id);
+
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id UUID PRIMARY KEY,
+			name TEXT NOT NULL,
+			key_hash TEXT NOT NULL UNIQUE,
+			key_prefix TEXT NOT NULL,
+			rate_limit DOUBLE PRECISION NOT NULL DEFAULT 10,
+			burst INT NOT NULL DEFAULT 20,
+			daily_quota INT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			revoked_at TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS api_key_usage (
+			key_id UUID NOT NULL REFERENCES api_keys(id),
+			day DATE NOT NULL,
+			endpoint TEXT NOT NULL,
+			requests INT NOT NULL DEFAULT 0,
+			rejected INT NOT NULL DEFAULT 0,
+			PRIMARY KEY (key_id, day, endpoint)
+		);
+
+		CREATE TABLE IF NOT EXISTS organizations (
+			id UUID PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS users (
+			id UUID PRIMARY KEY,
+			org_id UUID NOT NULL REFERENCES organizations(id),
+			email TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			role TEXT NOT NULL,
+			password_hash TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id);
+
+		CREATE TABLE IF NOT EXISTS watchlists (
+			id UUID PRIMARY KEY,
+			org_id UUID NOT NULL REFERENCES organizations(id),
+			name TEXT NOT NULL,
+			title TEXT NOT NULL,
+			created_by TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS watchlist_teams (
+			watchlist_id UUID NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+			team_name TEXT NOT NULL,
+			added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (watchlist_id, team_name)
+		);
+
+		CREATE TABLE IF NOT EXISTS scouting_reports (
+			id UUID PRIMARY KEY,
+			org_id UUID NOT NULL REFERENCES organizations(id),
+			opponent TEXT NOT NULL,
+			my_team TEXT NOT NULL,
+			title TEXT NOT NULL,
+			time_window TEXT NOT NULL,
+			report JSONB NOT NULL,
+			pinned BOOLEAN NOT NULL DEFAULT FALSE,
+			archived BOOLEAN NOT NULL DEFAULT FALSE,
+			created_by TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS annotations (
+			id UUID PRIMARY KEY,
+			org_id UUID NOT NULL REFERENCES organizations(id),
+			team_name TEXT,
+			title TEXT,
+			report_id UUID REFERENCES scouting_reports(id),
+			author TEXT NOT NULL,
+			note TEXT,
+			tags JSONB NOT NULL DEFAULT '[]',
+			insight_priority TEXT,
+			insight_message TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		ALTER TABLE series ADD COLUMN IF NOT EXISTS tournament_id TEXT;
+		ALTER TABLE series ADD COLUMN IF NOT EXISTS patch TEXT;
+
+		CREATE TABLE IF NOT EXISTS series_games (
+			series_id TEXT NOT NULL REFERENCES series(id),
+			game_number INT NOT NULL,
+			map_name TEXT,
+			winner_team_id TEXT,
+			PRIMARY KEY (series_id, game_number)
+		);
+
+		CREATE TABLE IF NOT EXISTS team_ratings (
+			title TEXT NOT NULL,
+			team_id TEXT NOT NULL,
+			team_name TEXT NOT NULL,
+			rating DOUBLE PRECISION NOT NULL,
+			rd DOUBLE PRECISION NOT NULL,
+			volatility DOUBLE PRECISION NOT NULL,
+			series_played INT NOT NULL DEFAULT 0,
+			wins INT NOT NULL DEFAULT 0,
+			losses INT NOT NULL DEFAULT 0,
+			last_played TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (title, team_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS team_rating_history (
+			title TEXT NOT NULL,
+			team_id TEXT NOT NULL,
+			series_id TEXT NOT NULL,
+			played_at TIMESTAMP NOT NULL,
+			opponent_name TEXT NOT NULL,
+			opponent_rating DOUBLE PRECISION NOT NULL,
+			won BOOLEAN NOT NULL,
+			rating_before DOUBLE PRECISION NOT NULL,
+			rating_after DOUBLE PRECISION NOT NULL,
+			rd DOUBLE PRECISION NOT NULL,
+			PRIMARY KEY (title, team_id, series_id)
+		);
+
+		ALTER TABLE series ADD COLUMN IF NOT EXISTS events_downloaded BOOLEAN DEFAULT FALSE;
+
+		CREATE TABLE IF NOT EXISTS series_events (
+			series_id TEXT NOT NULL REFERENCES series(id),
+			sequence INT NOT NULL,
+			game_number INT NOT NULL,
+			round_number INT NOT NULL DEFAULT 0,
+			event_type TEXT NOT NULL,
+			source_type TEXT NOT NULL,
+			occurred_at TIMESTAMP,
+			actor_team_id TEXT,
+			actor_id TEXT,
+			actor_name TEXT,
+			target_team_id TEXT,
+			target_id TEXT,
+			target_name TEXT,
+			weapon TEXT,
+			detail TEXT,
+			PRIMARY KEY (series_id, sequence)
+		);
+
+		ALTER TABLE series_events ADD COLUMN IF NOT EXISTS value INT;
+
+		CREATE TABLE IF NOT EXISTS series_game_sides (
+			series_id TEXT NOT NULL REFERENCES series(id),
+			game_number INT NOT NULL,
+			team_id TEXT NOT NULL,
+			side TEXT NOT NULL,
+			PRIMARY KEY (series_id, game_number, team_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS series_draft_actions (
+			series_id TEXT NOT NULL REFERENCES series(id),
+			game_number INT NOT NULL,
+			sequence INT NOT NULL,
+			team_id TEXT NOT NULL,
+			action TEXT NOT NULL,
+			champion TEXT NOT NULL,
+			PRIMARY KEY (series_id, game_number, sequence)
+		);
+
+		CREATE TABLE IF NOT EXISTS series_rosters (
+			series_id TEXT NOT NULL REFERENCES series(id),
+			team_id TEXT NOT NULL,
+			player_id TEXT NOT NULL,
+			player_name TEXT,
+			PRIMARY KEY (series_id, team_id, player_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_series_tournament ON series(tournament_id);
+		CREATE INDEX IF NOT EXISTS idx_events_series_type ON series_events(series_id, event_type);
+		CREATE INDEX IF NOT EXISTS idx_rating_history_team ON team_rating_history(title, team_id, played_at);
+		CREATE INDEX IF NOT EXISTS idx_users_org ON users(org_id);
+		CREATE INDEX IF NOT EXISTS idx_annotations_org_team ON annotations(org_id, LOWER(team_name));
+		CREATE INDEX IF NOT EXISTS idx_annotations_report ON annotations(report_id);
+		CREATE INDEX IF NOT EXISTS idx_reports_org_created ON scouting_reports(org_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_watchlists_org ON watchlists(org_id);
 	`
 
 	_, err := r.DB.Exec(schema)
@@ -165,6 +350,7 @@ func (r *PostgresRepo) GetTeamStats(teamID, title string, startDate, endDate tim
 	return &models.TeamStats{
 		WinRate:       winRate,
 		MatchesPlayed: totalSeries,
+		Wins:          wins,
 		Kills:         totalKills,
 		KillsAvg:      killsAvg,
 		Deaths:        totalDeaths,
@@ -179,10 +365,12 @@ func (r *PostgresRepo) GetTeamStats(teamID, title string, startDate, endDate tim
 
 // SaveSeries stores series metadata
 func (r *PostgresRepo) SaveSeries(s *models.SeriesRecord) error {
-	query := `INSERT INTO series (id, team1_id, team2_id, team1_name, team2_name, title, start_time, team1_won, format, data_downloaded)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		ON CONFLICT (id) DO UPDATE SET team1_won = EXCLUDED.team1_won, data_downloaded = EXCLUDED.data_downloaded`
-	_, err := r.DB.Exec(query, s.ID, s.Team1ID, s.Team2ID, s.Team1Name, s.Team2Name, s.Title, s.StartTime, s.Team1Won, s.Format, s.DataDownloaded)
+	query := `INSERT INTO series (id, team1_id, team2_id, team1_name, team2_name, title, start_time, team1_won, format, data_downloaded, tournament_id, patch)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), NULLIF($12, ''))
+		ON CONFLICT (id) DO UPDATE SET team1_won = EXCLUDED.team1_won, data_downloaded = EXCLUDED.data_downloaded,
+			tournament_id = COALESCE(EXCLUDED.tournament_id, series.tournament_id),
+			patch = COALESCE(EXCLUDED.patch, series.patch)`
+	_, err := r.DB.Exec(query, s.ID, s.Team1ID, s.Team2ID, s.Team1Name, s.Team2Name, s.Title, s.StartTime, s.Team1Won, s.Format, s.DataDownloaded, s.TournamentID, s.Patch)
 	return err
 }
 
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// ReplaceRatings swaps in a fully recomputed set of ratings and history for a title
+func (r *PostgresRepo) ReplaceRatings(title string, ratings []models.TeamRating, history map[string][]models.RatingHistoryEntry) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM team_rating_history WHERE title = $1`, title); err != nil {
+		return fmt.Errorf("failed to clear rating history: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM team_ratings WHERE title = $1`, title); err != nil {
+		return fmt.Errorf("failed to clear ratings: %w", err)
+	}
+
+	for _, t := range ratings {
+		_, err := tx.Exec(`INSERT INTO team_ratings
+			(title, team_id, team_name, rating, rd, volatility, series_played, wins, losses, last_played, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			title, t.TeamID, t.TeamName, t.Rating, t.RD, t.Volatility, t.SeriesPlayed, t.Wins, t.Losses, t.LastPlayed, t.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save rating for %s: %w", t.TeamName, err)
+		}
+	}
+
+	for teamID, entries := range history {
+		for _, e := range entries {
+			_, err := tx.Exec(`INSERT INTO team_rating_history
+				(title, team_id, series_id, played_at, opponent_name, opponent_rating, won, rating_before, rating_after, rd)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+				title, teamID, e.SeriesID, e.PlayedAt, e.Opponent, e.OpponentRating, e.Won, e.RatingBefore, e.RatingAfter, e.RD)
+			if err != nil {
+				return fmt.Errorf("failed to save rating history: %w", err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListRatings returns a title's ratings, highest first
+func (r *PostgresRepo) ListRatings(title string, limit int) ([]models.TeamRating, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	rows, err := r.DB.Query(`
+		SELECT title, team_id, team_name, rating, rd, volatility, series_played, wins, losses,
+			COALESCE(last_played, updated_at), updated_at
+		FROM team_ratings
+		WHERE title = $1
+		ORDER BY rating DESC
+		LIMIT $2`, title, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []models.TeamRating
+	for rows.Next() {
+		t, err := scanTeamRating(rows)
+		if err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, *t)
+	}
+	return ratings, rows.Err()
+}
+
+// FindTeamRating looks a team up by name: exact (case-insensitive) match first,
+// then the most active team whose name contains the query
+func (r *PostgresRepo) FindTeamRating(title, name string) (*models.TeamRating, error) {
+	row := r.DB.QueryRow(`
+		SELECT title, team_id, team_name, rating, rd, volatility, series_played, wins, losses,
+			COALESCE(last_played, updated_at), updated_at
+		FROM team_ratings
+		WHERE title = $1 AND LOWER(team_name) LIKE '%' || $2 || '%'
+		ORDER BY (LOWER(team_name) = $2) DESC, series_played DESC
+		LIMIT 1`, title, strings.ToLower(escapeLike(name)))
+
+	t, err := scanTeamRating(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("rating for team %s: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetRatingHistory returns a team's rating after every series, oldest first
+func (r *PostgresRepo) GetRatingHistory(title, teamID string) ([]models.RatingHistoryEntry, error) {
+	rows, err := r.DB.Query(`
+		SELECT series_id, played_at, opponent_name, opponent_rating, won, rating_before, rating_after, rd
+		FROM team_rating_history
+		WHERE title = $1 AND team_id = $2
+		ORDER BY played_at ASC, series_id ASC`, title, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rating history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.RatingHistoryEntry{}
+	for rows.Next() {
+		var e models.RatingHistoryEntry
+		if err := rows.Scan(&e.SeriesID, &e.PlayedAt, &e.Opponent, &e.OpponentRating, &e.Won,
+			&e.RatingBefore, &e.RatingAfter, &e.RD); err != nil {
+			return nil, fmt.Errorf("failed to scan rating history: %w", err)
+		}
+		history = append(history, e)
+	}
+	return history, rows.Err()
+}
+
+func scanTeamRating(row rowScanner) (*models.TeamRating, error) {
+	var t models.TeamRating
+	err := row.Scan(&t.Title, &t.TeamID, &t.TeamName, &t.Rating, &t.RD, &t.Volatility,
+		&t.SeriesPlayed, &t.Wins, &t.Losses, &t.LastPlayed, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// SaveReport persists a generated scouting report for an organisation
+func (r *PostgresRepo) SaveReport(saved *models.SavedReport) error {
+	payload, err := json.Marshal(saved.Report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	query := `INSERT INTO scouting_reports (id, org_id, opponent, my_team, title, time_window, report, pinned, archived, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err = r.DB.Exec(query, saved.ID, saved.OrgID, saved.Opponent, saved.YourTeam, saved.Title,
+		string(saved.TimeWindow), payload, saved.Pinned, saved.Archived, saved.CreatedBy, saved.CreatedAt)
+	return err
+}
+
+// GetReport fetches a saved report, including its full payload, within an organisation
+func (r *PostgresRepo) GetReport(orgID, id string) (*models.SavedReport, error) {
+	query := `SELECT id, opponent, my_team, title, time_window, pinned, archived, COALESCE(created_by, ''), created_at, report
+		FROM scouting_reports WHERE org_id = $1 AND id = $2`
+
+	var saved models.SavedReport
+	var timeWindow string
+	var payload []byte
+	err := r.DB.QueryRow(query, orgID, id).Scan(&saved.ID, &saved.Opponent, &saved.YourTeam, &saved.Title, &timeWindow,
+		&saved.Pinned, &saved.Archived, &saved.CreatedBy, &saved.CreatedAt, &payload)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("report %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	saved.TimeWindow = models.TimeWindow(timeWindow)
+	saved.Report = &models.ScoutingReport{}
+	if err := json.Unmarshal(payload, saved.Report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal report %s: %w", id, err)
+	}
+	return &saved, nil
+}
+
+// ListReports returns report summaries for an organisation, pinned first then newest first
+func (r *PostgresRepo) ListReports(orgID string, filter models.SavedReportFilter) ([]models.SavedReport, error) {
+	conditions := []string{"org_id = $1"}
+	args := []interface{}{orgID}
+
+	if filter.Team != "" {
+		args = append(args, "%"+strings.ToLower(filter.Team)+"%")
+		conditions = append(conditions, fmt.Sprintf("(LOWER(opponent) LIKE $%d OR LOWER(my_team) LIKE $%d)", len(args), len(args)))
+	}
+	if filter.Title != "" {
+		args = append(args, strings.ToLower(filter.Title))
+		conditions = append(conditions, fmt.Sprintf("title = $%d", len(args)))
+	}
+	if filter.PinnedOnly {
+		conditions = append(conditions, "pinned = TRUE")
+	}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "archived = FALSE")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`SELECT id, opponent, my_team, title, time_window, pinned, archived, COALESCE(created_by, ''), created_at
+		FROM scouting_reports
+		WHERE %s
+		ORDER BY pinned DESC, created_at DESC
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := []models.SavedReport{}
+	for rows.Next() {
+		var saved models.SavedReport
+		var timeWindow string
+		if err := rows.Scan(&saved.ID, &saved.Opponent, &saved.YourTeam, &saved.Title, &timeWindow,
+			&saved.Pinned, &saved.Archived, &saved.CreatedBy, &saved.CreatedAt); err != nil {
+			return nil, err
+		}
+		saved.TimeWindow = models.TimeWindow(timeWindow)
+		reports = append(reports, saved)
+	}
+	return reports, rows.Err()
+}
+
+// UpdateReportFlags sets the pinned and/or archived flags on a saved report. Nil leaves a flag unchanged.
+func (r *PostgresRepo) UpdateReportFlags(orgID, id string, pinned, archived *bool) error {
+	query := `UPDATE scouting_reports
+		SET pinned = COALESCE($3, pinned), archived = COALESCE($4, archived)
+		WHERE org_id = $1 AND id = $2`
+	result, err := r.DB.Exec(query, orgID, id, pinned, archived)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("report %s: %w", id, ErrNotFound)
+	}
+	return nil
+}
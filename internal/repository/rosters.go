@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// SaveSeriesLineups replaces the players stored for a series, keyed by team ID
+func (r *PostgresRepo) SaveSeriesLineups(seriesID string, lineups map[string][]models.RosterPlayer) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM series_rosters WHERE series_id = $1`, seriesID); err != nil {
+		return fmt.Errorf("failed to clear series_rosters: %w", err)
+	}
+
+	for teamID, players := range lineups {
+		for _, p := range players {
+			_, err := tx.Exec(`INSERT INTO series_rosters (series_id, team_id, player_id, player_name)
+				VALUES ($1, $2, $3, NULLIF($4, ''))
+				ON CONFLICT (series_id, team_id, player_id) DO NOTHING`, seriesID, teamID, p.ID, p.Name)
+			if err != nil {
+				return fmt.Errorf("failed to save lineup of team %s: %w", teamID, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListSeriesLineups returns every team's lineup in the given series, oldest
+// series first. Series synced before lineups were kept have none.
+func (r *PostgresRepo) ListSeriesLineups(seriesIDs []string) ([]models.SeriesLineup, error) {
+	if len(seriesIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.DB.Query(`
+		SELECT ro.series_id, ro.team_id, s.start_time, ro.player_id, COALESCE(ro.player_name, '')
+		FROM series_rosters ro
+		JOIN series s ON s.id = ro.series_id
+		WHERE ro.series_id = ANY($1)
+		ORDER BY s.start_time ASC, ro.series_id, ro.team_id, ro.player_id`, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series lineups: %w", err)
+	}
+	defer rows.Close()
+
+	var lineups []models.SeriesLineup
+	for rows.Next() {
+		var l models.SeriesLineup
+		var p models.RosterPlayer
+		if err := rows.Scan(&l.SeriesID, &l.TeamID, &l.StartTime, &p.ID, &p.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan lineup: %w", err)
+		}
+		if n := len(lineups); n > 0 && lineups[n-1].SeriesID == l.SeriesID && lineups[n-1].TeamID == l.TeamID {
+			lineups[n-1].Players = append(lineups[n-1].Players, p)
+			continue
+		}
+		l.Players = []models.RosterPlayer{p}
+		lineups = append(lineups, l)
+	}
+	return lineups, rows.Err()
+}
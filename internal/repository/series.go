@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// SaveSeriesGames replaces the per-game results stored for a series
+func (r *PostgresRepo) SaveSeriesGames(seriesID string, games []models.SeriesGame) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"series_games", "series_game_sides", "series_draft_actions"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE series_id = $1`, seriesID); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	for _, g := range games {
+		_, err := tx.Exec(`INSERT INTO series_games (series_id, game_number, map_name, winner_team_id)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))`, seriesID, g.Number, g.Map, g.WinnerID)
+		if err != nil {
+			return fmt.Errorf("failed to save game %d: %w", g.Number, err)
+		}
+
+		for teamID, side := range g.Sides {
+			_, err := tx.Exec(`INSERT INTO series_game_sides (series_id, game_number, team_id, side)
+				VALUES ($1, $2, $3, $4)`, seriesID, g.Number, teamID, side)
+			if err != nil {
+				return fmt.Errorf("failed to save sides of game %d: %w", g.Number, err)
+			}
+		}
+
+		for _, a := range g.Draft {
+			_, err := tx.Exec(`INSERT INTO series_draft_actions (series_id, game_number, sequence, team_id, action, champion)
+				VALUES ($1, $2, $3, $4, $5, $6)`, seriesID, g.Number, a.Sequence, a.TeamID, a.Action, a.Champion)
+			if err != nil {
+				return fmt.Errorf("failed to save draft of game %d: %w", g.Number, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DownloadedSeriesIDs returns which of the given series are already stored with results
+func (r *PostgresRepo) DownloadedSeriesIDs(ids []string) (map[string]bool, error) {
+	downloaded := make(map[string]bool)
+	if len(ids) == 0 {
+		return downloaded, nil
+	}
+
+	rows, err := r.DB.Query(`SELECT id FROM series WHERE id = ANY($1) AND data_downloaded = true`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stored series: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		downloaded[id] = true
+	}
+	return downloaded, rows.Err()
+}
+
+// ListSeries returns every finished series stored for a title, oldest first
+func (r *PostgresRepo) ListSeries(title string) ([]models.SeriesRecord, error) {
+	rows, err := r.DB.Query(`
+		SELECT id, COALESCE(tournament_id, ''), team1_id, team2_id, team1_name, team2_name,
+			title, start_time, team1_won, COALESCE(format, ''), COALESCE(patch, ''), data_downloaded
+		FROM series
+		WHERE title = $1 AND data_downloaded = true
+		ORDER BY start_time ASC, id ASC`, strings.ToLower(title))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	defer rows.Close()
+
+	var series []models.SeriesRecord
+	for rows.Next() {
+		var s models.SeriesRecord
+		if err := rows.Scan(&s.ID, &s.TournamentID, &s.Team1ID, &s.Team2ID, &s.Team1Name, &s.Team2Name,
+			&s.Title, &s.StartTime, &s.Team1Won, &s.Format, &s.Patch, &s.DataDownloaded); err != nil {
+			return nil, fmt.Errorf("failed to scan series: %w", err)
+		}
+		series = append(series, s)
+	}
+	return series, rows.Err()
+}
+
+// GetSeries returns one stored series, whether or not its results were downloaded
+func (r *PostgresRepo) GetSeries(id string) (*models.SeriesRecord, error) {
+	var s models.SeriesRecord
+	err := r.DB.QueryRow(`
+		SELECT id, COALESCE(tournament_id, ''), team1_id, team2_id, team1_name, team2_name,
+			title, start_time, team1_won, COALESCE(format, ''), COALESCE(patch, ''), data_downloaded
+		FROM series
+		WHERE id = $1`, id).Scan(&s.ID, &s.TournamentID, &s.Team1ID, &s.Team2ID, &s.Team1Name, &s.Team2Name,
+		&s.Title, &s.StartTime, &s.Team1Won, &s.Format, &s.Patch, &s.DataDownloaded)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("series %s: %w", id, ErrNotFound)
+	}
+	return &s, err
+}
+
+// SetSeriesPatches retags stored series with the given patch versions, keyed by
+// series ID. An empty version clears the tag.
+func (r *PostgresRepo) SetSeriesPatches(patches map[string]string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for id, version := range patches {
+		if _, err := tx.Exec(`UPDATE series SET patch = NULLIF($2, '') WHERE id = $1`, id, version); err != nil {
+			return fmt.Errorf("failed to tag series %s: %w", id, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListSeriesGames returns the per-game results for every stored series of a title
+func (r *PostgresRepo) ListSeriesGames(title string) ([]models.SeriesGame, error) {
+	rows, err := r.DB.Query(`
+		SELECT g.series_id, g.game_number, COALESCE(g.map_name, ''), COALESCE(g.winner_team_id, '')
+		FROM series_games g
+		JOIN series s ON s.id = g.series_id
+		WHERE s.title = $1
+		ORDER BY s.start_time ASC, g.series_id, g.game_number`, strings.ToLower(title))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []models.SeriesGame
+	for rows.Next() {
+		var g models.SeriesGame
+		if err := rows.Scan(&g.SeriesID, &g.Number, &g.Map, &g.WinnerID); err != nil {
+			return nil, fmt.Errorf("failed to scan series game: %w", err)
+		}
+		games = append(games, g)
+	}
+	return games, rows.Err()
+}
+
+// ListGameDrafts returns the games of the given series with their sides and
+// draft actions filled in, in series and game order. Games stored before drafts
+// were synced come back without them.
+func (r *PostgresRepo) ListGameDrafts(seriesIDs []string) ([]models.SeriesGame, error) {
+	if len(seriesIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.DB.Query(`
+		SELECT g.series_id, g.game_number, COALESCE(g.map_name, ''), COALESCE(g.winner_team_id, '')
+		FROM series_games g
+		JOIN series s ON s.id = g.series_id
+		WHERE g.series_id = ANY($1)
+		ORDER BY s.start_time ASC, g.series_id, g.game_number`, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series games: %w", err)
+	}
+	defer rows.Close()
+
+	type gameKey struct {
+		seriesID string
+		number   int
+	}
+	var games []models.SeriesGame
+	for rows.Next() {
+		var g models.SeriesGame
+		if err := rows.Scan(&g.SeriesID, &g.Number, &g.Map, &g.WinnerID); err != nil {
+			return nil, fmt.Errorf("failed to scan series game: %w", err)
+		}
+		games = append(games, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	index := make(map[gameKey]int, len(games))
+	for i, g := range games {
+		index[gameKey{g.SeriesID, g.Number}] = i
+	}
+
+	sideRows, err := r.DB.Query(`
+		SELECT series_id, game_number, team_id, side
+		FROM series_game_sides
+		WHERE series_id = ANY($1)`, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list game sides: %w", err)
+	}
+	defer sideRows.Close()
+
+	for sideRows.Next() {
+		var key gameKey
+		var teamID, side string
+		if err := sideRows.Scan(&key.seriesID, &key.number, &teamID, &side); err != nil {
+			return nil, fmt.Errorf("failed to scan game side: %w", err)
+		}
+		if i, ok := index[key]; ok {
+			if games[i].Sides == nil {
+				games[i].Sides = make(map[string]string)
+			}
+			games[i].Sides[teamID] = side
+		}
+	}
+	if err := sideRows.Err(); err != nil {
+		return nil, err
+	}
+
+	draftRows, err := r.DB.Query(`
+		SELECT series_id, game_number, sequence, team_id, action, champion
+		FROM series_draft_actions
+		WHERE series_id = ANY($1)
+		ORDER BY series_id, game_number, sequence`, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list draft actions: %w", err)
+	}
+	defer draftRows.Close()
+
+	for draftRows.Next() {
+		var key gameKey
+		var a models.DraftAction
+		if err := draftRows.Scan(&key.seriesID, &key.number, &a.Sequence, &a.TeamID, &a.Action, &a.Champion); err != nil {
+			return nil, fmt.Errorf("failed to scan draft action: %w", err)
+		}
+		if i, ok := index[key]; ok {
+			games[i].Draft = append(games[i].Draft, a)
+		}
+	}
+	return games, draftRows.Err()
+}
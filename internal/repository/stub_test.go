@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// stubResult is what every query against a stub database returns
+type stubResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+var (
+	stubMu      sync.Mutex
+	stubResults = map[string]stubResult{}
+)
+
+func init() {
+	sql.Register("stub", stubDriver{})
+}
+
+// openStub returns a repository whose queries all answer with res
+func openStub(t *testing.T, res stubResult) *PostgresRepo {
+	t.Helper()
+
+	stubMu.Lock()
+	stubResults[t.Name()] = res
+	stubMu.Unlock()
+
+	db, err := sql.Open("stub", t.Name())
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		stubMu.Lock()
+		delete(stubResults, t.Name())
+		stubMu.Unlock()
+	})
+	return &PostgresRepo{DB: db}
+}
+
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) {
+	stubMu.Lock()
+	defer stubMu.Unlock()
+	return &stubConn{res: stubResults[name]}, nil
+}
+
+type stubConn struct {
+	res stubResult
+}
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) { return &stubStmt{res: c.res}, nil }
+func (c *stubConn) Close() error                              { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("stub: transactions are not supported")
+}
+
+type stubStmt struct {
+	res stubResult
+}
+
+func (s *stubStmt) Close() error  { return nil }
+func (s *stubStmt) NumInput() int { return -1 }
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.res.err != nil {
+		return nil, s.res.err
+	}
+	return driver.RowsAffected(len(s.res.rows)), nil
+}
+
+func (s *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.res.err != nil {
+		return nil, s.res.err
+	}
+	return &stubRows{columns: s.res.columns, rows: s.res.rows}, nil
+}
+
+type stubRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *stubRows) Columns() []string { return r.columns }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// CreateWatchlist stores a watchlist and its initial teams
+func (r *PostgresRepo) CreateWatchlist(w *models.Watchlist) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO watchlists (id, org_id, name, title, created_by, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		w.ID, w.OrgID, w.Name, w.Title, w.CreatedBy, w.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, team := range w.Teams {
+		if _, err := tx.Exec(`INSERT INTO watchlist_teams (watchlist_id, team_name) VALUES ($1, $2) ON CONFLICT DO NOTHING`, w.ID, team); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListWatchlists returns an organisation's watchlists with their teams
+func (r *PostgresRepo) ListWatchlists(orgID string) ([]models.Watchlist, error) {
+	query := `SELECT w.id, w.org_id, w.name, w.title, COALESCE(w.created_by, ''), w.created_at, wt.team_name
+		FROM watchlists w
+		LEFT JOIN watchlist_teams wt ON wt.watchlist_id = w.id
+		WHERE w.org_id = $1
+		ORDER BY w.created_at, wt.added_at`
+
+	rows, err := r.DB.Query(query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []models.Watchlist
+	index := make(map[string]int)
+	for rows.Next() {
+		var w models.Watchlist
+		var team *string
+		if err := rows.Scan(&w.ID, &w.OrgID, &w.Name, &w.Title, &w.CreatedBy, &w.CreatedAt, &team); err != nil {
+			return nil, err
+		}
+
+		i, exists := index[w.ID]
+		if !exists {
+			w.Teams = []string{}
+			lists = append(lists, w)
+			i = len(lists) - 1
+			index[w.ID] = i
+		}
+		if team != nil {
+			lists[i].Teams = append(lists[i].Teams, *team)
+		}
+	}
+	return lists, rows.Err()
+}
+
+// AddWatchlistTeam adds a team to a watchlist owned by the organisation
+func (r *PostgresRepo) AddWatchlistTeam(orgID, watchlistID, team string) error {
+	query := `INSERT INTO watchlist_teams (watchlist_id, team_name)
+		SELECT id, $3 FROM watchlists WHERE id = $2 AND org_id = $1
+		ON CONFLICT DO NOTHING`
+	result, err := r.DB.Exec(query, orgID, watchlistID, team)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return r.ensureWatchlist(orgID, watchlistID)
+	}
+	return nil
+}
+
+// RemoveWatchlistTeam removes a team from a watchlist owned by the organisation
+func (r *PostgresRepo) RemoveWatchlistTeam(orgID, watchlistID, team string) error {
+	if err := r.ensureWatchlist(orgID, watchlistID); err != nil {
+		return err
+	}
+	_, err := r.DB.Exec(`DELETE FROM watchlist_teams WHERE watchlist_id = $1 AND team_name = $2`, watchlistID, team)
+	return err
+}
+
+// DeleteWatchlist removes a watchlist owned by the organisation
+func (r *PostgresRepo) DeleteWatchlist(orgID, watchlistID string) error {
+	result, err := r.DB.Exec(`DELETE FROM watchlists WHERE id = $1 AND org_id = $2`, watchlistID, orgID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepo) ensureWatchlist(orgID, watchlistID string) error {
+	var exists bool
+	err := r.DB.QueryRow(`SELECT EXISTS(SELECT 1 FROM watchlists WHERE id = $1 AND org_id = $2)`, watchlistID, orgID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("watchlist %s: %w", watchlistID, ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// ApplyAnnotations merges the organisation's analyst notes on both teams (and,
+// for saved reports, on the report itself) into the report's key insights
+func (s *ReportService) ApplyAnnotations(orgID string, report *models.ScoutingReport) error {
+	var annotations []models.Annotation
+
+	for _, team := range []string{report.Matchup.Opponent, report.Matchup.YourTeam} {
+		teamNotes, err := s.pgRepo.ListTeamAnnotations(orgID, team, report.Matchup.Title)
+		if err != nil {
+			return fmt.Errorf("failed to load notes for %s: %w", team, err)
+		}
+		annotations = append(annotations, teamNotes...)
+	}
+
+	if report.Permalink != "" {
+		reportNotes, err := s.pgRepo.ListReportAnnotations(orgID, report.ReportID)
+		if err != nil {
+			return fmt.Errorf("failed to load notes for report %s: %w", report.ReportID, err)
+		}
+		annotations = append(annotations, reportNotes...)
+	}
+
+	report.KeyInsights = MergeAnnotations(report.KeyInsights, annotations)
+	return nil
+}
+
+// MergeAnnotations replaces the analyst insights in a report with the given
+// annotations, so notes deleted since the report was built drop out, and orders
+// the result by priority
+func MergeAnnotations(insights []models.KeyInsight, annotations []models.Annotation) []models.KeyInsight {
+	present := make(map[string]bool)
+	merged := []models.KeyInsight{}
+	for _, insight := range insights {
+		if insight.Source == models.InsightSourceAnalyst {
+			continue
+		}
+		merged = append(merged, insight)
+	}
+
+	for _, a := range annotations {
+		if present[a.ID] {
+			continue
+		}
+		present[a.ID] = true
+		merged = append(merged, AnnotationInsight(a))
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return priorityRank(merged[i].Priority) < priorityRank(merged[j].Priority)
+	})
+	return merged
+}
+
+// AnnotationInsight converts an annotation into a key insight. A custom insight
+// is used as written; otherwise the free-text note becomes a LOW priority insight.
+func AnnotationInsight(a models.Annotation) models.KeyInsight {
+	insight := models.KeyInsight{
+		Priority:     "LOW",
+		Icon:         "📝",
+		Source:       models.InsightSourceAnalyst,
+		Author:       a.Author,
+		Tags:         a.Tags,
+		AnnotationID: a.ID,
+	}
+
+	switch {
+	case a.Insight != nil:
+		insight.Priority = a.Insight.Priority
+		insight.Message = a.Insight.Message
+	case a.TeamName != "" && a.Note != "":
+		insight.Message = fmt.Sprintf("%s: %s", a.TeamName, a.Note)
+	case a.Note != "":
+		insight.Message = a.Note
+	default:
+		insight.Message = fmt.Sprintf("%s tagged: %s", a.TeamName, strings.Join(a.Tags, ", "))
+	}
+
+	return insight
+}
+
+func priorityRank(priority string) int {
+	switch priority {
+	case "HIGH":
+		return 0
+	case "MEDIUM":
+		return 1
+	default:
+		return 2
+	}
+}
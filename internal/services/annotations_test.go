@@ -0,0 +1,112 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestAnnotationInsight(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotation   models.Annotation
+		wantPriority string
+		wantMessage  string
+	}{
+		{
+			name:         "custom insight",
+			annotation:   models.Annotation{ID: "a1", TeamName: "G2 Esports", Note: "ignored", Insight: &models.KeyInsight{Priority: "HIGH", Message: "Expect an A-site rush"}},
+			wantPriority: "HIGH",
+			wantMessage:  "Expect an A-site rush",
+		},
+		{
+			name:         "team note",
+			annotation:   models.Annotation{ID: "a2", TeamName: "G2 Esports", Note: "Fast A-site executes"},
+			wantPriority: "LOW",
+			wantMessage:  "G2 Esports: Fast A-site executes",
+		},
+		{
+			name:         "report note",
+			annotation:   models.Annotation{ID: "a3", ReportID: "r1", Note: "Rewatch the Lotus VOD"},
+			wantPriority: "LOW",
+			wantMessage:  "Rewatch the Lotus VOD",
+		},
+		{
+			name:         "tags only",
+			annotation:   models.Annotation{ID: "a4", TeamName: "G2 Esports", Tags: []string{"fast A-site executes", "weak on Lotus"}},
+			wantPriority: "LOW",
+			wantMessage:  "G2 Esports tagged: fast A-site executes, weak on Lotus",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnnotationInsight(tt.annotation)
+			if got.Priority != tt.wantPriority || got.Message != tt.wantMessage {
+				t.Errorf("AnnotationInsight = %s %q, want %s %q", got.Priority, got.Message, tt.wantPriority, tt.wantMessage)
+			}
+			if got.Source != models.InsightSourceAnalyst || got.AnnotationID != tt.annotation.ID {
+				t.Errorf("source/annotationId = %q/%q, want %q/%q", got.Source, got.AnnotationID, models.InsightSourceAnalyst, tt.annotation.ID)
+			}
+		})
+	}
+}
+
+func TestMergeAnnotations(t *testing.T) {
+	generated := []models.KeyInsight{
+		{Priority: "MEDIUM", Message: "Opponent on a 4-game win streak"},
+		{Priority: "LOW", Message: "Both teams showing consistent performance"},
+	}
+	stale := models.KeyInsight{Priority: "HIGH", Message: "G2 Esports: deleted note", Source: models.InsightSourceAnalyst, AnnotationID: "deleted"}
+	highNote := models.Annotation{ID: "a1", Insight: &models.KeyInsight{Priority: "HIGH", Message: "Expect an A-site rush"}}
+	lowNote := models.Annotation{ID: "a2", TeamName: "G2 Esports", Note: "Weak on Lotus"}
+
+	tests := []struct {
+		name        string
+		insights    []models.KeyInsight
+		annotations []models.Annotation
+		want        []string
+	}{
+		{
+			name:     "no annotations",
+			insights: generated,
+			want:     []string{"Opponent on a 4-game win streak", "Both teams showing consistent performance"},
+		},
+		{
+			name:        "ordered by priority",
+			insights:    generated,
+			annotations: []models.Annotation{lowNote, highNote},
+			want:        []string{"Expect an A-site rush", "Opponent on a 4-game win streak", "Both teams showing consistent performance", "G2 Esports: Weak on Lotus"},
+		},
+		{
+			name:        "duplicate annotation listed once",
+			insights:    generated,
+			annotations: []models.Annotation{lowNote, lowNote},
+			want:        []string{"Opponent on a 4-game win streak", "Both teams showing consistent performance", "G2 Esports: Weak on Lotus"},
+		},
+		{
+			name:        "remerging an annotated report",
+			insights:    MergeAnnotations(generated, []models.Annotation{lowNote}),
+			annotations: []models.Annotation{lowNote},
+			want:        []string{"Opponent on a 4-game win streak", "Both teams showing consistent performance", "G2 Esports: Weak on Lotus"},
+		},
+		{
+			name:     "deleted annotation drops out",
+			insights: append([]models.KeyInsight{stale}, generated...),
+			want:     []string{"Opponent on a 4-game win streak", "Both teams showing consistent performance"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, insight := range MergeAnnotations(tt.insights, tt.annotations) {
+				got = append(got, insight.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeAnnotations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+)
+
+// BacktestScore summarises prediction quality on a set of series
+type BacktestScore struct {
+	Series   int     `json:"series"`
+	Brier    float64 `json:"brier"` // 0.25 = coin flip, lower is better
+	LogLoss  float64 `json:"logLoss"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+// TournamentBacktest is the score for one held-out tournament
+type TournamentBacktest struct {
+	TournamentID string    `json:"tournamentId"`
+	Start        time.Time `json:"start"`
+	Calibrated   bool      `json:"calibrated"` // false when no earlier data was available to fit on
+	BacktestScore
+}
+
+// BacktestReport is the result of replaying stored series tournament by tournament
+type BacktestReport struct {
+	Title       string                    `json:"title"`
+	Tournaments []TournamentBacktest      `json:"tournaments"`
+	Overall     BacktestScore             `json:"overall"`
+	Calibration []appstats.CalibrationBin `json:"calibration"`
+}
+
+// Backtest scores the prediction model on past tournaments without lookahead.
+// Ratings are updated series by series exactly as in live use, and the calibration
+// for each tournament is fitted only on series played before it started. Only the
+// listed tournaments are scored; an empty list scores every stored tournament.
+func Backtest(title string, series []models.SeriesRecord, tournamentIDs []string) *BacktestReport {
+	model := walkForward(series)
+
+	wanted := make(map[string]bool)
+	for _, id := range tournamentIDs {
+		wanted[id] = true
+	}
+
+	byTournament := make(map[string][]predictionSample)
+	for _, sample := range model.samples {
+		if sample.tournamentID == "" || (len(wanted) > 0 && !wanted[sample.tournamentID]) {
+			continue
+		}
+		byTournament[sample.tournamentID] = append(byTournament[sample.tournamentID], sample)
+	}
+
+	report := &BacktestReport{Title: title}
+	var allPredictions []float64
+	var allOutcomes []bool
+
+	for id, samples := range byTournament {
+		start := samples[0].at
+		ratingWeight, formWeight, calibrated := fitCalibration(model.samples, start)
+
+		predictions := make([]float64, len(samples))
+		outcomes := make([]bool, len(samples))
+		for i, sample := range samples {
+			predictions[i] = appstats.Sigmoid(ratingWeight*sample.ratingLogit + formWeight*sample.formDiff)
+			outcomes[i] = sample.team1Won
+		}
+
+		report.Tournaments = append(report.Tournaments, TournamentBacktest{
+			TournamentID:  id,
+			Start:         start,
+			Calibrated:    calibrated,
+			BacktestScore: score(predictions, outcomes),
+		})
+		allPredictions = append(allPredictions, predictions...)
+		allOutcomes = append(allOutcomes, outcomes...)
+	}
+
+	sort.Slice(report.Tournaments, func(i, j int) bool {
+		return report.Tournaments[i].Start.Before(report.Tournaments[j].Start)
+	})
+
+	report.Overall = score(allPredictions, allOutcomes)
+	report.Calibration = appstats.Calibration(allPredictions, allOutcomes, 10)
+	return report
+}
+
+func score(predictions []float64, outcomes []bool) BacktestScore {
+	correct := 0
+	for i, p := range predictions {
+		if (p >= 0.5) == outcomes[i] {
+			correct++
+		}
+	}
+
+	result := BacktestScore{
+		Series:  len(predictions),
+		Brier:   appstats.Brier(predictions, outcomes),
+		LogLoss: appstats.LogLoss(predictions, outcomes),
+	}
+	if len(predictions) > 0 {
+		result.Accuracy = float64(correct) / float64(len(predictions))
+	}
+	return result
+}
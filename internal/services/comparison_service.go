@@ -3,47 +3,98 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/yourusername/esports-scouting-backend/internal/grid"
 	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
 	"github.com/yourusername/esports-scouting-backend/internal/repository"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
 	"github.com/yourusername/esports-scouting-backend/pkg/cache"
 )
 
+// DefaultSignificanceLevel is the alpha used when none is configured
+const DefaultSignificanceLevel = 0.05
+
+const (
+	// Gaps smaller than these are not worth reporting even when significant
+	minWinRateGap = 0.05
+	minKDGap      = 0.1
+	minGoldGap    = 500 // gold at 15:00
+
+	// Fixed seed keeps K/D permutation p-values stable between identical requests
+	permutationRounds = 5000
+	permutationSeed   = 42
+)
+
 type ComparisonService struct {
 	gridClient    *grid.Client
 	cache         *cache.RedisClient
 	pgRepo        *repository.PostgresRepo
 	trendsService *TrendsService
+	rosters       *RosterService
+	alpha         float64 // significance level for advantages
+	halfLifeDays  float64 // default half-life of the recency-weighted form
+	patches       *patch.Calendar
 }
 
-func NewComparisonService(gc *grid.Client, rc *cache.RedisClient, pg *repository.PostgresRepo) *ComparisonService {
+func NewComparisonService(gc *grid.Client, rc *cache.RedisClient, pg *repository.PostgresRepo, alpha, halfLifeDays float64, patches *patch.Calendar) *ComparisonService {
 	return &ComparisonService{
 		gridClient:    gc,
 		cache:         rc,
 		pgRepo:        pg,
-		trendsService: NewTrendsService(gc, rc),
+		trendsService: NewTrendsService(gc, rc, pg, patches),
+		rosters:       NewRosterService(pg),
+		alpha:         alpha,
+		halfLifeDays:  halfLifeDays,
+		patches:       patches,
 	}
 }
 
-func (s *ComparisonService) CompareTeams(ctx context.Context, team1Name, team2Name, title string, timeWindow models.TimeWindow, tournamentIDs []string) (*models.ComparisonReport, error) {
+func (s *ComparisonService) CompareTeams(ctx context.Context, team1Name, team2Name, title string, timeWindow models.TimeWindow, tournamentIDs []string, opts models.CompareOptions) (*models.ComparisonReport, error) {
 	// Get stats directly by team name (no need for FindTeamByName)
-	stats1, err1 := s.gridClient.GetTeamStatistics(ctx, team1Name, title, timeWindow, tournamentIDs)
+	stats1, err1 := s.fetchStats(ctx, team1Name, title, timeWindow, tournamentIDs, opts)
 	if err1 != nil {
 		return nil, fmt.Errorf("failed to fetch stats for %s: %w", team1Name, err1)
 	}
 
-	stats2, err2 := s.gridClient.GetTeamStatistics(ctx, team2Name, title, timeWindow, tournamentIDs)
+	stats2, err2 := s.fetchStats(ctx, team2Name, title, timeWindow, tournamentIDs, opts)
 	if err2 != nil {
 		return nil, fmt.Errorf("failed to fetch stats for %s: %w", team2Name, err2)
 	}
 
-	// Calculate confidence scores
-	stats1.Confidence = CalculateConfidence(stats1.SampleSize, stats1.MatchesPlayed, timeWindow)
-	stats2.Confidence = CalculateConfidence(stats2.SampleSize, stats2.MatchesPlayed, timeWindow)
+	// Calculate confidence intervals and scores
+	ApplyIntervals(stats1)
+	ApplyIntervals(stats2)
+	ApplyScheduleAdjustment(stats1)
+	ApplyScheduleAdjustment(stats2)
+
+	halfLife := opts.HalfLifeDays
+	if halfLife <= 0 {
+		halfLife = s.halfLifeDays
+	}
+	if halfLife <= 0 {
+		halfLife = DefaultFormHalfLifeDays
+	}
+	// Historic ranges measure recency from the end of the range
+	now := time.Now()
+	if opts.DateRange != nil && opts.DateRange.To.Before(now) {
+		now = opts.DateRange.To
+	}
+	ApplyRecencyForm(stats1, halfLife, now)
+	ApplyRecencyForm(stats2, halfLife, now)
+	ApplyPatchBreakdown(stats1, s.patches, title)
+	ApplyPatchBreakdown(stats2, s.patches, title)
+	s.applyEventStats(stats1, title)
+	s.applyEventStats(stats2, title)
+
+	stats1.Confidence = CalculateConfidence(stats1, timeWindow)
+	stats2.Confidence = CalculateConfidence(stats2, timeWindow)
 
-	// Generate warnings based on confidence levels
+	// Generate warnings based on confidence levels and widened windows
 	warnings := GenerateWarnings(team1Name, stats1.Confidence, team2Name, stats2.Confidence)
+	warnings = append(warnings, GenerateWindowWarnings(team1Name, stats1, team2Name, stats2)...)
 
 	// Build report
 	report := &models.ComparisonReport{
@@ -57,18 +108,23 @@ func (s *ComparisonService) CompareTeams(ctx context.Context, team1Name, team2Na
 		},
 		Advantages: models.Advantages{},
 		DataQuality: models.DataQuality{
-			Team1Matches: stats1.MatchesPlayed,
-			Team2Matches: stats2.MatchesPlayed,
-			TimeRange:    timeWindow,
+			Team1Matches:       stats1.MatchesPlayed,
+			Team2Matches:       stats2.MatchesPlayed,
+			TimeRange:          effectiveWindow(stats1, stats2),
+			RequestedTimeRange: timeWindow,
+			DateRange:          opts.DateRange,
+			Strict:             opts.Strict,
+			Team1Window:        stats1.Window,
+			Team2Window:        stats2.Window,
 		},
 		Warnings: warnings,
 	}
 
-	s.calculateAdvantages(report)
+	s.calculateAdvantages(report, stats1, stats2, opts.Weighting)
 
 	// Optionally add recent trends if analyzing longer periods
-	if timeWindow == models.Last3Months || timeWindow == models.Last6Months || timeWindow == models.LastYear {
-		recentTrends := s.analyzeRecentTrends(ctx, team1Name, team2Name, title, tournamentIDs)
+	if opts.DateRange != nil || timeWindow == models.Last3Months || timeWindow == models.Last6Months || timeWindow == models.LastYear {
+		recentTrends := s.analyzeRecentTrends(ctx, team1Name, team2Name, title, tournamentIDs, opts.DateRange)
 		if recentTrends != nil {
 			report.RecentTrends = recentTrends
 		}
@@ -77,11 +133,83 @@ func (s *ComparisonService) CompareTeams(ctx context.Context, team1Name, team2Na
 	return report, nil
 }
 
+// effectiveWindow is the widest window any of the teams' stats came from after
+// fallback widening, so the comparison covers no more than it. Stats over an
+// explicit date range have no window; if none has one the result is empty.
+func effectiveWindow(stats ...*models.TeamStats) models.TimeWindow {
+	now := time.Now()
+	var widest models.TimeWindow
+	for _, s := range stats {
+		window := s.ActualTimeWindow
+		if window == "" {
+			continue
+		}
+		if widest == "" || window.Cutoff(now).Before(widest.Cutoff(now)) {
+			widest = window
+		}
+	}
+	return widest
+}
+
+// fetchStats loads a team's stats since its own current lineup took over with
+// SinceRosterChange, for the date range when one is given, otherwise for the
+// time window
+func (s *ComparisonService) fetchStats(ctx context.Context, teamName, title string, timeWindow models.TimeWindow, tournamentIDs []string, opts models.CompareOptions) (*models.TeamStats, error) {
+	if opts.SinceRosterChange {
+		dateRange, err := s.rosters.CurrentLineupRange(title, teamName)
+		if err != nil {
+			return nil, err
+		}
+		return s.gridClient.GetTeamStatisticsBetween(ctx, teamName, title, *dateRange, tournamentIDs)
+	}
+	if opts.DateRange != nil {
+		return s.gridClient.GetTeamStatisticsBetween(ctx, teamName, title, *opts.DateRange, tournamentIDs)
+	}
+	return s.gridClient.GetTeamStatistics(ctx, teamName, title, timeWindow, tournamentIDs, opts.Strict)
+}
+
+// applyEventStats adds opening-duel and trade stats, Valorant economy or LoL
+// objective stats, and side splits for the series whose events were ingested
+// with admin sync-events. LoL sides come from the stored games.
+func (s *ComparisonService) applyEventStats(stats *models.TeamStats, title string) {
+	title = models.CanonicalTitle(title)
+	eventTypes := append([]string{}, openingEventTypes...)
+	switch title {
+	case "valorant":
+		eventTypes = append(append(eventTypes, economyEventTypes...), valorantSideEventTypes...)
+	case "lol":
+		eventTypes = append(eventTypes, objectiveEventTypes...)
+	}
+
+	ids := seriesIDs(stats.Series, false)
+	events, err := s.pgRepo.ListSeriesEvents(ids, eventTypes...)
+	if err != nil {
+		fmt.Printf("[WARN] Failed to load series events: %v\n", err)
+		return
+	}
+	ApplyOpenings(stats, events, TradeWindow)
+	switch title {
+	case "valorant":
+		ApplyEconomy(stats, events)
+		ApplyValorantSides(stats, events)
+	case "lol":
+		ApplyObjectives(stats, events)
+		games, err := s.pgRepo.ListGameDrafts(ids)
+		if err != nil {
+			fmt.Printf("[WARN] Failed to load game sides: %v\n", err)
+			return
+		}
+		ApplyLoLSides(stats, games, events)
+	}
+}
+
 // buildComparisonStats extracts duplicate code for building comparison stats
 func (s *ComparisonService) buildComparisonStats(stats *models.TeamStats) models.ComparisonStats {
 	return models.ComparisonStats{
-		WinRate: stats.WinRate,
-		KDRatio: stats.KDRatio,
+		WinRate:         stats.WinRate,
+		WinRateInterval: stats.WinRateInterval,
+		KDRatio:         stats.KDRatio,
+		KDRatioInterval: stats.KDRatioInterval,
 		Kills: models.StatVal{
 			Avg:   stats.KillsAvg,
 			Total: stats.Kills,
@@ -92,13 +220,37 @@ func (s *ComparisonService) buildComparisonStats(stats *models.TeamStats) models
 		},
 		CurrentStreak: stats.CurrentStreak,
 		Confidence:    stats.Confidence,
+
+		ScheduleAdjusted: stats.ScheduleAdjusted,
+		Form:             stats.Form,
+		Patches:          stats.Patches,
+		Openings:         stats.Openings,
+		Economy:          stats.Economy,
+		Objectives:       stats.Objectives,
+		Side:             stats.Side,
+
+		SeriesIDs:   seriesIDs(stats.Series, false),
+		KDSeriesIDs: seriesIDs(stats.Series, true),
+	}
+}
+
+// seriesIDs lists the IDs of the given series, only those with downloaded
+// kills and deaths when withStats is set
+func seriesIDs(series []models.SeriesResult, withStats bool) []string {
+	ids := []string{}
+	for _, s := range series {
+		if withStats && !s.HasStats {
+			continue
+		}
+		ids = append(ids, s.SeriesID)
 	}
+	return ids
 }
 
-func (s *ComparisonService) analyzeRecentTrends(ctx context.Context, team1Name, team2Name, title string, tournamentIDs []string) *models.RecentTrends {
+func (s *ComparisonService) analyzeRecentTrends(ctx context.Context, team1Name, team2Name, title string, tournamentIDs []string, dateRange *models.DateRange) *models.RecentTrends {
 	// Try to get trends for both teams (non-blocking)
-	trends1, err1 := s.trendsService.AnalyzeTrends(ctx, team1Name, title, tournamentIDs)
-	trends2, err2 := s.trendsService.AnalyzeTrends(ctx, team2Name, title, tournamentIDs)
+	trends1, err1 := s.trendsService.AnalyzeTrends(ctx, team1Name, title, tournamentIDs, dateRange)
+	trends2, err2 := s.trendsService.AnalyzeTrends(ctx, team2Name, title, tournamentIDs, dateRange)
 
 	// If both fail, don't include trends
 	if err1 != nil && err2 != nil {
@@ -136,26 +288,210 @@ func (s *ComparisonService) filterSignificantAlerts(alerts []models.TrendAlert)
 	return significantAlerts
 }
 
-func (s *ComparisonService) calculateAdvantages(report *models.ComparisonReport) {
-	wrDiff := report.Team1.Stats.WinRate - report.Team2.Stats.WinRate
-	if wrDiff >= 0.05 {
-		report.Advantages.Team1 = append(report.Advantages.Team1, fmt.Sprintf("Higher win rate (+%.0f%%)", wrDiff*100))
-	} else if wrDiff <= -0.05 {
-		report.Advantages.Team2 = append(report.Advantages.Team2, fmt.Sprintf("Higher win rate (+%.0f%%)", -wrDiff*100))
+// calculateAdvantages lists the edges each team holds. Win rate and K/D gaps are
+// only reported as advantages when they are significant at the configured alpha;
+// gaps that fail the test are listed as inconclusive with their p-value. With
+// recency weighting, win rate and K/D are compared on each team's form. Otherwise,
+// when both teams have enough opponents with known records, they are compared on
+// their schedule-adjusted values.
+func (s *ComparisonService) calculateAdvantages(report *models.ComparisonReport, stats1, stats2 *models.TeamStats, weighting string) {
+	alpha := s.alpha
+	if alpha <= 0 || alpha >= 1 {
+		alpha = DefaultSignificanceLevel
+	}
+	report.Advantages.Alpha = alpha
+
+	recency := weighting == models.WeightingRecency && stats1.Form != nil && stats2.Form != nil
+	adjusted := !recency &&
+		stats1.ScheduleAdjusted != nil && stats1.ScheduleAdjusted.Sufficient &&
+		stats2.ScheduleAdjusted != nil && stats2.ScheduleAdjusted.Sufficient
+
+	// Win rate: Fisher's exact or two-proportion z-test on series won
+	wr1, wr2 := stats1.WinRate, stats2.WinRate
+	wins1, wins2 := stats1.Wins, stats2.Wins
+	n1, n2 := stats1.MatchesPlayed, stats2.MatchesPlayed
+	wrLabel := "win rate"
+	switch {
+	case recency:
+		// Weighted records are worth their effective sample size, not the raw count
+		wr1, wr2 = stats1.Form.WinRate, stats2.Form.WinRate
+		n1, n2 = effectiveCount(stats1.Form), effectiveCount(stats2.Form)
+		wins1 = int(math.Round(wr1 * float64(n1)))
+		wins2 = int(math.Round(wr2 * float64(n2)))
+		wrLabel = "recent form win rate"
+	case adjusted:
+		// Test the adjusted record as if it were won over the same number of series
+		wr1, wr2 = stats1.ScheduleAdjusted.WinRate, stats2.ScheduleAdjusted.WinRate
+		wins1 = int(math.Round(wr1 * float64(n1)))
+		wins2 = int(math.Round(wr2 * float64(n2)))
+		wrLabel = "schedule-adjusted win rate"
+	}
+	wrDiff := wr1 - wr2
+	if math.Abs(wrDiff) >= minWinRateGap {
+		p, test := appstats.ProportionTest(wins1, n1, wins2, n2)
+		s.addAdvantage(report, wrDiff > 0, alpha, models.Advantage{
+			Metric:           models.MetricWinRate,
+			Message:          fmt.Sprintf("Higher %s (+%.0f%%)", wrLabel, math.Abs(wrDiff)*100),
+			Difference:       math.Abs(wrDiff),
+			EffectSize:       math.Abs(appstats.CohensH(wr1, wr2)),
+			PValue:           &p,
+			Test:             test,
+			ScheduleAdjusted: adjusted,
+			RecencyWeighted:  recency,
+		}, n1, n2, "series")
 	}
 
-	kdDiff := report.Team1.Stats.KDRatio - report.Team2.Stats.KDRatio
-	if kdDiff >= 0.1 {
-		report.Advantages.Team1 = append(report.Advantages.Team1, fmt.Sprintf("Better K/D (+%.1f)", kdDiff))
-	} else if kdDiff <= -0.1 {
-		report.Advantages.Team2 = append(report.Advantages.Team2, fmt.Sprintf("Better K/D (+%.1f)", -kdDiff))
+	// K/D: permutation test on per-series K/D ratios
+	kd1, kd2 := stats1.KDRatio, stats2.KDRatio
+	kdRecency := recency && stats1.Form.KDRatio > 0 && stats2.Form.KDRatio > 0
+	kdAdjusted := adjusted && stats1.ScheduleAdjusted.KDRatio > 0 && stats2.ScheduleAdjusted.KDRatio > 0
+	kdLabel := "K/D"
+	switch {
+	case kdRecency:
+		kd1, kd2 = stats1.Form.KDRatio, stats2.Form.KDRatio
+		kdLabel = "recent form K/D"
+	case kdAdjusted:
+		kd1, kd2 = stats1.ScheduleAdjusted.KDRatio, stats2.ScheduleAdjusted.KDRatio
+		kdLabel = "schedule-adjusted K/D"
+	}
+	kdDiff := kd1 - kd2
+	if math.Abs(kdDiff) >= minKDGap {
+		samples1, samples2 := seriesKDRatios(stats1), seriesKDRatios(stats2)
+		if kdAdjusted {
+			samples1, samples2 = adjustedSeriesKDRatios(stats1), adjustedSeriesKDRatios(stats2)
+		}
+		adv := models.Advantage{
+			Metric:           models.MetricKDRatio,
+			Message:          fmt.Sprintf("Better %s (+%.1f)", kdLabel, math.Abs(kdDiff)),
+			Difference:       math.Abs(kdDiff),
+			ScheduleAdjusted: kdAdjusted,
+			RecencyWeighted:  kdRecency,
+		}
+		if len(samples1) >= 2 && len(samples2) >= 2 {
+			var p float64
+			if kdRecency {
+				weights1, weights2 := seriesRecencyWeights(stats1), seriesRecencyWeights(stats2)
+				_, p = appstats.WeightedPermutationTest(samples1, weights1, samples2, weights2, permutationRounds, permutationSeed)
+				adv.Test = "weighted-permutation"
+			} else {
+				_, p = appstats.PermutationTest(samples1, samples2, permutationRounds, permutationSeed)
+				adv.Test = "permutation"
+			}
+			adv.PValue = &p
+			adv.EffectSize = math.Abs(appstats.CohensD(samples1, samples2))
+		}
+		s.addAdvantage(report, kdDiff > 0, alpha, adv, len(samples1), len(samples2), "series")
+	}
+
+	if stats1.Objectives != nil && stats2.Objectives != nil {
+		s.objectiveAdvantages(report, stats1.Objectives, stats2.Objectives, alpha)
 	}
 
+	// Streaks are a description of recent results rather than an estimate, so they are not tested
 	s1 := report.Team1.Stats.CurrentStreak
 	s2 := report.Team2.Stats.CurrentStreak
 	if s1.Type == "win" && (s2.Type != "win" || s1.Count > s2.Count) {
-		report.Advantages.Team1 = append(report.Advantages.Team1, "Stronger win streak")
+		report.Advantages.Team1 = append(report.Advantages.Team1, models.Advantage{
+			Team: report.Team1.Name, Metric: models.MetricStreak, Message: "Stronger win streak",
+			Difference: float64(s1.Count - winStreak(s2)),
+		})
 	} else if s2.Type == "win" && (s1.Type != "win" || s2.Count > s1.Count) {
-		report.Advantages.Team2 = append(report.Advantages.Team2, "Stronger win streak")
+		report.Advantages.Team2 = append(report.Advantages.Team2, models.Advantage{
+			Team: report.Team2.Name, Metric: models.MetricStreak, Message: "Stronger win streak",
+			Difference: float64(s2.Count - winStreak(s1)),
+		})
+	}
+}
+
+// objectiveAdvantages compares LoL gold leads at 15:00 (permutation test on
+// per-game differences) and first dragon, herald and baron rates (proportion
+// tests), since kills alone misjudge LoL teams
+func (s *ComparisonService) objectiveAdvantages(report *models.ComparisonReport, obj1, obj2 *models.ObjectiveStats, alpha float64) {
+	goldDiff := obj1.GoldDiff15 - obj2.GoldDiff15
+	if math.Abs(goldDiff) >= minGoldGap {
+		adv := models.Advantage{
+			Metric:     models.MetricGoldDiff15,
+			Message:    fmt.Sprintf("Bigger gold lead at 15 minutes (+%.0f)", math.Abs(goldDiff)),
+			Difference: math.Abs(goldDiff),
+		}
+		if len(obj1.GoldDiffs15) >= 2 && len(obj2.GoldDiffs15) >= 2 {
+			_, p := appstats.PermutationTest(obj1.GoldDiffs15, obj2.GoldDiffs15, permutationRounds, permutationSeed)
+			adv.PValue = &p
+			adv.Test = "permutation"
+			adv.EffectSize = math.Abs(appstats.CohensD(obj1.GoldDiffs15, obj2.GoldDiffs15))
+		}
+		s.addAdvantage(report, goldDiff > 0, alpha, adv, len(obj1.GoldDiffs15), len(obj2.GoldDiffs15), "games")
+	}
+
+	for _, first := range []struct {
+		metric, label string
+		obj1, obj2    models.FirstObjective
+	}{
+		{models.MetricFirstDragon, "first dragon", obj1.FirstDragon, obj2.FirstDragon},
+		{models.MetricFirstHerald, "first herald", obj1.FirstHerald, obj2.FirstHerald},
+		{models.MetricFirstBaron, "first baron", obj1.FirstBaron, obj2.FirstBaron},
+	} {
+		if first.obj1.Games == 0 || first.obj2.Games == 0 {
+			continue
+		}
+		diff := first.obj1.Rate - first.obj2.Rate
+		if math.Abs(diff) < minWinRateGap {
+			continue
+		}
+		p, test := appstats.ProportionTest(first.obj1.Firsts, first.obj1.Games, first.obj2.Firsts, first.obj2.Games)
+		s.addAdvantage(report, diff > 0, alpha, models.Advantage{
+			Metric:     first.metric,
+			Message:    fmt.Sprintf("Takes %s more often (+%.0f%%)", first.label, math.Abs(diff)*100),
+			Difference: math.Abs(diff),
+			EffectSize: math.Abs(appstats.CohensH(first.obj1.Rate, first.obj2.Rate)),
+			PValue:     &p,
+			Test:       test,
+		}, first.obj1.Games, first.obj2.Games, "games")
 	}
-}
\ No newline at end of file
+}
+
+// addAdvantage files a tested advantage under the leading team when significant,
+// otherwise under Inconclusive with an explanation. unit names what n1 and n2
+// count.
+func (s *ComparisonService) addAdvantage(report *models.ComparisonReport, team1Leads bool, alpha float64, adv models.Advantage, n1, n2 int, unit string) {
+	adv.Team = report.Team2.Name
+	if team1Leads {
+		adv.Team = report.Team1.Name
+	}
+
+	if adv.PValue == nil {
+		adv.Message = fmt.Sprintf("%s - not enough data to test (%d vs %d %s)", adv.Message, n1, n2, unit)
+		report.Advantages.Inconclusive = append(report.Advantages.Inconclusive, adv)
+		return
+	}
+
+	if *adv.PValue > alpha {
+		adv.Message = fmt.Sprintf("%s - not significant at α=%.2f (p=%.2f, %d vs %d %s)", adv.Message, alpha, *adv.PValue, n1, n2, unit)
+		report.Advantages.Inconclusive = append(report.Advantages.Inconclusive, adv)
+		return
+	}
+
+	if team1Leads {
+		report.Advantages.Team1 = append(report.Advantages.Team1, adv)
+	} else {
+		report.Advantages.Team2 = append(report.Advantages.Team2, adv)
+	}
+}
+
+// seriesKDRatios returns the K/D of every series with downloaded stats
+func seriesKDRatios(stats *models.TeamStats) []float64 {
+	var ratios []float64
+	for _, series := range stats.Series {
+		if series.HasStats && series.Deaths > 0 {
+			ratios = append(ratios, float64(series.Kills)/float64(series.Deaths))
+		}
+	}
+	return ratios
+}
+
+func winStreak(streak models.Streak) int {
+	if streak.Type == "win" {
+		return streak.Count
+	}
+	return 0
+}
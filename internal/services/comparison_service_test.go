@@ -1,94 +1,183 @@
 package services
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/yourusername/esports-scouting-backend/internal/models"
 )
 
-func TestCalculateAdvantages(t *testing.T) {
+// buildStats creates team stats with one series result per K/D sample
+func buildStats(wins, matches int, kd []float64, streak models.Streak) *models.TeamStats {
+	stats := &models.TeamStats{
+		Wins:          wins,
+		MatchesPlayed: matches,
+		SampleSize:    matches,
+		WinRate:       float64(wins) / float64(matches),
+		CurrentStreak: streak,
+	}
+	for i, ratio := range kd {
+		deaths := 50
+		kills := int(ratio * float64(deaths))
+		stats.Kills += kills
+		stats.Deaths += deaths
+		stats.Series = append(stats.Series, models.SeriesResult{
+			SeriesID: string(rune('a' + i)),
+			Won:      i < wins,
+			HasStats: true,
+			Kills:    kills,
+			Deaths:   deaths,
+		})
+	}
+	if stats.Deaths > 0 {
+		stats.KDRatio = float64(stats.Kills) / float64(stats.Deaths)
+	}
+	return stats
+}
+
+func comparisonFor(stats1, stats2 *models.TeamStats) *models.ComparisonReport {
 	s := &ComparisonService{}
+	return &models.ComparisonReport{
+		Team1: models.ComparisonTeamData{Name: "Team1", Stats: s.buildComparisonStats(stats1)},
+		Team2: models.ComparisonTeamData{Name: "Team2", Stats: s.buildComparisonStats(stats2)},
+	}
+}
+
+func metrics(advantages []models.Advantage) []string {
+	var out []string
+	for _, adv := range advantages {
+		out = append(out, adv.Metric)
+	}
+	return out
+}
+
+func TestCalculateAdvantages(t *testing.T) {
+	strongKD := []float64{1.4, 1.6, 1.3, 1.5, 1.7, 1.45, 1.55, 1.6, 1.5, 1.4}
+	weakKD := []float64{0.8, 0.9, 0.85, 1.0, 0.7, 0.95, 0.9, 0.8, 0.85, 0.9}
+	evenKD := []float64{1.0, 1.1, 0.9, 1.05, 0.95}
 
 	tests := []struct {
-		name           string
-		report         *models.ComparisonReport
-		expectedTeam1  []string
-		expectedTeam2  []string
+		name               string
+		alpha              float64
+		stats1             *models.TeamStats
+		stats2             *models.TeamStats
+		expectedTeam1      []string
+		expectedTeam2      []string
+		expectInconclusive []string
 	}{
 		{
-			name: "Team 1 better in everything",
-			report: &models.ComparisonReport{
-				Team1: models.ComparisonTeamData{
-					Stats: models.ComparisonStats{
-						WinRate: 0.8,
-						KDRatio: 1.5,
-						CurrentStreak: models.Streak{Type: "win", Count: 5},
-					},
-				},
-				Team2: models.ComparisonTeamData{
-					Stats: models.ComparisonStats{
-						WinRate: 0.5,
-						KDRatio: 1.0,
-						CurrentStreak: models.Streak{Type: "loss", Count: 1},
-					},
-				},
-			},
-			expectedTeam1: []string{"Higher win rate (+30%)", "Better K/D (+0.5)", "Stronger win streak"},
-			expectedTeam2: nil,
+			name:          "Team 1 better in everything",
+			stats1:        buildStats(24, 30, strongKD, models.Streak{Type: "win", Count: 5}),
+			stats2:        buildStats(10, 30, weakKD, models.Streak{Type: "loss", Count: 1}),
+			expectedTeam1: []string{models.MetricWinRate, models.MetricKDRatio, models.MetricStreak},
+		},
+		{
+			name:          "Team 2 better in everything",
+			stats1:        buildStats(10, 30, weakKD, models.Streak{Type: "loss", Count: 1}),
+			stats2:        buildStats(24, 30, strongKD, models.Streak{Type: "win", Count: 5}),
+			expectedTeam2: []string{models.MetricWinRate, models.MetricKDRatio, models.MetricStreak},
+		},
+		{
+			name:   "Close match",
+			stats1: buildStats(12, 20, evenKD, models.Streak{Type: "win", Count: 2}),
+			stats2: buildStats(12, 20, evenKD, models.Streak{Type: "win", Count: 2}),
+		},
+		{
+			name:               "Three matches each is not enough evidence",
+			stats1:             buildStats(2, 3, []float64{1.3, 1.1, 1.4}, models.Streak{Type: "loss", Count: 1}),
+			stats2:             buildStats(1, 3, []float64{0.9, 1.2, 0.8}, models.Streak{Type: "loss", Count: 1}),
+			expectInconclusive: []string{models.MetricWinRate, models.MetricKDRatio},
 		},
 		{
-			name: "Team 2 better in everything",
-			report: &models.ComparisonReport{
-				Team1: models.ComparisonTeamData{
-					Stats: models.ComparisonStats{
-						WinRate: 0.5,
-						KDRatio: 1.0,
-						CurrentStreak: models.Streak{Type: "loss", Count: 1},
-					},
-				},
-				Team2: models.ComparisonTeamData{
-					Stats: models.ComparisonStats{
-						WinRate: 0.8,
-						KDRatio: 1.5,
-						CurrentStreak: models.Streak{Type: "win", Count: 5},
-					},
-				},
-			},
-			expectedTeam1: nil,
-			expectedTeam2: []string{"Higher win rate (+30%)", "Better K/D (+0.5)", "Stronger win streak"},
+			name:               "K/D without per-series data is not tested",
+			stats1:             &models.TeamStats{Wins: 5, MatchesPlayed: 10, WinRate: 0.5, KDRatio: 1.4},
+			stats2:             &models.TeamStats{Wins: 5, MatchesPlayed: 10, WinRate: 0.5, KDRatio: 1.0},
+			expectInconclusive: []string{models.MetricKDRatio},
 		},
 		{
-			name: "Close match",
-			report: &models.ComparisonReport{
-				Team1: models.ComparisonTeamData{
-					Stats: models.ComparisonStats{
-						WinRate: 0.61,
-						KDRatio: 1.25,
-						CurrentStreak: models.Streak{Type: "win", Count: 2},
-					},
-				},
-				Team2: models.ComparisonTeamData{
-					Stats: models.ComparisonStats{
-						WinRate: 0.60,
-						KDRatio: 1.20,
-						CurrentStreak: models.Streak{Type: "win", Count: 2},
-					},
-				},
-			},
-			expectedTeam1: nil,
-			expectedTeam2: nil,
+			name:          "Looser alpha accepts weaker evidence",
+			alpha:         0.2,
+			stats1:        buildStats(3, 3, nil, models.Streak{}),
+			stats2:        buildStats(0, 3, nil, models.Streak{}),
+			expectedTeam1: []string{models.MetricWinRate},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s.calculateAdvantages(tt.report)
-			
-			if len(tt.report.Advantages.Team1) != len(tt.expectedTeam1) {
-				t.Errorf("Team1 advantages length mismatch: got %v, want %v", tt.report.Advantages.Team1, tt.expectedTeam1)
+			s := &ComparisonService{alpha: tt.alpha}
+			report := comparisonFor(tt.stats1, tt.stats2)
+			s.calculateAdvantages(report, tt.stats1, tt.stats2, models.WeightingUniform)
+
+			got1, got2 := metrics(report.Advantages.Team1), metrics(report.Advantages.Team2)
+			gotInconclusive := metrics(report.Advantages.Inconclusive)
+			if strings.Join(got1, ",") != strings.Join(tt.expectedTeam1, ",") {
+				t.Errorf("Team1 advantages: got %v, want %v", got1, tt.expectedTeam1)
+			}
+			if strings.Join(got2, ",") != strings.Join(tt.expectedTeam2, ",") {
+				t.Errorf("Team2 advantages: got %v, want %v", got2, tt.expectedTeam2)
+			}
+			if strings.Join(gotInconclusive, ",") != strings.Join(tt.expectInconclusive, ",") {
+				t.Errorf("Inconclusive: got %v, want %v", gotInconclusive, tt.expectInconclusive)
+			}
+
+			for _, adv := range append(report.Advantages.Team1, report.Advantages.Team2...) {
+				if adv.Metric != models.MetricStreak && (adv.PValue == nil || *adv.PValue > report.Advantages.Alpha) {
+					t.Errorf("%s advantage reported without a significant p-value", adv.Metric)
+				}
+			}
+		})
+	}
+}
+
+func TestSeriesIDs(t *testing.T) {
+	series := []models.SeriesResult{
+		{SeriesID: "s3", HasStats: true},
+		{SeriesID: "s2"},
+		{SeriesID: "s1", HasStats: true},
+	}
+
+	tests := []struct {
+		name      string
+		series    []models.SeriesResult
+		withStats bool
+		want      []string
+	}{
+		{"all series", series, false, []string{"s3", "s2", "s1"}},
+		{"only with stats", series, true, []string{"s3", "s1"}},
+		{"none", nil, false, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seriesIDs(tt.series, tt.withStats)
+			if got == nil || strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("seriesIDs = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []models.TimeWindow
+		want    models.TimeWindow
+	}{
+		{"same window", []models.TimeWindow{models.Last3Months, models.Last3Months}, models.Last3Months},
+		{"one team widened", []models.TimeWindow{models.LastMonth, models.Last6Months}, models.Last6Months},
+		{"widest second", []models.TimeWindow{models.LastYear, models.LastWeek}, models.LastYear},
+		{"explicit date range", []models.TimeWindow{"", ""}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stats []*models.TeamStats
+			for _, w := range tt.windows {
+				stats = append(stats, &models.TeamStats{ActualTimeWindow: w})
 			}
-			if len(tt.report.Advantages.Team2) != len(tt.expectedTeam2) {
-				t.Errorf("Team2 advantages length mismatch: got %v, want %v", tt.report.Advantages.Team2, tt.expectedTeam2)
+			if got := effectiveWindow(stats...); got != tt.want {
+				t.Errorf("effectiveWindow(%v) = %q, want %q", tt.windows, got, tt.want)
 			}
 		})
 	}
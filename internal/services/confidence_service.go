@@ -2,49 +2,79 @@ package services
 
 import (
 	"fmt"
+	"math"
+	"time"
+
 	"github.com/yourusername/esports-scouting-backend/internal/models"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+)
+
+// Interval width thresholds for the 95% Wilson interval on win rate. A width of
+// 0.40 means the true win rate is pinned down to roughly ±20 points.
+const (
+	highConfidenceMaxWidth   = 0.40
+	mediumConfidenceMaxWidth = 0.60
 )
 
-// CalculateConfidence determines confidence level based on sample size and total matches
-func CalculateConfidence(sampleSize int, totalTeamMatches int, timeWindow models.TimeWindow) models.Confidence {
+// ApplyIntervals fills in the 95% confidence intervals for win rate (Wilson score)
+// and K/D ratio (Poisson rate ratio) from the raw counts in stats
+func ApplyIntervals(stats *models.TeamStats) {
+	lower, upper := appstats.Wilson(stats.Wins, stats.MatchesPlayed, appstats.Z95)
+	stats.WinRateInterval = models.Interval{Lower: lower, Upper: upper, Level: 0.95}
+
+	lower, upper = appstats.RateRatio(stats.Kills, stats.Deaths, appstats.Z95)
+	if math.IsInf(upper, 1) {
+		// No kills or deaths recorded - report the point estimate without a range
+		lower, upper = stats.KDRatio, stats.KDRatio
+	}
+	stats.KDRatioInterval = models.Interval{Lower: lower, Upper: upper, Level: 0.95}
+}
+
+// CalculateConfidence determines confidence from the width of the win rate interval.
+// ApplyIntervals must have been called on stats first. The reliability score is the
+// share of the 0-100% range the interval rules out, so it grows with sample size and
+// is higher for lopsided records than for coin-flip ones.
+func CalculateConfidence(stats *models.TeamStats, timeWindow models.TimeWindow) models.Confidence {
+	width := stats.WinRateInterval.Upper - stats.WinRateInterval.Lower
+	if stats.MatchesPlayed == 0 {
+		width = 1
+	}
+
 	var level models.ConfidenceLevel
-	var reliabilityScore int
-	var reasoning string
+	switch {
+	case width <= highConfidenceMaxWidth:
+		level = models.ConfidenceHigh
+	case width <= mediumConfidenceMaxWidth:
+		level = models.ConfidenceMedium
+	default:
+		level = models.ConfidenceLow
+	}
 
-	// Determine confidence level based on thresholds
-	if totalTeamMatches >= 18 {
-		// Team has significant match history
-		if sampleSize >= 18 {
-			level = models.ConfidenceHigh
-			reliabilityScore = 95
-		} else if sampleSize >= 7 {
-			level = models.ConfidenceMedium
-			reliabilityScore = 70
-		} else {
-			level = models.ConfidenceLow
-			reliabilityScore = 40
-		}
-	} else {
-		// Team has limited match history
-		if sampleSize >= 15 {
-			level = models.ConfidenceHigh
-			reliabilityScore = 90
-		} else if sampleSize >= 5 {
-			level = models.ConfidenceMedium
-			reliabilityScore = 60
-		} else {
-			level = models.ConfidenceLow
-			reliabilityScore = 30
-		}
+	reliabilityScore := int(math.Round((1 - width) * 100))
+	if reliabilityScore < 0 {
+		reliabilityScore = 0
+	}
+
+	// Describe the window the data really covers, not the one requested
+	if stats.ActualTimeWindow != "" {
+		timeWindow = stats.ActualTimeWindow
 	}
 
 	// Generate reasoning string
+	var reasoning string
 	timeWindowStr := formatTimeWindow(timeWindow)
-	if sampleSize == totalTeamMatches {
-		reasoning = fmt.Sprintf("Based on all %d matches available %s", sampleSize, timeWindowStr)
+	if stats.DateRange != nil {
+		timeWindowStr = formatDateRange(*stats.DateRange)
+	}
+	if stats.Window != nil && !stats.Window.FirstMatch.IsZero() {
+		timeWindowStr += fmt.Sprintf(", played %s", formatMatchSpan(stats.Window))
+	}
+	if stats.SampleSize == stats.MatchesPlayed {
+		reasoning = fmt.Sprintf("Based on all %d matches available %s", stats.SampleSize, timeWindowStr)
 	} else {
-		reasoning = fmt.Sprintf("Based on %d of %d total matches %s", sampleSize, totalTeamMatches, timeWindowStr)
+		reasoning = fmt.Sprintf("Based on %d of %d total matches %s", stats.SampleSize, stats.MatchesPlayed, timeWindowStr)
 	}
+	reasoning += fmt.Sprintf(" (win rate %s)", FormatRateInterval(stats.WinRate, stats.WinRateInterval))
 
 	// Add context about confidence
 	switch level {
@@ -56,14 +86,23 @@ func CalculateConfidence(sampleSize int, totalTeamMatches int, timeWindow models
 		reasoning += " - limited data, predictions less reliable"
 	}
 
+	if stats.Window != nil && stats.Window.Expanded {
+		reasoning += fmt.Sprintf(". Window widened from %s: %s", formatTimeWindow(stats.Window.Requested), stats.Window.Reason)
+	}
+
 	return models.Confidence{
 		Level:            level,
-		SampleSize:       sampleSize,
+		SampleSize:       stats.SampleSize,
 		Reasoning:        reasoning,
 		ReliabilityScore: reliabilityScore,
 	}
 }
 
+// FormatRateInterval renders a rate with its interval, e.g. "55% (38–71%)"
+func FormatRateInterval(rate float64, interval models.Interval) string {
+	return fmt.Sprintf("%.0f%% (%.0f–%.0f%%)", rate*100, interval.Lower*100, interval.Upper*100)
+}
+
 // GenerateWarnings creates warning messages for low-confidence scenarios
 func GenerateWarnings(team1Name string, team1Confidence models.Confidence, team2Name string, team2Confidence models.Confidence) []string {
 	var warnings []string
@@ -101,6 +140,45 @@ func GenerateWarnings(team1Name string, team1Confidence models.Confidence, team2
 	return warnings
 }
 
+// GenerateWindowWarnings flags teams whose window was widened or that ended up
+// compared over different windows
+func GenerateWindowWarnings(team1Name string, stats1 *models.TeamStats, team2Name string, stats2 *models.TeamStats) []string {
+	var warnings []string
+
+	for _, team := range []struct {
+		name  string
+		stats *models.TeamStats
+	}{{team1Name, stats1}, {team2Name, stats2}} {
+		w := team.stats.Window
+		if w == nil {
+			continue
+		}
+		if w.Expanded {
+			warnings = append(warnings, fmt.Sprintf("%s: window widened from %s to %s (%s) - stats span %s",
+				team.name, w.Requested, w.Actual, w.Reason, formatMatchSpan(w)))
+		}
+		if w.Strict && team.stats.MatchesPlayed < 3 {
+			warnings = append(warnings, fmt.Sprintf("%s: strict mode kept %s with only %d matches", team.name, w.Requested, team.stats.MatchesPlayed))
+		}
+	}
+
+	if stats1.ActualTimeWindow != "" && stats2.ActualTimeWindow != "" && stats1.ActualTimeWindow != stats2.ActualTimeWindow {
+		warnings = append(warnings, fmt.Sprintf("Teams are compared over different windows (%s: %s, %s: %s)",
+			team1Name, stats1.ActualTimeWindow, team2Name, stats2.ActualTimeWindow))
+	}
+
+	return warnings
+}
+
+// formatMatchSpan gives the first and last match day, e.g. "2025-05-02 to 2025-07-28"
+func formatMatchSpan(w *models.WindowDisclosure) string {
+	first, last := w.FirstMatch.Format("2006-01-02"), w.LastMatch.Format("2006-01-02")
+	if first == last {
+		return first
+	}
+	return first + " to " + last
+}
+
 func formatTimeWindow(tw models.TimeWindow) string {
 	switch tw {
 	case models.LastWeek:
@@ -117,3 +195,9 @@ func formatTimeWindow(tw models.TimeWindow) string {
 		return "in the selected time period"
 	}
 }
+
+// formatDateRange describes an explicit range by its first and last day
+func formatDateRange(r models.DateRange) string {
+	last := r.To.Add(-time.Nanosecond)
+	return fmt.Sprintf("between %s and %s", r.From.Format("2006-01-02"), last.Format("2006-01-02"))
+}
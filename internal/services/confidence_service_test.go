@@ -0,0 +1,126 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestCalculateConfidence(t *testing.T) {
+	tests := []struct {
+		name          string
+		wins, matches int
+		expected      models.ConfidenceLevel
+	}{
+		{"Large even sample", 25, 50, models.ConfidenceHigh},
+		{"Lopsided medium sample", 14, 15, models.ConfidenceHigh},
+		{"Even medium sample", 5, 10, models.ConfidenceMedium},
+		{"Tiny sample", 2, 3, models.ConfidenceLow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := &models.TeamStats{
+				Wins:          tt.wins,
+				MatchesPlayed: tt.matches,
+				SampleSize:    tt.matches,
+				WinRate:       float64(tt.wins) / float64(tt.matches),
+				Kills:         tt.matches * 40,
+				Deaths:        tt.matches * 38,
+			}
+			ApplyIntervals(stats)
+			conf := CalculateConfidence(stats, models.Last3Months)
+
+			if conf.Level != tt.expected {
+				t.Errorf("level = %s, want %s (interval %.2f-%.2f)", conf.Level, tt.expected, stats.WinRateInterval.Lower, stats.WinRateInterval.Upper)
+			}
+			if stats.WinRateInterval.Lower > stats.WinRate || stats.WinRateInterval.Upper < stats.WinRate {
+				t.Errorf("win rate %.2f outside interval %.2f-%.2f", stats.WinRate, stats.WinRateInterval.Lower, stats.WinRateInterval.Upper)
+			}
+		})
+	}
+}
+
+func TestReliabilityGrowsWithSampleSize(t *testing.T) {
+	small := &models.TeamStats{Wins: 3, MatchesPlayed: 6, SampleSize: 6}
+	large := &models.TeamStats{Wins: 20, MatchesPlayed: 40, SampleSize: 40}
+	ApplyIntervals(small)
+	ApplyIntervals(large)
+
+	if CalculateConfidence(large, models.LastYear).ReliabilityScore <= CalculateConfidence(small, models.LastYear).ReliabilityScore {
+		t.Error("expected a larger sample to give a higher reliability score")
+	}
+}
+
+func TestConfidenceDisclosesWidenedWindow(t *testing.T) {
+	stats := &models.TeamStats{
+		Wins: 4, MatchesPlayed: 8, SampleSize: 8,
+		ActualTimeWindow: models.Last3Months,
+		Window: &models.WindowDisclosure{
+			Requested:  models.LastWeek,
+			Actual:     models.Last3Months,
+			Expanded:   true,
+			Reason:     "only 1 of the 3 matches needed in LAST_WEEK",
+			FirstMatch: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC),
+			LastMatch:  time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	ApplyIntervals(stats)
+
+	// The requested window must not leak into the reasoning
+	reasoning := CalculateConfidence(stats, models.LastWeek).Reasoning
+	for _, want := range []string{"over the last 3 months", "2025-05-02 to 2025-07-28", "widened from over the last week", "only 1 of the 3"} {
+		if !strings.Contains(reasoning, want) {
+			t.Errorf("reasoning %q missing %q", reasoning, want)
+		}
+	}
+}
+
+func TestGenerateWindowWarnings(t *testing.T) {
+	span := func(w *models.WindowDisclosure) *models.WindowDisclosure {
+		w.FirstMatch = time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+		w.LastMatch = time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+		return w
+	}
+
+	tests := []struct {
+		name   string
+		stats1 *models.TeamStats
+		stats2 *models.TeamStats
+		want   []string
+	}{
+		{
+			name:   "same window",
+			stats1: &models.TeamStats{MatchesPlayed: 5, ActualTimeWindow: models.LastMonth, Window: span(&models.WindowDisclosure{Requested: models.LastMonth, Actual: models.LastMonth})},
+			stats2: &models.TeamStats{MatchesPlayed: 5, ActualTimeWindow: models.LastMonth, Window: span(&models.WindowDisclosure{Requested: models.LastMonth, Actual: models.LastMonth})},
+		},
+		{
+			name:   "one team widened",
+			stats1: &models.TeamStats{MatchesPlayed: 5, ActualTimeWindow: models.LastMonth, Window: span(&models.WindowDisclosure{Requested: models.LastMonth, Actual: models.LastMonth})},
+			stats2: &models.TeamStats{MatchesPlayed: 4, ActualTimeWindow: models.Last3Months, Window: span(&models.WindowDisclosure{Requested: models.LastMonth, Actual: models.Last3Months, Expanded: true, Reason: "only 2 of the 3 matches needed in LAST_MONTH"})},
+			want:   []string{"B: window widened from LAST_MONTH to LAST_3_MONTHS", "different windows"},
+		},
+		{
+			name:   "strict with a thin window",
+			stats1: &models.TeamStats{MatchesPlayed: 2, ActualTimeWindow: models.LastWeek, Window: span(&models.WindowDisclosure{Requested: models.LastWeek, Actual: models.LastWeek, Strict: true})},
+			stats2: &models.TeamStats{MatchesPlayed: 3, ActualTimeWindow: models.LastWeek, Window: span(&models.WindowDisclosure{Requested: models.LastWeek, Actual: models.LastWeek, Strict: true})},
+			want:   []string{"A: strict mode kept LAST_WEEK with only 2 matches"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := GenerateWindowWarnings("A", tt.stats1, "B", tt.stats2)
+			if len(warnings) != len(tt.want) {
+				t.Fatalf("got %d warnings %q, want %d", len(warnings), warnings, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warning %q missing %q", warnings[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// draftListLimit caps the pick and ban lists of a draft report
+const draftListLimit = 10
+
+// A comfort pick needs minComfortPicks picks and at least an even record before
+// it is suggested as a ban; suggestedBanCount is how many are suggested
+const (
+	minComfortPicks   = 3
+	suggestedBanCount = 3
+)
+
+// DraftService reports LoL draft habits from stored games
+type DraftService struct {
+	pgRepo *repository.PostgresRepo
+}
+
+func NewDraftService(pg *repository.PostgresRepo) *DraftService {
+	return &DraftService{pgRepo: pg}
+}
+
+// TeamDraft loads a team's stored games with their drafts. A non-nil seriesIDs
+// limits it to those series, so a report only counts drafts from the period it
+// covers. Teams are found by name among rated teams, so the title must have been
+// synced.
+func (s *DraftService) TeamDraft(title, teamName string, seriesIDs []string) (*models.DraftReport, error) {
+	team, ids, err := storedTeamSeries(s.pgRepo, title, teamName, seriesIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.pgRepo.ListGameDrafts(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	report := DraftTendencies(team.TeamID, games)
+	if report == nil {
+		return nil, fmt.Errorf("drafts for %s: %w", team.TeamName, repository.ErrNotFound)
+	}
+	report.Team = team.TeamName
+	return report, nil
+}
+
+// DraftTendencies summarises a team's picks, the bans used against it and its
+// record per side over games with draft data. A pick is blind when the opponent
+// had not shown more picks than the team at that point, so in a standard draft
+// blue's B1, B3 and B5 and red's R2 and R4 are blind. Returns nil when none of
+// the games have the team's picks.
+func DraftTendencies(teamID string, games []models.SeriesGame) *models.DraftReport {
+	report := &models.DraftReport{TeamID: teamID}
+	picks := make(map[string]*models.ChampionPicks)
+	bans := make(map[string]*models.ChampionBans)
+	sides := make(map[string]*models.RoundRecord)
+	sidedGames := 0
+
+	for _, g := range games {
+		ours := false
+		for _, a := range g.Draft {
+			if a.TeamID == teamID && a.Action == models.DraftPick {
+				ours = true
+				break
+			}
+		}
+		if !ours {
+			continue
+		}
+		report.Games++
+		decided, won := g.WinnerID != "", g.WinnerID == teamID
+
+		ourPicks, theirPicks := 0, 0
+		for _, a := range g.Draft {
+			switch {
+			case a.Action == models.DraftPick && a.TeamID == teamID:
+				p := picks[a.Champion]
+				if p == nil {
+					p = &models.ChampionPicks{Champion: a.Champion}
+					picks[a.Champion] = p
+				}
+				p.Picks++
+				if ourPicks == 0 {
+					p.FirstPicks++
+				}
+				blind := theirPicks <= ourPicks
+				if blind {
+					p.BlindPicks++
+				}
+				if decided {
+					p.Decided++
+					if won {
+						p.Wins++
+					}
+					if blind {
+						addRound(&report.Blind, won)
+					} else {
+						addRound(&report.Counter, won)
+					}
+				}
+				ourPicks++
+			case a.Action == models.DraftPick:
+				theirPicks++
+			case a.Action == models.DraftBan && a.TeamID != teamID:
+				b := bans[a.Champion]
+				if b == nil {
+					b = &models.ChampionBans{Champion: a.Champion}
+					bans[a.Champion] = b
+				}
+				b.Bans++
+			}
+		}
+
+		if side := g.Sides[teamID]; side != "" && decided {
+			sidedGames++
+			if sides[side] == nil {
+				sides[side] = &models.RoundRecord{}
+			}
+			addRound(sides[side], won)
+		}
+	}
+
+	if report.Games == 0 {
+		return nil
+	}
+
+	report.PriorityPicks = []models.ChampionPicks{}
+	for _, p := range picks {
+		if p.Decided > 0 {
+			p.WinRate = float64(p.Wins) / float64(p.Decided)
+		}
+		p.PickRate = float64(p.Picks) / float64(report.Games)
+		report.PriorityPicks = append(report.PriorityPicks, *p)
+	}
+	sort.Slice(report.PriorityPicks, func(i, j int) bool {
+		a, b := report.PriorityPicks[i], report.PriorityPicks[j]
+		if a.Picks != b.Picks {
+			return a.Picks > b.Picks
+		}
+		if a.FirstPicks != b.FirstPicks {
+			return a.FirstPicks > b.FirstPicks
+		}
+		return a.Champion < b.Champion
+	})
+	if len(report.PriorityPicks) > draftListLimit {
+		report.PriorityPicks = report.PriorityPicks[:draftListLimit]
+	}
+
+	report.BansAgainst = []models.ChampionBans{}
+	for _, b := range bans {
+		b.BanRate = float64(b.Bans) / float64(report.Games)
+		report.BansAgainst = append(report.BansAgainst, *b)
+	}
+	sort.Slice(report.BansAgainst, func(i, j int) bool {
+		a, b := report.BansAgainst[i], report.BansAgainst[j]
+		if a.Bans != b.Bans {
+			return a.Bans > b.Bans
+		}
+		return a.Champion < b.Champion
+	})
+	if len(report.BansAgainst) > draftListLimit {
+		report.BansAgainst = report.BansAgainst[:draftListLimit]
+	}
+
+	finishRecord(&report.Blind)
+	finishRecord(&report.Counter)
+
+	report.Sides = []models.SideRecord{}
+	for side, record := range sides {
+		finishRecord(record)
+		report.Sides = append(report.Sides, models.SideRecord{Side: side, RoundRecord: *record})
+	}
+	sort.Slice(report.Sides, func(i, j int) bool { return report.Sides[i].Side < report.Sides[j].Side })
+	if sidedGames > 0 && sides["blue"] != nil {
+		report.BlueShare = float64(sides["blue"].Rounds) / float64(sidedGames)
+	}
+
+	return report
+}
+
+// SuggestBans picks the opponent's comfort champions - picked often and won at
+// least as often as lost - most wins first
+func SuggestBans(opponent *models.DraftReport) []models.SuggestedBan {
+	if opponent == nil {
+		return nil
+	}
+
+	var comfort []models.ChampionPicks
+	for _, p := range opponent.PriorityPicks {
+		if p.Picks >= minComfortPicks && p.WinRate >= 0.5 {
+			comfort = append(comfort, p)
+		}
+	}
+	sort.SliceStable(comfort, func(i, j int) bool {
+		if comfort[i].Wins != comfort[j].Wins {
+			return comfort[i].Wins > comfort[j].Wins
+		}
+		return comfort[i].Picks > comfort[j].Picks
+	})
+	if len(comfort) > suggestedBanCount {
+		comfort = comfort[:suggestedBanCount]
+	}
+
+	bans := []models.SuggestedBan{}
+	for _, p := range comfort {
+		reason := fmt.Sprintf("picked in %.0f%% of their games (%d-%d)", p.PickRate*100, p.Wins, p.Decided-p.Wins)
+		if p.FirstPicks > 0 {
+			reason += fmt.Sprintf(", first pick %d times", p.FirstPicks)
+		}
+		bans = append(bans, models.SuggestedBan{Champion: p.Champion, Reason: reason})
+	}
+	return bans
+}
+
+// draftInsight calls out the opponent's strongest comfort pick
+func draftInsight(opponent string, draft *models.DraftMatchup) *models.KeyInsight {
+	if draft == nil || draft.Opponent == nil || len(draft.SuggestedBans) == 0 {
+		return nil
+	}
+
+	top := draft.SuggestedBans[0]
+	return &models.KeyInsight{
+		Priority: "MEDIUM",
+		Icon:     "🟡",
+		Message:  fmt.Sprintf("%s lean on %s - %s. Consider banning it", opponent, top.Champion, top.Reason),
+	}
+}
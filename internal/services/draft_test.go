@@ -0,0 +1,192 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// standardDraft builds a tournament draft: three bans each, B1 R1 R2 B2 B3 R3,
+// two bans each, then R4 B4 B5 R5
+func standardDraft(blue, red string, blueBans, redBans, bluePicks, redPicks []string) []models.DraftAction {
+	var draft []models.DraftAction
+	add := func(team, action, champion string) {
+		draft = append(draft, models.DraftAction{Sequence: len(draft) + 1, TeamID: team, Action: action, Champion: champion})
+	}
+	for i := 0; i < 3; i++ {
+		add(blue, models.DraftBan, blueBans[i])
+		add(red, models.DraftBan, redBans[i])
+	}
+	add(blue, models.DraftPick, bluePicks[0])
+	add(red, models.DraftPick, redPicks[0])
+	add(red, models.DraftPick, redPicks[1])
+	add(blue, models.DraftPick, bluePicks[1])
+	add(blue, models.DraftPick, bluePicks[2])
+	add(red, models.DraftPick, redPicks[2])
+	for i := 3; i < 5; i++ {
+		add(red, models.DraftBan, redBans[i])
+		add(blue, models.DraftBan, blueBans[i])
+	}
+	add(red, models.DraftPick, redPicks[3])
+	add(blue, models.DraftPick, bluePicks[3])
+	add(blue, models.DraftPick, bluePicks[4])
+	add(red, models.DraftPick, redPicks[4])
+	return draft
+}
+
+func TestDraftTendencies(t *testing.T) {
+	ourPicks := []string{"Azir", "Vi", "Rell", "Jinx", "Ksante"}
+	theirPicks := []string{"Orianna", "Sejuani", "Nautilus", "Kaisa", "Gnar"}
+	bans := []string{"Ahri", "Lee Sin", "Rakan", "Varus", "Renekton"}
+	games := []models.SeriesGame{
+		{SeriesID: "s1", Number: 1, WinnerID: "us", Sides: map[string]string{"us": "blue", "them": "red"},
+			Draft: standardDraft("us", "them", bans, bans, ourPicks, theirPicks)},
+		{SeriesID: "s1", Number: 2, WinnerID: "them", Sides: map[string]string{"us": "red", "them": "blue"},
+			Draft: standardDraft("them", "us", bans, bans, theirPicks, ourPicks)},
+		{SeriesID: "s2", Number: 1, WinnerID: "us", Sides: map[string]string{"us": "blue", "them": "red"},
+			Draft: standardDraft("us", "them", bans, bans, ourPicks, theirPicks)},
+		{SeriesID: "s3", Number: 1, WinnerID: "x", Draft: standardDraft("x", "y", bans, bans, ourPicks, theirPicks)}, // not ours
+	}
+
+	report := DraftTendencies("us", games)
+	if report == nil {
+		t.Fatal("no report")
+	}
+	if report.Games != 3 || len(report.PriorityPicks) != 5 {
+		t.Fatalf("report = %+v, want 3 games and 5 champions", report)
+	}
+
+	azir := report.PriorityPicks[0]
+	if azir.Champion != "Azir" || azir.Picks != 3 || azir.FirstPicks != 3 || azir.Wins != 2 || math.Abs(azir.PickRate-1) > 1e-9 {
+		t.Errorf("top pick = %+v, want Azir first-picked in all 3 games", azir)
+	}
+	// Azir is B1 on blue (blind) and R1 on red (counter)
+	if azir.BlindPicks != 2 {
+		t.Errorf("Azir blind picks = %d, want 2", azir.BlindPicks)
+	}
+
+	// Blue: B1, B3, B5 blind and B2, B4 counter; red: R2, R4 blind
+	if report.Blind.Rounds != 8 || report.Blind.Wins != 6 || report.Counter.Rounds != 7 || report.Counter.Wins != 4 {
+		t.Errorf("blind = %+v, counter = %+v", report.Blind, report.Counter)
+	}
+
+	if len(report.BansAgainst) != 5 || report.BansAgainst[0].Bans != 3 || report.BansAgainst[0].BanRate != 1 {
+		t.Errorf("bans against = %+v", report.BansAgainst)
+	}
+
+	if len(report.Sides) != 2 || report.Sides[0].Side != "blue" || report.Sides[0].Rounds != 2 || report.Sides[0].Wins != 2 ||
+		report.Sides[1].Side != "red" || report.Sides[1].Wins != 0 {
+		t.Errorf("sides = %+v", report.Sides)
+	}
+	if math.Abs(report.BlueShare-2.0/3) > 1e-9 {
+		t.Errorf("blue share = %.2f, want 0.67", report.BlueShare)
+	}
+
+	if DraftTendencies("nobody", games) != nil {
+		t.Error("expected nil for a team without drafts")
+	}
+}
+
+func TestDraftTendenciesSkipsUndecidedGames(t *testing.T) {
+	ourPicks := []string{"Azir", "Vi", "Rell", "Jinx", "Ksante"}
+	theirPicks := []string{"Orianna", "Sejuani", "Nautilus", "Kaisa", "Gnar"}
+	bans := []string{"Ahri", "Lee Sin", "Rakan", "Varus", "Renekton"}
+	draft := standardDraft("us", "them", bans, bans, ourPicks, theirPicks)
+	games := []models.SeriesGame{
+		{SeriesID: "s1", Number: 1, WinnerID: "us", Draft: draft},
+		{SeriesID: "s1", Number: 2, WinnerID: "them", Draft: draft},
+		{SeriesID: "s1", Number: 3, WinnerID: "us", Draft: draft},
+		{SeriesID: "s2", Number: 1, Draft: draft}, // no result stored
+	}
+
+	report := DraftTendencies("us", games)
+	if report == nil {
+		t.Fatal("no report")
+	}
+	azir := report.PriorityPicks[0]
+	if azir.Picks != 4 || azir.Decided != 3 || azir.Wins != 2 || math.Abs(azir.WinRate-2.0/3) > 1e-9 {
+		t.Errorf("Azir = %+v, want 2 wins from 3 decided of 4 picks", azir)
+	}
+	if bans := SuggestBans(report); len(bans) == 0 || bans[0].Reason != "picked in 100% of their games (2-1), first pick 4 times" {
+		t.Errorf("bans = %+v", bans)
+	}
+}
+
+func TestDraftTendenciesOverReportSeries(t *testing.T) {
+	oldPicks := []string{"Azir", "Vi", "Rell", "Jinx", "Ksante"}
+	newPicks := []string{"Orianna", "Vi", "Rell", "Jinx", "Ksante"}
+	theirPicks := []string{"Ahri", "Sejuani", "Nautilus", "Kaisa", "Gnar"}
+	bans := []string{"Lee Sin", "Rakan", "Varus", "Renekton", "Taliyah"}
+
+	stored := []models.SeriesRecord{
+		{ID: "before-range", Team1ID: "us", Team2ID: "them"},
+		{ID: "r1", Team1ID: "us", Team2ID: "them"},
+		{ID: "r2", Team1ID: "them", Team2ID: "us"},
+	}
+	games := []models.SeriesGame{
+		{SeriesID: "before-range", Number: 1, WinnerID: "us", Draft: standardDraft("us", "them", bans, bans, oldPicks, theirPicks)},
+		{SeriesID: "before-range", Number: 2, WinnerID: "us", Draft: standardDraft("us", "them", bans, bans, oldPicks, theirPicks)},
+		{SeriesID: "before-range", Number: 3, WinnerID: "us", Draft: standardDraft("us", "them", bans, bans, oldPicks, theirPicks)},
+		{SeriesID: "r1", Number: 1, WinnerID: "us", Draft: standardDraft("us", "them", bans, bans, newPicks, theirPicks)},
+		{SeriesID: "r2", Number: 1, WinnerID: "us", Draft: standardDraft("us", "them", bans, bans, newPicks, theirPicks)},
+	}
+
+	// The report passes the series its comparison covered; TeamDraft loads the
+	// drafts of those series only
+	inRange := make(map[string]bool)
+	for _, id := range teamSeriesIDs(stored, "us", []string{"r1", "r2"}) {
+		inRange[id] = true
+	}
+	var loaded []models.SeriesGame
+	for _, g := range games {
+		if inRange[g.SeriesID] {
+			loaded = append(loaded, g)
+		}
+	}
+
+	report := DraftTendencies("us", loaded)
+	if report == nil || report.Games != 2 {
+		t.Fatalf("report = %+v, want the 2 games in range", report)
+	}
+	for _, p := range report.PriorityPicks {
+		if p.Champion == "Azir" {
+			t.Errorf("Azir was only picked before the range but is listed: %+v", p)
+		}
+	}
+	if report.PriorityPicks[0].Champion != "Orianna" || report.PriorityPicks[0].FirstPicks != 2 {
+		t.Errorf("top pick = %+v, want Orianna first-picked twice", report.PriorityPicks[0])
+	}
+}
+
+func TestSuggestBans(t *testing.T) {
+	opponent := &models.DraftReport{
+		Games: 10,
+		PriorityPicks: []models.ChampionPicks{
+			{Champion: "Azir", Picks: 8, FirstPicks: 5, Decided: 8, Wins: 4, WinRate: 0.5, PickRate: 0.8},
+			{Champion: "Vi", Picks: 6, Decided: 6, Wins: 5, WinRate: 5.0 / 6, PickRate: 0.6},
+			{Champion: "Rell", Picks: 5, Decided: 5, Wins: 1, WinRate: 0.2, PickRate: 0.5}, // losing record
+			{Champion: "Ahri", Picks: 2, Decided: 2, Wins: 2, WinRate: 1, PickRate: 0.2},   // too few picks
+			{Champion: "Jinx", Picks: 4, Decided: 4, Wins: 3, WinRate: 0.75, PickRate: 0.4},
+			{Champion: "Gnar", Picks: 3, Decided: 3, Wins: 2, WinRate: 2.0 / 3, PickRate: 0.3},
+		},
+	}
+
+	bans := SuggestBans(opponent)
+	want := []string{"Vi", "Azir", "Jinx"}
+	if len(bans) != len(want) {
+		t.Fatalf("bans = %+v, want %v", bans, want)
+	}
+	for i, champion := range want {
+		if bans[i].Champion != champion {
+			t.Errorf("ban %d = %s, want %s", i, bans[i].Champion, champion)
+		}
+	}
+	if bans[1].Reason != "picked in 80% of their games (4-4), first pick 5 times" {
+		t.Errorf("reason = %q", bans[1].Reason)
+	}
+
+	if got := SuggestBans(nil); got != nil {
+		t.Errorf("SuggestBans(nil) = %+v", got)
+	}
+}
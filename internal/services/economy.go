@@ -0,0 +1,154 @@
+package services
+
+import (
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+)
+
+// Team loadout values (credits over all five players) that separate buy types
+const (
+	ecoMaxLoadout   = 5000  // below: eco
+	forceMaxLoadout = 20000 // below: force buy; from here on: full buy
+)
+
+// valorantHalfRounds is the length of a regulation half; each half opens with a pistol round
+const valorantHalfRounds = 12
+
+// economyEventTypes are the stored event types the economy analysis reads
+var economyEventTypes = []string{models.EventBuy, models.EventRoundEnd}
+
+// classifyBuy names a team's buy from the round number and its loadout value
+func classifyBuy(round, loadout int) string {
+	switch {
+	case isPistolRound(round):
+		return models.BuyPistol
+	case loadout < ecoMaxLoadout:
+		return models.BuyEco
+	case loadout < forceMaxLoadout:
+		return models.BuyForce
+	default:
+		return models.BuyFull
+	}
+}
+
+// isPistolRound reports whether a round opens a regulation half
+func isPistolRound(round int) bool {
+	return round == 1 || round == valorantHalfRounds+1
+}
+
+// ApplyEconomy fills in stats.Economy from the stored buy and round-end events of
+// the team's series. Rounds without a known winner are skipped, as are rounds
+// without buy data (pistol and bonus rounds only need the winner).
+func ApplyEconomy(stats *models.TeamStats, events []models.GridEvent) {
+	teamBySeries := make(map[string]string, len(stats.Series))
+	for _, s := range stats.Series {
+		if s.TeamID != "" {
+			teamBySeries[s.SeriesID] = s.TeamID
+		}
+	}
+
+	type roundBuys struct {
+		ours, theirs int
+		won, decided bool
+	}
+	rounds := make(map[roundKey]*roundBuys)
+	var order []roundKey
+
+	for _, e := range events {
+		team := teamBySeries[e.SeriesID]
+		if team == "" || e.RoundNumber == 0 {
+			continue
+		}
+		key := roundKey{e.SeriesID, e.GameNumber, e.RoundNumber}
+		r := rounds[key]
+		if r == nil {
+			r = &roundBuys{}
+			rounds[key] = r
+			order = append(order, key)
+		}
+
+		switch e.Type {
+		case models.EventBuy:
+			if e.ActorTeamID == team {
+				r.ours = e.Value
+			} else {
+				r.theirs = e.Value
+			}
+		case models.EventRoundEnd:
+			r.decided = true
+			r.won = e.ActorTeamID == team
+		}
+	}
+
+	byBuy := make(map[string]*models.RoundRecord)
+	economy := &models.EconomyStats{}
+	series := make(map[string]bool)
+
+	for _, key := range order {
+		r := rounds[key]
+		if !r.decided {
+			continue
+		}
+
+		if key.round == 2 || key.round == valorantHalfRounds+2 {
+			pistol := rounds[roundKey{key.seriesID, key.game, key.round - 1}]
+			if pistol != nil && pistol.decided {
+				if pistol.won {
+					addRound(&economy.Bonus, r.won)
+				} else {
+					addRound(&economy.PostPistolLoss, r.won)
+				}
+			}
+		}
+
+		if !isPistolRound(key.round) && r.ours == 0 {
+			continue // no buy data
+		}
+		buy := classifyBuy(key.round, r.ours)
+		if byBuy[buy] == nil {
+			byBuy[buy] = &models.RoundRecord{}
+		}
+		addRound(byBuy[buy], r.won)
+		economy.Rounds++
+		series[key.seriesID] = true
+
+		if buy == models.BuyFull && r.theirs > 0 && classifyBuy(key.round, r.theirs) == models.BuyEco {
+			addRound(&economy.AntiEco, r.won)
+		}
+	}
+
+	if economy.Rounds == 0 {
+		return
+	}
+	economy.Series = len(series)
+
+	economy.ByBuy = []models.BuyTypeStats{}
+	for _, buy := range []string{models.BuyPistol, models.BuyEco, models.BuyForce, models.BuyFull} {
+		if record := byBuy[buy]; record != nil {
+			finishRecord(record)
+			economy.ByBuy = append(economy.ByBuy, models.BuyTypeStats{Type: buy, RoundRecord: *record})
+		}
+	}
+	finishRecord(&economy.AntiEco)
+	finishRecord(&economy.Bonus)
+	finishRecord(&economy.PostPistolLoss)
+
+	stats.Economy = economy
+}
+
+func addRound(record *models.RoundRecord, won bool) {
+	record.Rounds++
+	if won {
+		record.Wins++
+	}
+}
+
+// finishRecord fills in the win rate and its 95% interval
+func finishRecord(record *models.RoundRecord) {
+	if record.Rounds == 0 {
+		return
+	}
+	record.WinRate = float64(record.Wins) / float64(record.Rounds)
+	lower, upper := appstats.Wilson(record.Wins, record.Rounds, appstats.Z95)
+	record.WinRateInterval = models.Interval{Lower: lower, Upper: upper, Level: 0.95}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestClassifyBuy(t *testing.T) {
+	tests := []struct {
+		round, loadout int
+		want           string
+	}{
+		{1, 4000, models.BuyPistol},
+		{13, 0, models.BuyPistol},
+		{2, 3200, models.BuyEco},
+		{3, 12000, models.BuyForce},
+		{4, 19999, models.BuyForce},
+		{5, 24500, models.BuyFull},
+		{25, 24500, models.BuyFull}, // overtime rounds are not pistols
+	}
+	for _, tt := range tests {
+		if got := classifyBuy(tt.round, tt.loadout); got != tt.want {
+			t.Errorf("classifyBuy(%d, %d) = %s, want %s", tt.round, tt.loadout, got, tt.want)
+		}
+	}
+}
+
+func TestApplyEconomy(t *testing.T) {
+	var events []models.GridEvent
+	round := func(n, ours, theirs int, winner string) {
+		if ours > 0 {
+			events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, RoundNumber: n, Type: models.EventBuy, ActorTeamID: "us", Value: ours})
+		}
+		if theirs > 0 {
+			events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, RoundNumber: n, Type: models.EventBuy, ActorTeamID: "them", Value: theirs})
+		}
+		if winner != "" {
+			events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, RoundNumber: n, Type: models.EventRoundEnd, ActorTeamID: winner})
+		}
+	}
+
+	round(1, 3900, 3900, "us")     // pistol won
+	round(2, 9000, 2000, "us")     // bonus converted (a force against an eco)
+	round(3, 22000, 3000, "them")  // anti-eco lost
+	round(4, 23000, 24000, "us")   // full vs full
+	round(5, 0, 0, "them")         // no buy data
+	round(6, 24000, 24000, "")     // unfinished
+	round(13, 3900, 3900, "them")  // pistol lost
+	round(14, 2500, 15000, "them") // eco against their bonus
+	round(15, 21000, 4000, "us")   // anti-eco won
+
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}}}
+	ApplyEconomy(stats, events)
+
+	e := stats.Economy
+	if e == nil {
+		t.Fatal("Economy not set")
+	}
+	if e.Series != 1 || e.Rounds != 7 {
+		t.Errorf("economy = %d series, %d rounds, want 1 and 7", e.Series, e.Rounds)
+	}
+
+	want := map[string][2]int{ // rounds, wins
+		models.BuyPistol: {2, 1},
+		models.BuyEco:    {1, 0},
+		models.BuyForce:  {1, 1},
+		models.BuyFull:   {3, 2},
+	}
+	if len(e.ByBuy) != len(want) {
+		t.Fatalf("byBuy = %+v", e.ByBuy)
+	}
+	for _, b := range e.ByBuy {
+		if w := want[b.Type]; b.Rounds != w[0] || b.Wins != w[1] {
+			t.Errorf("%s = %d-%d, want %d rounds, %d wins", b.Type, b.Rounds, b.Wins, w[0], w[1])
+		}
+	}
+	if e.ByBuy[0].Type != models.BuyPistol || e.ByBuy[3].WinRate < 0.66 || e.ByBuy[3].WinRateInterval.Level != 0.95 {
+		t.Errorf("byBuy order/rates = %+v", e.ByBuy)
+	}
+
+	if e.AntiEco.Rounds != 2 || e.AntiEco.Wins != 1 {
+		t.Errorf("anti-eco = %+v, want 1 of 2", e.AntiEco)
+	}
+	if e.Bonus.Rounds != 1 || e.Bonus.Wins != 1 {
+		t.Errorf("bonus = %+v, want 1 of 1", e.Bonus)
+	}
+	if e.PostPistolLoss.Rounds != 1 || e.PostPistolLoss.Wins != 0 {
+		t.Errorf("post pistol loss = %+v, want 0 of 1", e.PostPistolLoss)
+	}
+}
+
+func TestApplyEconomyWithoutEvents(t *testing.T) {
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}}}
+	ApplyEconomy(stats, nil)
+	if stats.Economy != nil {
+		t.Errorf("Economy = %+v, want nil without events", stats.Economy)
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// EventIngestService downloads the JSONL event files of stored series and keeps
+// their normalised kills, rounds, plants/defuses and objectives in Postgres
+type EventIngestService struct {
+	downloader *grid.FileDownloader
+	pgRepo     *repository.PostgresRepo
+}
+
+func NewEventIngestService(fd *grid.FileDownloader, pg *repository.PostgresRepo) *EventIngestService {
+	return &EventIngestService{
+		downloader: fd,
+		pgRepo:     pg,
+	}
+}
+
+// IngestTitle stores the events of every stored series of a title (or just the
+// given series). Series whose events are already stored are skipped unless
+// refresh is set. Run sync-series first: events are only kept for stored series.
+func (s *EventIngestService) IngestTitle(ctx context.Context, title string, seriesIDs []string, refresh bool) (*SyncResult, error) {
+	title = models.CanonicalTitle(title)
+	series, err := s.pgRepo.ListSeries(title)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(seriesIDs))
+	for _, id := range seriesIDs {
+		wanted[id] = true
+	}
+
+	var ids []string
+	for _, rec := range series {
+		if len(wanted) == 0 || wanted[rec.ID] {
+			ids = append(ids, rec.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no stored %s series to ingest events for - run sync-series first", title)
+	}
+
+	stored, err := s.pgRepo.EventsDownloadedSeriesIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Listed: len(ids)}
+	events := 0
+	for _, id := range ids {
+		if stored[id] && !refresh {
+			result.Skipped++
+			continue
+		}
+
+		n, err := s.ingestSeries(ctx, id)
+		if err != nil {
+			fmt.Printf("[DEBUG] Skipping events for series %s: %v\n", id, err)
+			result.Failed++
+			continue
+		}
+		events += n
+		result.Saved++
+	}
+
+	fmt.Printf("[INFO] Ingested %s events: %d series saved (%d events), %d already stored, %d unavailable\n",
+		title, result.Saved, events, result.Skipped, result.Failed)
+	return result, nil
+}
+
+func (s *EventIngestService) ingestSeries(ctx context.Context, seriesID string) (int, error) {
+	events, err := s.downloader.DownloadSeriesEvents(ctx, seriesID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.pgRepo.SaveSeriesEvents(seriesID, events); err != nil {
+		return 0, fmt.Errorf("failed to save events: %w", err)
+	}
+	return len(events), nil
+}
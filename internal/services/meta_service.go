@@ -3,32 +3,42 @@ package services
 import (
 "context"
 "fmt"
+"math"
+"sort"
+"strings"
 "time"
 
 "github.com/yourusername/esports-scouting-backend/internal/grid"
 "github.com/yourusername/esports-scouting-backend/internal/models"
+"github.com/yourusername/esports-scouting-backend/internal/patch"
+"github.com/yourusername/esports-scouting-backend/internal/repository"
 "github.com/yourusername/esports-scouting-backend/pkg/cache"
 )
 
+// Map play rate moves smaller than this between patches are not reported as shifts
+const minMapShift = 0.10
+
 type MetaService struct {
 	gridClient *grid.Client
 	cache      *cache.RedisClient
+	pgRepo     *repository.PostgresRepo
+	patches    *patch.Calendar
 }
 
-func NewMetaService(gc *grid.Client, rc *cache.RedisClient) *MetaService {
+func NewMetaService(gc *grid.Client, rc *cache.RedisClient, pg *repository.PostgresRepo, patches *patch.Calendar) *MetaService {
 	return &MetaService{
 		gridClient: gc,
 		cache:      rc,
+		pgRepo:     pg,
+		patches:    patches,
 	}
 }
 
 // AnalyzeMeta provides meta analysis for a game title
-// NOTE: This is a simplified implementation since Grid.gg API doesn't provide
-// agent/champion pick data. This returns placeholder data structure.
-func (s *MetaService) AnalyzeMeta(ctx context.Context, title string, tournamentID string) (*models.MetaReport, error) {
-	// For hackathon purposes, we return a structured placeholder
-	// In production, this would query pick/ban data from Grid.gg
-
+// NOTE: Grid.gg doesn't provide agent/champion pick data in this tier, so TopPicks
+// stay empty. When series have been synced, the report carries the map pool on
+// each patch (optionally just patchVersion) and the map shifts between patches.
+func (s *MetaService) AnalyzeMeta(ctx context.Context, title string, tournamentID string, patchVersion string) (*models.MetaReport, error) {
 	report := &models.MetaReport{
 		Title:       title,
 		Tournament:  tournamentID,
@@ -36,10 +46,144 @@ func (s *MetaService) AnalyzeMeta(ctx context.Context, title string, tournamentI
 		SampleSize:  0,
 		TopPicks:    []models.MetaPick{},
 		MetaShifts:  []models.MetaShift{},
+		Patch:       patchVersion,
+	}
+
+	canonical := models.CanonicalTitle(title)
+	series, err := s.pgRepo.ListSeries(canonical)
+	if err != nil {
+		return report, err
+	}
+	games, err := s.pgRepo.ListSeriesGames(canonical)
+	if err != nil {
+		return report, err
+	}
+
+	report.Patches = patchMeta(s.patches, canonical, series, games, tournamentID)
+	if patchVersion != "" {
+		var only []models.PatchMeta
+		for _, pm := range report.Patches {
+			if pm.Version == patchVersion {
+				only = append(only, pm)
+			}
+		}
+		report.Patches = only
+	} else {
+		report.MetaShifts = mapShifts(report.Patches)
+	}
+	for _, pm := range report.Patches {
+		report.SampleSize += pm.Series
+	}
+
+	if report.SampleSize == 0 {
+		// Add note that this feature requires additional Grid.gg API access
+		return report, fmt.Errorf("meta analysis requires Grid.gg pick/ban data API access (not available in hackathon tier) or synced series with map data")
+	}
+	return report, nil
+}
+
+// patchMeta counts the maps played on each patch, in release order. A series'
+// stored patch tag is used when present, otherwise the calendar is consulted.
+// Series before the first known patch are left out.
+func patchMeta(calendar *patch.Calendar, title string, series []models.SeriesRecord, games []models.SeriesGame, tournamentID string) []models.PatchMeta {
+	versionOf := make(map[string]string, len(series))
+	byVersion := make(map[string]*models.PatchMeta)
+	mapGames := make(map[string]map[string]int)
+	for _, rec := range series {
+		if tournamentID != "" && rec.TournamentID != tournamentID {
+			continue
+		}
+		version := rec.Patch
+		if version == "" {
+			version = calendar.At(title, rec.StartTime)
+		}
+		if version == "" {
+			continue
+		}
+		versionOf[rec.ID] = version
+		if byVersion[version] == nil {
+			byVersion[version] = &models.PatchMeta{Version: version}
+			mapGames[version] = make(map[string]int)
+		}
+		byVersion[version].Series++
+	}
+
+	for _, g := range games {
+		version, ok := versionOf[g.SeriesID]
+		if !ok || g.Map == "" {
+			continue
+		}
+		byVersion[version].Games++
+		mapGames[version][strings.ToLower(g.Map)]++
 	}
 
-	// Add note that this feature requires additional Grid.gg API access
-	return report, fmt.Errorf("meta analysis requires Grid.gg pick/ban data API access (not available in hackathon tier)")
+	var breakdown []models.PatchMeta
+	for _, version := range calendar.Versions(title) {
+		pm := byVersion[version]
+		if pm == nil {
+			continue
+		}
+		pm.Maps = []models.MapShare{}
+		for name, count := range mapGames[version] {
+			pm.Maps = append(pm.Maps, models.MapShare{
+				Map:      name,
+				Games:    count,
+				PlayRate: float64(count) / float64(pm.Games),
+			})
+		}
+		sort.Slice(pm.Maps, func(i, j int) bool {
+			if pm.Maps[i].Games != pm.Maps[j].Games {
+				return pm.Maps[i].Games > pm.Maps[j].Games
+			}
+			return pm.Maps[i].Map < pm.Maps[j].Map
+		})
+		breakdown = append(breakdown, *pm)
+	}
+	return breakdown
+}
+
+// mapShifts reports maps whose play rate moved by at least minMapShift between
+// consecutive patches, including maps entering or leaving the pool
+func mapShifts(patches []models.PatchMeta) []models.MetaShift {
+	shifts := []models.MetaShift{}
+	for i := 1; i < len(patches); i++ {
+		prev, cur := patches[i-1], patches[i]
+		if prev.Games == 0 || cur.Games == 0 {
+			continue
+		}
+
+		rates := make(map[string][2]float64)
+		for _, m := range prev.Maps {
+			r := rates[m.Map]
+			r[0] = m.PlayRate
+			rates[m.Map] = r
+		}
+		for _, m := range cur.Maps {
+			r := rates[m.Map]
+			r[1] = m.PlayRate
+			rates[m.Map] = r
+		}
+
+		names := make([]string, 0, len(rates))
+		for name := range rates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			r := rates[name]
+			delta := r[1] - r[0]
+			if math.Abs(delta) < minMapShift {
+				continue
+			}
+			shifts = append(shifts, models.MetaShift{
+				Pick:   name,
+				Change: fmt.Sprintf("%+.0f%% play rate (%s → %s)", delta*100, prev.Version, cur.Version),
+				Reason: fmt.Sprintf("%.0f%% of games on %s, %.0f%% on %s", r[0]*100, prev.Version, r[1]*100, cur.Version),
+			})
+		}
+	}
+	return shifts
 }
 
 // GetMetaContextForTeam provides meta context for a specific team
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+)
+
+// objectiveEventTypes are the stored event types the objective analysis reads
+var objectiveEventTypes = []string{models.EventObjective, models.EventGold, models.EventGameEnd}
+
+// Objective kinds, told apart by the Grid target
+const (
+	objectiveDragon = "dragon"
+	objectiveHerald = "herald"
+	objectiveBaron  = "baron"
+	objectivePlate  = "plate"
+)
+
+// objectiveKind names the objective an event took, or "" for towers,
+// inhibitors and minor camps
+func objectiveKind(e models.GridEvent) string {
+	if strings.HasSuffix(e.SourceType, "-towerPlate") {
+		return objectivePlate
+	}
+	target := strings.ToLower(e.Detail)
+	switch {
+	case strings.Contains(target, "baron"):
+		return objectiveBaron
+	case strings.Contains(target, "herald"):
+		return objectiveHerald
+	case strings.Contains(target, "drake"), strings.Contains(target, "dragon"):
+		return objectiveDragon
+	default:
+		return ""
+	}
+}
+
+// gameTempo is one game's objectives and gold, from the team's point of view
+type gameTempo struct {
+	firsts       map[string]bool // objective kind -> taken by the team
+	plates       int
+	gold         map[string][2]int // minute -> ours, theirs
+	ended, won   bool
+	length       int
+	involvesTeam bool
+}
+
+// ApplyObjectives fills in stats.Objectives from the stored objective, gold and
+// game-end events of the team's series. Games count once any of their events
+// involve the team; without any Objectives stays nil.
+func ApplyObjectives(stats *models.TeamStats, events []models.GridEvent) {
+	games, order := collectGameTempo(stats, events)
+	tempos := make([]*gameTempo, 0, len(order))
+	for _, key := range order {
+		tempos = append(tempos, games[key])
+	}
+	stats.Objectives = summariseObjectives(tempos)
+}
+
+// collectGameTempo groups objective, gold and game-end events by game (keyed
+// with round 0), in series order
+func collectGameTempo(stats *models.TeamStats, events []models.GridEvent) (map[roundKey]*gameTempo, []roundKey) {
+	teamBySeries := make(map[string]string, len(stats.Series))
+	for _, s := range stats.Series {
+		if s.TeamID != "" {
+			teamBySeries[s.SeriesID] = s.TeamID
+		}
+	}
+
+	games := make(map[roundKey]*gameTempo)
+	var order []roundKey
+
+	for _, e := range events {
+		team := teamBySeries[e.SeriesID]
+		if team == "" {
+			continue
+		}
+		key := roundKey{e.SeriesID, e.GameNumber, 0}
+		g := games[key]
+		if g == nil {
+			g = &gameTempo{firsts: make(map[string]bool), gold: make(map[string][2]int)}
+			games[key] = g
+			order = append(order, key)
+		}
+		ours := e.ActorTeamID == team
+
+		switch e.Type {
+		case models.EventObjective:
+			kind := objectiveKind(e)
+			if kind == objectivePlate {
+				if ours {
+					g.plates++
+				}
+			} else if _, taken := g.firsts[kind]; kind != "" && !taken {
+				g.firsts[kind] = ours
+			}
+		case models.EventGold:
+			worth := g.gold[e.Detail]
+			if ours {
+				worth[0] = e.Value
+			} else {
+				worth[1] = e.Value
+			}
+			g.gold[e.Detail] = worth
+		case models.EventGameEnd:
+			g.ended = e.ActorTeamID != ""
+			g.won = ours
+			g.length = e.Value
+		default:
+			continue
+		}
+		if e.ActorTeamID != "" {
+			g.involvesTeam = true
+		}
+	}
+	return games, order
+}
+
+// summariseObjectives aggregates the games that involve the team, or returns
+// nil when there are none
+func summariseObjectives(games []*gameTempo) *models.ObjectiveStats {
+	objectives := &models.ObjectiveStats{}
+	var plates, lengthGames, length int
+	var gold10, gold15 []float64
+	firsts := map[string]*models.FirstObjective{
+		objectiveDragon: &objectives.FirstDragon,
+		objectiveHerald: &objectives.FirstHerald,
+		objectiveBaron:  &objectives.FirstBaron,
+	}
+
+	for _, g := range games {
+		if !g.involvesTeam {
+			continue
+		}
+		objectives.Games++
+		plates += g.plates
+
+		for kind, ours := range g.firsts {
+			first := firsts[kind]
+			first.Games++
+			if ours {
+				first.Firsts++
+			}
+		}
+		if g.ended && g.length > 0 {
+			lengthGames++
+			length += g.length
+		}
+
+		if worth, ok := g.gold["10"]; ok && worth[0] > 0 && worth[1] > 0 {
+			gold10 = append(gold10, float64(worth[0]-worth[1]))
+		}
+		if worth, ok := g.gold["15"]; ok && worth[0] > 0 && worth[1] > 0 {
+			diff := worth[0] - worth[1]
+			gold15 = append(gold15, float64(diff))
+			switch {
+			case !g.ended:
+			case diff > 0:
+				addRound(&objectives.AheadAt15, g.won)
+			case diff < 0:
+				addRound(&objectives.BehindAt15, g.won)
+			}
+		}
+	}
+
+	if objectives.Games == 0 {
+		return nil
+	}
+	for _, first := range firsts {
+		if first.Games > 0 {
+			first.Rate = float64(first.Firsts) / float64(first.Games)
+			lower, upper := appstats.Wilson(first.Firsts, first.Games, appstats.Z95)
+			first.RateInterval = models.Interval{Lower: lower, Upper: upper, Level: 0.95}
+		}
+	}
+	objectives.PlatesPerGame = float64(plates) / float64(objectives.Games)
+	if lengthGames > 0 {
+		objectives.AvgGameLength = float64(length) / float64(lengthGames)
+	}
+	objectives.GoldDiff10 = average(gold10)
+	objectives.GoldDiff15 = average(gold15)
+	objectives.GoldDiffs15 = gold15
+	finishRecord(&objectives.AheadAt15)
+	finishRecord(&objectives.BehindAt15)
+	return objectives
+}
+
+func average(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, x := range xs {
+		total += x
+	}
+	return total / float64(len(xs))
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestObjectiveKind(t *testing.T) {
+	tests := []struct {
+		sourceType, detail, want string
+	}{
+		{"team-killed-ATierNPC", "oceanDrake", objectiveDragon},
+		{"team-killed-ATierNPC", "elderDragon", objectiveDragon},
+		{"team-killed-ATierNPC", "riftHerald", objectiveHerald},
+		{"team-killed-ATierNPC", "baron", objectiveBaron},
+		{"player-destroyed-towerPlate", "plate-3", objectivePlate},
+		{"team-destroyed-tower", "tower-blue-top-outer", ""},
+		{"team-killed-BTierNPC", "scuttleCrab", ""},
+	}
+	for _, tt := range tests {
+		e := models.GridEvent{SourceType: tt.sourceType, Detail: tt.detail}
+		if got := objectiveKind(e); got != tt.want {
+			t.Errorf("objectiveKind(%s, %s) = %q, want %q", tt.sourceType, tt.detail, got, tt.want)
+		}
+	}
+}
+
+func TestApplyObjectives(t *testing.T) {
+	var events []models.GridEvent
+	objective := func(game int, team, sourceType, detail string) {
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: game, Type: models.EventObjective,
+			SourceType: sourceType, ActorTeamID: team, Detail: detail})
+	}
+	gold := func(game int, minute string, ours, theirs int) {
+		events = append(events,
+			models.GridEvent{SeriesID: "s1", GameNumber: game, Type: models.EventGold, ActorTeamID: "us", Detail: minute, Value: ours},
+			models.GridEvent{SeriesID: "s1", GameNumber: game, Type: models.EventGold, ActorTeamID: "them", Detail: minute, Value: theirs})
+	}
+	end := func(game int, winner string, seconds int) {
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: game, Type: models.EventGameEnd, ActorTeamID: winner, Value: seconds})
+	}
+
+	// Game 1: we take first dragon and herald, lead at 15 and win
+	objective(1, "us", "team-killed-ATierNPC", "cloudDrake")
+	objective(1, "them", "team-killed-ATierNPC", "oceanDrake")
+	objective(1, "us", "team-killed-ATierNPC", "riftHerald")
+	objective(1, "us", "player-destroyed-towerPlate", "plate-1")
+	objective(1, "us", "player-destroyed-towerPlate", "plate-2")
+	gold(1, "10", 16000, 15000)
+	gold(1, "15", 26000, 23000)
+	objective(1, "us", "team-killed-ATierNPC", "baron")
+	end(1, "us", 1800)
+	// Game 2: they take first dragon, we trail at 15 and lose
+	objective(2, "them", "team-killed-ATierNPC", "infernalDrake")
+	objective(2, "them", "player-destroyed-towerPlate", "plate-1")
+	objective(2, "us", "player-destroyed-towerPlate", "plate-2")
+	gold(2, "10", 14000, 15000)
+	gold(2, "15", 22000, 25000)
+	objective(2, "them", "team-killed-ATierNPC", "baron")
+	end(2, "them", 2400)
+	// Game 3 only has kills, loaded alongside for opening stats
+	events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 3, Type: models.EventKill, ActorTeamID: "us", TargetTeamID: "them"})
+	// Series without a team ID is ignored
+	events = append(events, models.GridEvent{SeriesID: "s2", GameNumber: 1, Type: models.EventGameEnd, ActorTeamID: "x", Value: 100})
+
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}, {SeriesID: "s2"}}}
+	ApplyObjectives(stats, events)
+
+	o := stats.Objectives
+	if o == nil {
+		t.Fatal("Objectives not set")
+	}
+	if o.Games != 2 {
+		t.Errorf("games = %d, want 2", o.Games)
+	}
+	if o.FirstDragon.Games != 2 || o.FirstDragon.Firsts != 1 || o.FirstDragon.Rate != 0.5 {
+		t.Errorf("first dragon = %+v, want 1 of 2", o.FirstDragon)
+	}
+	if o.FirstHerald.Games != 1 || o.FirstHerald.Firsts != 1 || o.FirstHerald.Rate != 1 {
+		t.Errorf("first herald = %+v, want 1 of 1", o.FirstHerald)
+	}
+	if o.FirstBaron.Games != 2 || o.FirstBaron.Firsts != 1 {
+		t.Errorf("first baron = %+v, want 1 of 2", o.FirstBaron)
+	}
+	if o.PlatesPerGame != 1.5 {
+		t.Errorf("plates per game = %.2f, want 1.5", o.PlatesPerGame)
+	}
+	if o.GoldDiff10 != 0 || o.GoldDiff15 != 0 || len(o.GoldDiffs15) != 2 {
+		t.Errorf("gold diffs = %.0f at 10, %.0f at 15 (%v), want 0 and 0", o.GoldDiff10, o.GoldDiff15, o.GoldDiffs15)
+	}
+	if o.AvgGameLength != 2100 {
+		t.Errorf("avg game length = %.0f, want 2100", o.AvgGameLength)
+	}
+	if o.AheadAt15.Rounds != 1 || o.AheadAt15.WinRate != 1 || o.BehindAt15.Rounds != 1 || o.BehindAt15.Wins != 0 {
+		t.Errorf("ahead = %+v, behind = %+v", o.AheadAt15, o.BehindAt15)
+	}
+
+	empty := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}}}
+	ApplyObjectives(empty, nil)
+	if empty.Objectives != nil {
+		t.Error("Objectives should stay nil without events")
+	}
+}
+
+func TestObjectiveAdvantages(t *testing.T) {
+	ahead := []float64{2100, 1800, 2500, 1900, 2300, 2000, 2200, 1700}
+	behind := []float64{-900, -1200, -700, -1100, -800, -1000, -1300, -600}
+	stats1 := &models.TeamStats{Objectives: &models.ObjectiveStats{
+		GoldDiff15:  average(ahead),
+		GoldDiffs15: ahead,
+		FirstDragon: models.FirstObjective{Games: 40, Firsts: 30, Rate: 0.75},
+		FirstBaron:  models.FirstObjective{Games: 10, Firsts: 5, Rate: 0.5},
+	}}
+	stats2 := &models.TeamStats{Objectives: &models.ObjectiveStats{
+		GoldDiff15:  average(behind),
+		GoldDiffs15: behind,
+		FirstDragon: models.FirstObjective{Games: 40, Firsts: 12, Rate: 0.3},
+		FirstBaron:  models.FirstObjective{Games: 10, Firsts: 4, Rate: 0.4},
+	}}
+
+	s := &ComparisonService{}
+	report := comparisonFor(stats1, stats2)
+	s.objectiveAdvantages(report, stats1.Objectives, stats2.Objectives, DefaultSignificanceLevel)
+
+	if got := strings.Join(metrics(report.Advantages.Team1), ","); got != models.MetricGoldDiff15+","+models.MetricFirstDragon {
+		t.Errorf("Team1 advantages = %s, want gold at 15 and first dragon", got)
+	}
+	if len(report.Advantages.Inconclusive) != 1 || report.Advantages.Inconclusive[0].Metric != models.MetricFirstBaron {
+		t.Fatalf("inconclusive = %+v, want first baron", report.Advantages.Inconclusive)
+	}
+	if msg := report.Advantages.Inconclusive[0].Message; !strings.HasSuffix(msg, "10 vs 10 games)") {
+		t.Errorf("inconclusive message = %q, want it to count games", msg)
+	}
+	if gold := report.Advantages.Team1[0]; math.Abs(gold.Difference-(average(ahead)-average(behind))) > 1e-9 {
+		t.Errorf("gold difference = %.0f", gold.Difference)
+	}
+}
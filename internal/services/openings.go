@@ -0,0 +1,226 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+)
+
+// TradeWindow is how soon a teammate must kill the killer for a death to count as traded
+const TradeWindow = 5 * time.Second
+
+// minEntryDuels is how many opening duels an entry player needs before their
+// record is called out in a scouting report
+const minEntryDuels = 10
+
+// openingEventTypes are the stored event types the opening analysis reads
+var openingEventTypes = []string{models.EventGameStart, models.EventRoundStart, models.EventKill}
+
+type roundKey struct {
+	seriesID    string
+	game, round int
+}
+
+type roundDeath struct {
+	victimID, victimTeam, killerID string
+	at                             time.Time
+	traded                         bool
+}
+
+type roundState struct {
+	opened bool
+	deaths []*roundDeath
+}
+
+// ApplyOpenings fills in stats.Openings from the stored kill events of the team's
+// series, in series order. Series without event data are ignored; without any
+// kills Openings stays nil.
+func ApplyOpenings(stats *models.TeamStats, events []models.GridEvent, tradeWindow time.Duration) {
+	teamBySeries := make(map[string]string, len(stats.Series))
+	for _, s := range stats.Series {
+		if s.TeamID != "" {
+			teamBySeries[s.SeriesID] = s.TeamID
+		}
+	}
+
+	openings := &models.OpeningStats{TradeWindowSeconds: tradeWindow.Seconds()}
+	players := make(map[string]*models.PlayerOpenings)
+	playerSeries := make(map[string]map[string]bool)
+	seriesRounds := make(map[string]int)
+	roundStarts := make(map[roundKey]time.Time)
+	rounds := make(map[roundKey]*roundState)
+	var firstKillSeconds float64
+	timedFirstKills := 0
+
+	player := func(seriesID, id, name string) *models.PlayerOpenings {
+		p := players[id]
+		if p == nil {
+			p = &models.PlayerOpenings{ID: id, Name: name}
+			players[id] = p
+			playerSeries[id] = make(map[string]bool)
+		}
+		playerSeries[id][seriesID] = true
+		return p
+	}
+
+	for _, e := range events {
+		team := teamBySeries[e.SeriesID]
+		if team == "" {
+			continue
+		}
+		key := roundKey{e.SeriesID, e.GameNumber, e.RoundNumber}
+
+		switch e.Type {
+		case models.EventGameStart, models.EventRoundStart:
+			roundStarts[key] = e.OccurredAt
+			continue
+		case models.EventKill:
+		default:
+			continue
+		}
+
+		ours, theirs := e.ActorTeamID == team, e.TargetTeamID == team
+		if ours == theirs {
+			continue // team kill, or neither side is ours
+		}
+
+		state := rounds[key]
+		if state == nil {
+			state = &roundState{}
+			rounds[key] = state
+			seriesRounds[e.SeriesID]++
+		}
+
+		if !state.opened {
+			state.opened = true
+			if ours {
+				openings.FirstKills++
+				player(e.SeriesID, e.ActorID, e.ActorName).FirstKills++
+				if start, ok := roundStarts[key]; ok && !start.IsZero() && !e.OccurredAt.Before(start) {
+					firstKillSeconds += e.OccurredAt.Sub(start).Seconds()
+					timedFirstKills++
+				}
+			} else {
+				openings.FirstDeaths++
+				player(e.SeriesID, e.TargetID, e.TargetName).FirstDeaths++
+			}
+		}
+
+		// A kill trades every recent death its victim caused on the killer's team
+		traded := false
+		if !e.OccurredAt.IsZero() {
+			for _, d := range state.deaths {
+				if d.traded || d.victimTeam != e.ActorTeamID || d.killerID != e.TargetID || e.OccurredAt.Sub(d.at) > tradeWindow {
+					continue
+				}
+				d.traded = true
+				traded = true
+				if ours {
+					openings.TradedDeaths++
+					player(e.SeriesID, d.victimID, "").TradedDeaths++
+				}
+			}
+		}
+		if ours {
+			killer := player(e.SeriesID, e.ActorID, e.ActorName)
+			if traded {
+				killer.TradeKills++
+			}
+		} else {
+			openings.Deaths++
+			player(e.SeriesID, e.TargetID, e.TargetName).Deaths++
+		}
+
+		if !e.OccurredAt.IsZero() {
+			state.deaths = append(state.deaths, &roundDeath{
+				victimID:   e.TargetID,
+				victimTeam: e.TargetTeamID,
+				killerID:   e.ActorID,
+				at:         e.OccurredAt,
+			})
+		}
+	}
+
+	openings.Rounds = len(rounds)
+	if openings.Rounds == 0 {
+		return
+	}
+	openings.Series = len(seriesRounds)
+	if timedFirstKills > 0 {
+		openings.AvgFirstKillSeconds = firstKillSeconds / float64(timedFirstKills)
+	}
+	openings.FirstKillRate, openings.FirstDeathRate, openings.OpeningDuelWinRate, openings.OpeningDuelInterval, openings.TradeRate =
+		openingRates(openings.FirstKills, openings.FirstDeaths, openings.Rounds, openings.TradedDeaths, openings.Deaths)
+
+	openings.Players = []models.PlayerOpenings{}
+	for id, p := range players {
+		for seriesID := range playerSeries[id] {
+			p.Rounds += seriesRounds[seriesID]
+		}
+		p.FirstKillRate, p.FirstDeathRate, p.OpeningDuelWinRate, p.OpeningDuelInterval, p.TradeRate =
+			openingRates(p.FirstKills, p.FirstDeaths, p.Rounds, p.TradedDeaths, p.Deaths)
+		openings.Players = append(openings.Players, *p)
+	}
+	sort.Slice(openings.Players, func(i, j int) bool {
+		a, b := openings.Players[i], openings.Players[j]
+		if a.FirstKills+a.FirstDeaths != b.FirstKills+b.FirstDeaths {
+			return a.FirstKills+a.FirstDeaths > b.FirstKills+b.FirstDeaths
+		}
+		return a.Name < b.Name
+	})
+
+	stats.Openings = openings
+}
+
+// openingRates derives the rates shared by team and player opening records
+func openingRates(firstKills, firstDeaths, rounds, tradedDeaths, deaths int) (firstKillRate, firstDeathRate, duelWinRate float64, duelInterval models.Interval, tradeRate float64) {
+	if rounds > 0 {
+		firstKillRate = float64(firstKills) / float64(rounds)
+		firstDeathRate = float64(firstDeaths) / float64(rounds)
+	}
+	if duels := firstKills + firstDeaths; duels > 0 {
+		duelWinRate = float64(firstKills) / float64(duels)
+		lower, upper := appstats.Wilson(firstKills, duels, appstats.Z95)
+		duelInterval = models.Interval{Lower: lower, Upper: upper, Level: 0.95}
+	}
+	if deaths > 0 {
+		tradeRate = float64(tradedDeaths) / float64(deaths)
+	}
+	return firstKillRate, firstDeathRate, duelWinRate, duelInterval, tradeRate
+}
+
+// entryInsight calls out the opponent's entry player - the one taking the most
+// opening duels - when their record is clearly lopsided either way
+func entryInsight(opponent string, openings *models.OpeningStats) *models.KeyInsight {
+	if openings == nil || len(openings.Players) == 0 {
+		return nil
+	}
+
+	entry := openings.Players[0]
+	duels := entry.FirstKills + entry.FirstDeaths
+	if duels < minEntryDuels {
+		return nil
+	}
+
+	switch {
+	case entry.OpeningDuelInterval.Lower > 0.5:
+		return &models.KeyInsight{
+			Priority: "HIGH",
+			Icon:     "🔴",
+			Message: fmt.Sprintf("%s's entry %s wins %.0f%% of opening duels (%d-%d) - deny early picks and play for trades",
+				opponent, entry.Name, entry.OpeningDuelWinRate*100, entry.FirstKills, entry.FirstDeaths),
+		}
+	case entry.OpeningDuelInterval.Upper < 0.5:
+		return &models.KeyInsight{
+			Priority: "MEDIUM",
+			Icon:     "🟢",
+			Message: fmt.Sprintf("%s's entry %s loses %.0f%% of opening duels (%d-%d) - take the fight to them early",
+				opponent, entry.Name, (1-entry.OpeningDuelWinRate)*100, entry.FirstKills, entry.FirstDeaths),
+		}
+	default:
+		return nil
+	}
+}
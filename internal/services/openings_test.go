@@ -0,0 +1,104 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestApplyOpenings(t *testing.T) {
+	start := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	at := func(sec float64) time.Time { return start.Add(time.Duration(sec * float64(time.Second))) }
+	kill := func(round int, sec float64, killer, killerTeam, victim, victimTeam string) models.GridEvent {
+		return models.GridEvent{SeriesID: "s1", GameNumber: 1, RoundNumber: round, Type: models.EventKill, OccurredAt: at(sec),
+			ActorID: killer, ActorName: killer, ActorTeamID: killerTeam, TargetID: victim, TargetName: victim, TargetTeamID: victimTeam}
+	}
+
+	events := []models.GridEvent{
+		{SeriesID: "s1", GameNumber: 1, RoundNumber: 1, Type: models.EventRoundStart, OccurredAt: at(0)},
+		kill(1, 20, "ace", "us", "x1", "them"), // our opening kill 20s in
+		kill(1, 30, "x2", "them", "ace", "us"),
+		kill(1, 33, "bob", "us", "x2", "them"), // trades ace
+		{SeriesID: "s1", GameNumber: 1, RoundNumber: 2, Type: models.EventRoundStart, OccurredAt: at(100)},
+		kill(2, 110, "x1", "them", "ace", "us"), // their opening kill
+		kill(2, 120, "bob", "us", "x1", "them"), // too late to trade
+		{SeriesID: "s1", GameNumber: 1, RoundNumber: 3, Type: models.EventRoundStart, OccurredAt: at(200)},
+		kill(3, 230, "ace", "us", "x3", "them"),
+		kill(3, 231, "x4", "them", "bob", "us"), // bob killed nobody, so nothing is traded
+		// Series not in the stats are ignored
+		{SeriesID: "other", GameNumber: 1, RoundNumber: 1, Type: models.EventKill, ActorTeamID: "us", TargetTeamID: "them"},
+	}
+
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}}}
+	ApplyOpenings(stats, events, TradeWindow)
+
+	o := stats.Openings
+	if o == nil {
+		t.Fatal("Openings not set")
+	}
+	if o.Series != 1 || o.Rounds != 3 || o.FirstKills != 2 || o.FirstDeaths != 1 {
+		t.Errorf("openings = %+v, want 1 series, 3 rounds, 2-1 opening duels", o)
+	}
+	if math.Abs(o.OpeningDuelWinRate-2.0/3) > 1e-9 || math.Abs(o.FirstKillRate-2.0/3) > 1e-9 {
+		t.Errorf("duel rate = %.2f, first kill rate = %.2f, want 0.67", o.OpeningDuelWinRate, o.FirstKillRate)
+	}
+	if o.AvgFirstKillSeconds != 25 {
+		t.Errorf("avg first kill = %.1fs, want 25s", o.AvgFirstKillSeconds)
+	}
+	if o.Deaths != 3 || o.TradedDeaths != 1 || math.Abs(o.TradeRate-1.0/3) > 1e-9 {
+		t.Errorf("trades = %d of %d (%.2f), want 1 of 3", o.TradedDeaths, o.Deaths, o.TradeRate)
+	}
+
+	if len(o.Players) != 2 {
+		t.Fatalf("got %d players, want 2: %+v", len(o.Players), o.Players)
+	}
+	ace, bob := o.Players[0], o.Players[1]
+	if ace.Name != "ace" || ace.FirstKills != 2 || ace.FirstDeaths != 1 || ace.Rounds != 3 || ace.TradedDeaths != 1 {
+		t.Errorf("ace = %+v", ace)
+	}
+	if bob.Name != "bob" || bob.TradeKills != 1 || bob.FirstKills+bob.FirstDeaths != 0 {
+		t.Errorf("bob = %+v", bob)
+	}
+}
+
+func TestApplyOpeningsWithoutEvents(t *testing.T) {
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}}}
+	ApplyOpenings(stats, nil, TradeWindow)
+	if stats.Openings != nil {
+		t.Errorf("Openings = %+v, want nil without events", stats.Openings)
+	}
+}
+
+func TestEntryInsight(t *testing.T) {
+	openings := func(fk, fd int) *models.OpeningStats {
+		o := &models.OpeningStats{Players: []models.PlayerOpenings{{Name: "Derke", FirstKills: fk, FirstDeaths: fd}}}
+		p := &o.Players[0]
+		_, _, p.OpeningDuelWinRate, p.OpeningDuelInterval, _ = openingRates(fk, fd, 0, 0, 0)
+		return o
+	}
+
+	tests := []struct {
+		name   string
+		fk, fd int
+		want   string
+	}{
+		{"strong entry", 30, 10, "HIGH"},
+		{"weak entry", 8, 24, "MEDIUM"},
+		{"even", 16, 14, ""},
+		{"too few duels", 7, 1, ""},
+	}
+	for _, tt := range tests {
+		insight := entryInsight("FNATIC", openings(tt.fk, tt.fd))
+		switch {
+		case tt.want == "" && insight != nil:
+			t.Errorf("%s: got %+v, want no insight", tt.name, insight)
+		case tt.want != "" && (insight == nil || insight.Priority != tt.want):
+			t.Errorf("%s: got %+v, want %s", tt.name, insight, tt.want)
+		case insight != nil && !strings.Contains(insight.Message, "Derke"):
+			t.Errorf("%s: message %q doesn't name the entry", tt.name, insight.Message)
+		}
+	}
+}
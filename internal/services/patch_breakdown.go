@@ -0,0 +1,62 @@
+package services
+
+import (
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+)
+
+// ApplyPatchBreakdown tags a team's series with their patch and fills in the
+// per-patch record
+func ApplyPatchBreakdown(stats *models.TeamStats, calendar *patch.Calendar, title string) {
+	tagPatches(calendar, title, stats.Series)
+	stats.Patches = patchBreakdown(calendar, title, stats.Series)
+}
+
+// tagPatches sets each series' patch from the calendar
+func tagPatches(calendar *patch.Calendar, title string, series []models.SeriesResult) {
+	for i := range series {
+		series[i].Patch = calendar.At(title, series[i].StartTime)
+	}
+}
+
+// patchBreakdown groups tagged series by patch, in release order. Series played
+// before the first known patch are left out.
+func patchBreakdown(calendar *patch.Calendar, title string, series []models.SeriesResult) []models.PatchStats {
+	byVersion := make(map[string]*models.PatchStats)
+	for _, s := range series {
+		if s.Patch == "" {
+			continue
+		}
+		ps := byVersion[s.Patch]
+		if ps == nil {
+			ps = &models.PatchStats{Version: s.Patch}
+			byVersion[s.Patch] = ps
+		}
+		ps.MatchesPlayed++
+		ps.SeriesIDs = append(ps.SeriesIDs, s.SeriesID)
+		if s.Won {
+			ps.Wins++
+		}
+		if s.HasStats {
+			ps.Kills += s.Kills
+			ps.Deaths += s.Deaths
+		}
+	}
+
+	var breakdown []models.PatchStats
+	for _, version := range calendar.Versions(title) {
+		ps := byVersion[version]
+		if ps == nil {
+			continue
+		}
+		ps.WinRate = float64(ps.Wins) / float64(ps.MatchesPlayed)
+		lower, upper := appstats.Wilson(ps.Wins, ps.MatchesPlayed, appstats.Z95)
+		ps.WinRateInterval = models.Interval{Lower: lower, Upper: upper, Level: 0.95}
+		if ps.Deaths > 0 {
+			ps.KDRatio = float64(ps.Kills) / float64(ps.Deaths)
+		}
+		breakdown = append(breakdown, *ps)
+	}
+	return breakdown
+}
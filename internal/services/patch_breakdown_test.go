@@ -0,0 +1,134 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
+)
+
+func testCalendar(t *testing.T) *patch.Calendar {
+	t.Helper()
+	cal, err := patch.Parse([]byte(`{"valorant": [
+		{"version": "9.0", "start": "2024-06-25"},
+		{"version": "9.01", "start": "2024-07-09"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return cal
+}
+
+func TestApplyPatchBreakdown(t *testing.T) {
+	cal := testCalendar(t)
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 12, 0, 0, 0, time.UTC) }
+
+	stats := &models.TeamStats{Series: []models.SeriesResult{
+		{SeriesID: "a", StartTime: day(20), Won: true, HasStats: true, Kills: 60, Deaths: 40},
+		{SeriesID: "b", StartTime: day(10), Won: false, HasStats: true, Kills: 30, Deaths: 50},
+		{SeriesID: "c", StartTime: day(5), Won: true, HasStats: false},
+		{SeriesID: "d", StartTime: day(1), Won: true, HasStats: true, Kills: 50, Deaths: 25},
+		{SeriesID: "e", StartTime: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Won: false},
+	}}
+
+	ApplyPatchBreakdown(stats, cal, "valorant")
+
+	wantTags := []string{"9.01", "9.01", "9.0", "9.0", ""}
+	for i, want := range wantTags {
+		if got := stats.Series[i].Patch; got != want {
+			t.Errorf("series %s tagged %q, want %q", stats.Series[i].SeriesID, got, want)
+		}
+	}
+
+	if len(stats.Patches) != 2 {
+		t.Fatalf("got %d patches, want 2 (series before the calendar are left out)", len(stats.Patches))
+	}
+
+	older, newer := stats.Patches[0], stats.Patches[1]
+	if older.Version != "9.0" || older.MatchesPlayed != 2 || older.Wins != 2 || older.WinRate != 1 {
+		t.Errorf("9.0 = %+v, want 2-0", older)
+	}
+	if len(older.SeriesIDs) != 2 || older.SeriesIDs[0] != "c" || older.SeriesIDs[1] != "d" {
+		t.Errorf("9.0 series = %v, want [c d]", older.SeriesIDs)
+	}
+	// Only the series with stats count towards K/D
+	if older.KDRatio != 2 {
+		t.Errorf("9.0 K/D = %.2f, want 2.00", older.KDRatio)
+	}
+	if older.WinRateInterval.Upper != 1 || older.WinRateInterval.Lower <= 0 {
+		t.Errorf("9.0 interval = %+v", older.WinRateInterval)
+	}
+
+	if newer.Version != "9.01" || newer.MatchesPlayed != 2 || newer.Wins != 1 || newer.WinRate != 0.5 {
+		t.Errorf("9.01 = %+v, want 1-1", newer)
+	}
+	if math.Abs(newer.KDRatio-1) > 1e-9 {
+		t.Errorf("9.01 K/D = %.2f, want 1.00", newer.KDRatio)
+	}
+}
+
+func TestApplyPatchBreakdownWithoutCalendar(t *testing.T) {
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "a", StartTime: time.Now(), Won: true}}}
+
+	ApplyPatchBreakdown(stats, nil, "valorant")
+
+	if stats.Patches != nil || stats.Series[0].Patch != "" {
+		t.Errorf("nil calendar should leave stats untagged, got %+v", stats.Patches)
+	}
+}
+
+func TestPatchMeta(t *testing.T) {
+	cal := testCalendar(t)
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 12, 0, 0, 0, time.UTC) }
+
+	series := []models.SeriesRecord{
+		{ID: "s1", TournamentID: "t1", StartTime: day(1)},
+		{ID: "s2", TournamentID: "t1", StartTime: day(2)},
+		// Stored tag wins over the calendar
+		{ID: "s3", TournamentID: "t1", StartTime: day(3), Patch: "9.01"},
+		{ID: "s4", TournamentID: "t2", StartTime: day(15)},
+	}
+	games := []models.SeriesGame{
+		{SeriesID: "s1", Number: 1, Map: "Ascent"},
+		{SeriesID: "s1", Number: 2, Map: "Bind"},
+		{SeriesID: "s2", Number: 1, Map: "ascent"},
+		{SeriesID: "s2", Number: 2, Map: "Ascent"},
+		{SeriesID: "s3", Number: 1, Map: "Lotus"},
+		{SeriesID: "s4", Number: 1, Map: "Lotus"},
+		{SeriesID: "s4", Number: 2, Map: ""},
+	}
+
+	metas := patchMeta(cal, "valorant", series, games, "")
+	if len(metas) != 2 {
+		t.Fatalf("got %d patches, want 2", len(metas))
+	}
+
+	first := metas[0]
+	if first.Version != "9.0" || first.Series != 2 || first.Games != 4 {
+		t.Errorf("9.0 = %+v, want 2 series, 4 games", first)
+	}
+	if first.Maps[0].Map != "ascent" || first.Maps[0].Games != 3 || first.Maps[0].PlayRate != 0.75 {
+		t.Errorf("9.0 top map = %+v, want ascent at 75%%", first.Maps[0])
+	}
+
+	second := metas[1]
+	if second.Version != "9.01" || second.Series != 2 || second.Games != 2 || len(second.Maps) != 1 {
+		t.Errorf("9.01 = %+v, want 2 series, 2 lotus games", second)
+	}
+
+	only := patchMeta(cal, "valorant", series, games, "t2")
+	if len(only) != 1 || only[0].Series != 1 {
+		t.Errorf("tournament filter = %+v, want just s4", only)
+	}
+
+	shifts := mapShifts(metas)
+	// ascent and bind left the pool, lotus entered it
+	if len(shifts) != 3 {
+		t.Fatalf("got %d shifts, want 3: %+v", len(shifts), shifts)
+	}
+	if shifts[0].Pick != "ascent" || shifts[2].Pick != "lotus" {
+		t.Errorf("shifts = %+v, want alphabetical by map", shifts)
+	}
+}
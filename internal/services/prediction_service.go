@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/rating"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+	"github.com/yourusername/esports-scouting-backend/pkg/cache"
+)
+
+// ErrNoRatingHistory is returned when a title or team has no stored series to rate
+var ErrNoRatingHistory = errors.New("no rating history")
+
+const (
+	// Series a team needs before its predictions are used to fit the calibration
+	predictionWarmupSeries = 5
+	// Below this many warm predictions the raw rating probability is used as-is
+	minCalibrationSamples = 30
+	calibrationRidge      = 1.0
+
+	// Form is shrunk towards zero by n/(n+formShrinkMatches) recent matches
+	formShrinkMatches = 3
+	// Map win rates are shrunk towards the team's overall map win rate with this many pseudo-games
+	mapPriorGames = 4
+)
+
+type PredictionService struct {
+	pgRepo        *repository.PostgresRepo
+	cache         *cache.RedisClient
+	trendsService *TrendsService
+}
+
+func NewPredictionService(gc *grid.Client, rc *cache.RedisClient, pg *repository.PostgresRepo) *PredictionService {
+	return &PredictionService{
+		pgRepo:        pg,
+		cache:         rc,
+		trendsService: NewTrendsService(gc, rc, pg, nil), // predictions only use the alerts
+	}
+}
+
+// Predict returns team1's calibrated probability of winning a series against team2
+func (s *PredictionService) Predict(ctx context.Context, team1, team2, title string, tournamentIDs []string) (*models.Prediction, error) {
+	cacheKey := predictionCacheKey(team1, team2, title, tournamentIDs)
+	var cached models.Prediction
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	trends1, trends2 := s.fetchTrends(ctx, team1, team2, title, tournamentIDs)
+	prediction, err := s.PredictWithTrends(ctx, team1, team2, title, trends1, trends2, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, cacheKey, prediction, 30*time.Minute); err != nil {
+		fmt.Printf("[WARN] Failed to cache prediction: %v\n", err)
+	}
+	return prediction, nil
+}
+
+// predictionCacheKey identifies a prediction. The tournament filter is part of the
+// key, in sorted order, because it changes the form the prediction is based on.
+func predictionCacheKey(team1, team2, title string, tournamentIDs []string) string {
+	ids := append([]string(nil), tournamentIDs...)
+	sort.Strings(ids)
+	return fmt.Sprintf("predict:%s:%s:%s:%s", strings.ToLower(team1), strings.ToLower(team2), models.CanonicalTitle(title), strings.Join(ids, ","))
+}
+
+// PredictWithTrends is Predict for callers that already hold both teams' trend
+// reports. Either may be nil, in which case form is taken from stored series.
+// A non-zero asOf predicts as of that moment, ignoring series played since.
+func (s *PredictionService) PredictWithTrends(ctx context.Context, team1, team2, title string, trends1, trends2 *models.TrendReport, asOf time.Time) (*models.Prediction, error) {
+	title = models.CanonicalTitle(title)
+
+	series, err := s.pgRepo.ListSeries(title)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !asOf.IsZero() && asOf.Before(now) {
+		now = asOf
+		cut := len(series)
+		for i, record := range series {
+			if !record.StartTime.Before(asOf) {
+				cut = i
+				break
+			}
+		}
+		series = series[:cut] // ListSeries is oldest first
+	}
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no stored %s series - run `admin sync-series -title %s`: %w", title, title, ErrNoRatingHistory)
+	}
+
+	model := FitPredictionModel(series)
+
+	id1, ok := model.ResolveTeam(team1)
+	if !ok {
+		return nil, fmt.Errorf("team %s has no stored %s series: %w", team1, title, ErrNoRatingHistory)
+	}
+	id2, ok := model.ResolveTeam(team2)
+	if !ok {
+		return nil, fmt.Errorf("team %s has no stored %s series: %w", team2, title, ErrNoRatingHistory)
+	}
+
+	// Recent form comes from TrendsService; stored series are the fallback
+	form1, form2, formSource := formDelta(model.history[id1], now), formDelta(model.history[id2], now), "stored-series"
+	if trends1 != nil && trends2 != nil {
+		form1, form2, formSource = trendForm(trends1), trendForm(trends2), "trends"
+	}
+
+	p := model.Probability(id1, id2, now, form1-form2)
+
+	prediction := &models.Prediction{
+		Team1:               model.names[id1],
+		Team2:               model.names[id2],
+		Title:               title,
+		Team1WinProbability: p,
+		Team2WinProbability: 1 - p,
+		Team1Rating:         roundRating(model.ratings.at(id1, now).Rating),
+		Team2Rating:         roundRating(model.ratings.at(id2, now).Rating),
+		Team1RatedSeries:    model.ratings.seriesPlayed(id1),
+		Team2RatedSeries:    model.ratings.seriesPlayed(id2),
+		Team1Form:           form1,
+		Team2Form:           form2,
+		Model:               model.Info(formSource),
+		GeneratedAt:         time.Now(),
+	}
+
+	prediction.Favorite = prediction.Team1
+	if p < 0.5 {
+		prediction.Favorite = prediction.Team2
+	}
+
+	for _, id := range []string{id1, id2} {
+		if played := model.ratings.seriesPlayed(id); played < predictionWarmupSeries {
+			prediction.Warnings = append(prediction.Warnings, fmt.Sprintf("%s has only %d rated series - rating is still settling", model.names[id], played))
+		}
+	}
+	if !model.calibrated {
+		prediction.Warnings = append(prediction.Warnings, "Not enough history to calibrate - raw rating probability shown")
+	}
+
+	if title == "valorant" {
+		games, err := s.pgRepo.ListSeriesGames(title)
+		if err != nil {
+			fmt.Printf("[WARN] Failed to load map results for prediction: %v\n", err)
+		} else {
+			prediction.Maps = PredictMaps(p, series, games, id1, id2)
+		}
+	}
+
+	return prediction, nil
+}
+
+// fetchTrends loads both teams' trend reports in parallel; failures yield nil
+func (s *PredictionService) fetchTrends(ctx context.Context, team1, team2, title string, tournamentIDs []string) (*models.TrendReport, *models.TrendReport) {
+	var (
+		trends [2]*models.TrendReport
+		wg     sync.WaitGroup
+	)
+	for i, team := range []string{team1, team2} {
+		wg.Add(1)
+		go func(i int, team string) {
+			defer wg.Done()
+			t, err := s.trendsService.AnalyzeTrends(ctx, team, title, tournamentIDs, nil)
+			if err != nil {
+				fmt.Printf("[DEBUG] Trends for %s unavailable for prediction: %v\n", team, err)
+				return
+			}
+			trends[i] = t
+		}(i, team)
+	}
+	wg.Wait()
+	return trends[0], trends[1]
+}
+
+// trendForm converts a trend report into the same form feature the model is fitted on
+func trendForm(t *models.TrendReport) float64 {
+	if t.Recent.Matches == 0 || t.Overall.Matches == 0 {
+		return 0
+	}
+	n := float64(t.Recent.Matches)
+	return (t.Recent.WinRate - t.Overall.WinRate) * n / (n + formShrinkMatches)
+}
+
+// teamResult is one stored series from a team's perspective
+type teamResult struct {
+	at  time.Time
+	won bool
+}
+
+// formDelta is the last-week win rate minus the win rate over the rest of the
+// 3 months before at, shrunk towards zero when only a few recent matches exist.
+// Like TrendsService's recent and overall periods, the two never share a series,
+// so live and backtested predictions use the same feature.
+func formDelta(history []teamResult, at time.Time) float64 {
+	weekAgo := at.AddDate(0, 0, -7)
+	baselineStart := at.AddDate(0, -3, 0)
+
+	var recentWins, recent, baselineWins, baseline int
+	for _, r := range history {
+		if !r.at.Before(at) || !r.at.After(baselineStart) {
+			continue
+		}
+		if r.at.After(weekAgo) {
+			recent++
+			if r.won {
+				recentWins++
+			}
+			continue
+		}
+		baseline++
+		if r.won {
+			baselineWins++
+		}
+	}
+
+	if recent == 0 || baseline == 0 {
+		return 0
+	}
+
+	n := float64(recent)
+	delta := float64(recentWins)/n - float64(baselineWins)/float64(baseline)
+	return delta * n / (n + formShrinkMatches)
+}
+
+// predictionSample is one walk-forward prediction made before the result was known
+type predictionSample struct {
+	seriesID     string
+	tournamentID string
+	at           time.Time
+	ratingLogit  float64 // logit of the Glicko-2 win probability for team 1
+	formDiff     float64
+	team1Won     bool
+	warm         bool // both teams had enough rated series
+}
+
+// PredictionModel is the Glicko-2 table behind /ratings plus a logistic
+// calibration fitted on stored series
+type PredictionModel struct {
+	ratings        *glickoTable
+	history        map[string][]teamResult
+	names          map[string]string // team ID -> latest name
+	samples        []predictionSample
+	ratingWeight   float64
+	formWeight     float64
+	calibrated     bool
+	trainedThrough time.Time
+}
+
+// FitPredictionModel replays series chronologically, then fits the calibration on
+// the out-of-sample predictions made along the way
+func FitPredictionModel(series []models.SeriesRecord) *PredictionModel {
+	model := walkForward(series)
+	model.ratingWeight, model.formWeight, model.calibrated = fitCalibration(model.samples, time.Time{})
+	return model
+}
+
+func walkForward(series []models.SeriesRecord) *PredictionModel {
+	title := ""
+	if len(series) > 0 {
+		title = series[0].Title
+	}
+	model := &PredictionModel{
+		ratings: newGlickoTable(title),
+		history: make(map[string][]teamResult),
+		names:   make(map[string]string),
+	}
+
+	for _, s := range series {
+		model.samples = append(model.samples, predictionSample{
+			seriesID:     s.ID,
+			tournamentID: s.TournamentID,
+			at:           s.StartTime,
+			ratingLogit:  appstats.Logit(rating.Glicko2Expected(model.ratings.at(s.Team1ID, s.StartTime), model.ratings.at(s.Team2ID, s.StartTime))),
+			formDiff:     formDelta(model.history[s.Team1ID], s.StartTime) - formDelta(model.history[s.Team2ID], s.StartTime),
+			team1Won:     s.Team1Won,
+			warm:         model.ratings.seriesPlayed(s.Team1ID) >= predictionWarmupSeries && model.ratings.seriesPlayed(s.Team2ID) >= predictionWarmupSeries,
+		})
+
+		model.ratings.play(s)
+		model.history[s.Team1ID] = append(model.history[s.Team1ID], teamResult{at: s.StartTime, won: s.Team1Won})
+		model.history[s.Team2ID] = append(model.history[s.Team2ID], teamResult{at: s.StartTime, won: !s.Team1Won})
+		model.names[s.Team1ID] = s.Team1Name
+		model.names[s.Team2ID] = s.Team2Name
+		model.trainedThrough = s.StartTime
+	}
+
+	return model
+}
+
+// fitCalibration fits P(team1 wins) = sigmoid(a*ratingLogit + b*formDiff) on warm
+// samples before cutoff (zero cutoff = all samples). Each sample is added in both
+// orientations so the fit is symmetric and needs no intercept. Falls back to the
+// raw rating probability (a=1, b=0) when there is too little data.
+func fitCalibration(samples []predictionSample, cutoff time.Time) (ratingWeight, formWeight float64, ok bool) {
+	var features [][]float64
+	var outcomes []bool
+	for _, s := range samples {
+		if !s.warm || (!cutoff.IsZero() && !s.at.Before(cutoff)) {
+			continue
+		}
+		features = append(features, []float64{s.ratingLogit, s.formDiff}, []float64{-s.ratingLogit, -s.formDiff})
+		outcomes = append(outcomes, s.team1Won, !s.team1Won)
+	}
+
+	if len(features)/2 < minCalibrationSamples {
+		return 1, 0, false
+	}
+
+	weights, err := appstats.FitLogistic(features, outcomes, calibrationRidge)
+	if err != nil || weights[0] <= 0 {
+		return 1, 0, false
+	}
+	return weights[0], weights[1], true
+}
+
+// ResolveTeam finds a team ID by name: exact (case-insensitive) match first,
+// then the most-rated team whose name contains the query
+func (m *PredictionModel) ResolveTeam(name string) (string, bool) {
+	query := strings.ToLower(strings.TrimSpace(name))
+	if query == "" {
+		return "", false
+	}
+
+	best, bestGames := "", -1
+	for id, teamName := range m.names {
+		lower := strings.ToLower(teamName)
+		if lower == query {
+			return id, true
+		}
+		if strings.Contains(lower, query) && m.ratings.seriesPlayed(id) > bestGames {
+			best, bestGames = id, m.ratings.seriesPlayed(id)
+		}
+	}
+	return best, best != ""
+}
+
+// Probability returns the calibrated probability that team a beats team b, with
+// both ratings taken as of at
+func (m *PredictionModel) Probability(a, b string, at time.Time, formDiff float64) float64 {
+	x := appstats.Logit(rating.Glicko2Expected(m.ratings.at(a, at), m.ratings.at(b, at)))
+	return appstats.Sigmoid(m.ratingWeight*x + m.formWeight*formDiff)
+}
+
+// Info describes the fitted model for API responses
+func (m *PredictionModel) Info(formSource string) models.PredictionModelInfo {
+	return models.PredictionModelInfo{
+		Method:         "glicko2+form",
+		FormSource:     formSource,
+		SeriesUsed:     len(m.samples),
+		Calibrated:     m.calibrated,
+		RatingWeight:   m.ratingWeight,
+		FormWeight:     m.formWeight,
+		TrainedThrough: m.trainedThrough,
+	}
+}
+
+// PredictMaps derives per-map win probabilities for a best-of-three. The series
+// probability is converted to a single-game probability, then shifted by how much
+// better or worse each team does on a map than on its other maps.
+func PredictMaps(seriesProbability float64, series []models.SeriesRecord, games []models.SeriesGame, team1ID, team2ID string) []models.MapPrediction {
+	type record struct{ wins, games int }
+	perMap := map[string]map[string]*record{team1ID: {}, team2ID: {}}
+	overall := map[string]*record{team1ID: {}, team2ID: {}}
+
+	// Games only record the winner, so attribute each game to the teams of its series
+	seriesTeams := make(map[string]map[string]bool)
+	for _, s := range series {
+		seriesTeams[s.ID] = map[string]bool{s.Team1ID: true, s.Team2ID: true}
+	}
+
+	for _, g := range games {
+		if g.Map == "" {
+			continue
+		}
+		for _, team := range []string{team1ID, team2ID} {
+			if !seriesTeams[g.SeriesID][team] {
+				continue
+			}
+			if perMap[team][g.Map] == nil {
+				perMap[team][g.Map] = &record{}
+			}
+			perMap[team][g.Map].games++
+			overall[team].games++
+			if g.WinnerID == team {
+				perMap[team][g.Map].wins++
+				overall[team].wins++
+			}
+		}
+	}
+
+	gameProbability := bestOfThreeGameProbability(seriesProbability)
+
+	// relativeStrength is the logit gap between a team's shrunk map win rate and its overall map win rate
+	relativeStrength := func(team, mapName string) (float64, int) {
+		o := overall[team]
+		if o.games == 0 {
+			return 0, 0
+		}
+		base := (float64(o.wins) + 1) / (float64(o.games) + 2)
+		r := perMap[team][mapName]
+		if r == nil {
+			return 0, 0
+		}
+		shrunk := (float64(r.wins) + mapPriorGames*base) / (float64(r.games) + mapPriorGames)
+		return appstats.Logit(shrunk) - appstats.Logit(base), r.games
+	}
+
+	mapNames := make(map[string]bool)
+	for _, team := range []string{team1ID, team2ID} {
+		for name := range perMap[team] {
+			mapNames[name] = true
+		}
+	}
+
+	var predictions []models.MapPrediction
+	for name := range mapNames {
+		adj1, games1 := relativeStrength(team1ID, name)
+		adj2, games2 := relativeStrength(team2ID, name)
+		predictions = append(predictions, models.MapPrediction{
+			Map:                 name,
+			Team1WinProbability: appstats.Sigmoid(appstats.Logit(gameProbability) + adj1 - adj2),
+			Team1Games:          games1,
+			Team2Games:          games2,
+		})
+	}
+
+	sort.Slice(predictions, func(i, j int) bool { return predictions[i].Map < predictions[j].Map })
+	return predictions
+}
+
+// bestOfThreeGameProbability inverts P(series) = p²(3 - 2p) by bisection
+func bestOfThreeGameProbability(seriesProbability float64) float64 {
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if mid*mid*(3-2*mid) < seriesProbability {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// simulateSeries plays a round robin between teams of known strength, one
+// tournament per month, with results drawn from a logistic model
+func simulateSeries(seed int64, tournaments int) []models.SeriesRecord {
+	strengths := []float64{1.5, 1.0, 0.5, 0, 0, -0.5, -1.0, -1.5}
+	rng := rand.New(rand.NewSource(seed))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var series []models.SeriesRecord
+	for t := 0; t < tournaments; t++ {
+		at := start.AddDate(0, t, 0)
+		for i := range strengths {
+			for j := i + 1; j < len(strengths); j++ {
+				at = at.Add(3 * time.Hour)
+				p := 1 / (1 + math.Exp(-(strengths[i] - strengths[j])))
+				series = append(series, models.SeriesRecord{
+					ID:           fmt.Sprintf("s%d-%d-%d", t, i, j),
+					TournamentID: fmt.Sprintf("t%d", t),
+					Team1ID:      fmt.Sprintf("team%d", i),
+					Team2ID:      fmt.Sprintf("team%d", j),
+					Team1Name:    fmt.Sprintf("Team %d", i),
+					Team2Name:    fmt.Sprintf("Team %d", j),
+					StartTime:    at,
+					Team1Won:     rng.Float64() < p,
+				})
+			}
+		}
+	}
+	return series
+}
+
+func TestFitPredictionModel(t *testing.T) {
+	model := FitPredictionModel(simulateSeries(1, 8))
+
+	strongest, ok := model.ResolveTeam("team 0")
+	if !ok {
+		t.Fatal("expected to resolve Team 0")
+	}
+	weakest, _ := model.ResolveTeam("Team 7")
+
+	if p := model.Probability(strongest, weakest, model.trainedThrough, 0); p < 0.75 {
+		t.Errorf("strongest vs weakest = %.2f, expected a clear favourite", p)
+	}
+	if p := model.Probability(weakest, strongest, model.trainedThrough, 0); p > 0.25 {
+		t.Errorf("weakest vs strongest = %.2f, expected a clear underdog", p)
+	}
+	if !model.calibrated {
+		t.Error("expected enough history to calibrate")
+	}
+
+	if _, ok := model.ResolveTeam("unknown"); ok {
+		t.Error("resolved a team that never played")
+	}
+}
+
+func TestPredictionRatingsMatchLeaderboard(t *testing.T) {
+	series := simulateSeries(2, 3)
+	now := series[len(series)-1].StartTime.AddDate(0, 0, 30)
+	model := FitPredictionModel(series)
+	ratings, _ := ReplayGlicko("valorant", series, now)
+
+	for _, r := range ratings {
+		if got := roundRating(model.ratings.at(r.TeamID, now).Rating); got != r.Rating {
+			t.Errorf("%s: prediction rating %.1f, leaderboard %.1f", r.TeamName, got, r.Rating)
+		}
+		if got := model.ratings.seriesPlayed(r.TeamID); got != r.SeriesPlayed {
+			t.Errorf("%s: prediction counts %d series, leaderboard %d", r.TeamName, got, r.SeriesPlayed)
+		}
+	}
+}
+
+func TestBacktestBeatsCoinFlip(t *testing.T) {
+	report := Backtest("valorant", simulateSeries(2, 10), nil)
+
+	if len(report.Tournaments) != 10 {
+		t.Fatalf("got %d tournaments, want 10", len(report.Tournaments))
+	}
+	if report.Tournaments[0].Calibrated {
+		t.Error("first tournament has no earlier data and should not be calibrated")
+	}
+	if report.Overall.Brier >= 0.25 {
+		t.Errorf("overall Brier %.3f is no better than a coin flip", report.Overall.Brier)
+	}
+	if report.Overall.Series != 10*28 {
+		t.Errorf("scored %d series, want %d", report.Overall.Series, 10*28)
+	}
+
+	only := Backtest("valorant", simulateSeries(2, 10), []string{"t9"})
+	if len(only.Tournaments) != 1 || only.Tournaments[0].TournamentID != "t9" {
+		t.Errorf("tournament filter not applied: %+v", only.Tournaments)
+	}
+}
+
+func TestFormDeltaMatchesTrends(t *testing.T) {
+	at := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	var history []teamResult
+	var series []models.SeriesResult
+	for i, daysAgo := range []int{80, 60, 40, 20, 10, 5, 3, 1} {
+		won := i%3 != 0 || daysAgo < 7
+		start := at.AddDate(0, 0, -daysAgo)
+		history = append(history, teamResult{at: start, won: won})
+		series = append(series, models.SeriesResult{SeriesID: fmt.Sprint(i), StartTime: start, Won: won})
+	}
+
+	recentFrom := at.Add(-recentPeriod)
+	report := &models.TrendReport{
+		Overall: periodStats(series, at.AddDate(0, -3, 0), recentFrom),
+		Recent:  periodStats(series, recentFrom, at),
+	}
+	want := trendForm(report)
+	if got := formDelta(history, at); math.Abs(got-want) > 1e-9 || got == 0 {
+		t.Errorf("formDelta = %.4f, trendForm = %.4f, want equal and non-zero", got, want)
+	}
+
+	// Only recent matches leaves no baseline to compare against
+	if got := formDelta(history[5:], at); got != 0 {
+		t.Errorf("formDelta without a baseline = %.4f, want 0", got)
+	}
+}
+
+func TestBestOfThreeGameProbability(t *testing.T) {
+	for _, p := range []float64{0.2, 0.5, 0.7} {
+		series := p * p * (3 - 2*p)
+		if got := bestOfThreeGameProbability(series); math.Abs(got-p) > 1e-6 {
+			t.Errorf("inverse of %.3f = %.4f, want %.4f", series, got, p)
+		}
+	}
+}
+
+func TestPredictMaps(t *testing.T) {
+	series := []models.SeriesRecord{
+		{ID: "s1", Team1ID: "a", Team2ID: "c", Team1Won: true},
+		{ID: "s2", Team1ID: "a", Team2ID: "c", Team1Won: true},
+		{ID: "s3", Team1ID: "b", Team2ID: "c", Team1Won: true},
+	}
+	games := []models.SeriesGame{
+		{SeriesID: "s1", Number: 1, Map: "Ascent", WinnerID: "a"},
+		{SeriesID: "s1", Number: 2, Map: "Bind", WinnerID: "c"},
+		{SeriesID: "s1", Number: 3, Map: "Ascent", WinnerID: "a"},
+		{SeriesID: "s2", Number: 1, Map: "Ascent", WinnerID: "a"},
+		{SeriesID: "s2", Number: 2, Map: "Bind", WinnerID: "c"},
+		{SeriesID: "s3", Number: 1, Map: "Bind", WinnerID: "b"},
+	}
+
+	maps := PredictMaps(0.5, series, games, "a", "b")
+	if len(maps) != 2 || maps[0].Map != "Ascent" || maps[1].Map != "Bind" {
+		t.Fatalf("unexpected maps: %+v", maps)
+	}
+	if maps[0].Team1WinProbability <= 0.5 {
+		t.Errorf("team a is strong on Ascent, got %.2f", maps[0].Team1WinProbability)
+	}
+	if maps[1].Team1WinProbability >= 0.5 {
+		t.Errorf("team a is weak on Bind, got %.2f", maps[1].Team1WinProbability)
+	}
+	if maps[0].Team1Games != 3 || maps[1].Team1Games != 2 {
+		t.Errorf("game counts: %+v", maps)
+	}
+}
+
+func TestPredictionCacheKey(t *testing.T) {
+	unfiltered := predictionCacheKey("Cloud9", "G2 Esports", "valorant", nil)
+
+	tests := []struct {
+		name          string
+		tournamentIDs []string
+		sameAs        string
+		want          bool
+	}{
+		{"no filter", nil, unfiltered, true},
+		{"filtered differs from unfiltered", []string{"t1"}, unfiltered, false},
+		{"order does not matter", []string{"t2", "t1"}, predictionCacheKey("cloud9", "g2 esports", "valorant", []string{"t1", "t2"}), true},
+		{"different tournaments differ", []string{"t1", "t3"}, predictionCacheKey("Cloud9", "G2 Esports", "valorant", []string{"t1", "t2"}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := predictionCacheKey("Cloud9", "G2 Esports", "valorant", tt.tournamentIDs)
+			if (got == tt.sameAs) != tt.want {
+				t.Errorf("key %q vs %q: equal = %v, want %v", got, tt.sameAs, got == tt.sameAs, tt.want)
+			}
+		})
+	}
+
+	ids := []string{"t2", "t1"}
+	predictionCacheKey("Cloud9", "G2 Esports", "valorant", ids)
+	if ids[0] != "t2" {
+		t.Error("predictionCacheKey reordered the caller's tournament IDs")
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/rating"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+const (
+	// One rating period of inactivity per week widens a team's RD
+	ratingPeriod = 7 * 24 * time.Hour
+	// Teams with a deviation above this are flagged provisional on the leaderboard
+	provisionalRD = 110.0
+)
+
+type RatingService struct {
+	pgRepo *repository.PostgresRepo
+}
+
+func NewRatingService(pg *repository.PostgresRepo) *RatingService {
+	return &RatingService{pgRepo: pg}
+}
+
+// Recompute replays every stored series for a title and replaces its ratings
+func (s *RatingService) Recompute(title string) ([]models.TeamRating, error) {
+	title = models.CanonicalTitle(title)
+
+	series, err := s.pgRepo.ListSeries(title)
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no stored %s series: %w", title, ErrNoRatingHistory)
+	}
+
+	ratings, history := ReplayGlicko(title, series, time.Now())
+	if err := s.pgRepo.ReplaceRatings(title, ratings, history); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[INFO] Rated %d %s teams from %d series\n", len(ratings), title, len(series))
+	return ratings, nil
+}
+
+// Leaderboard returns the title's ratings, ranked
+func (s *RatingService) Leaderboard(title string, limit int) ([]models.TeamRating, error) {
+	ratings, err := s.pgRepo.ListRatings(models.CanonicalTitle(title), limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ratings {
+		ratings[i].Rank = i + 1
+		ratings[i].Provisional = ratings[i].RD > provisionalRD
+	}
+	return ratings, nil
+}
+
+// History returns a team's current rating and its change after every series
+func (s *RatingService) History(title, teamName string) (*models.TeamRating, []models.RatingHistoryEntry, error) {
+	title = models.CanonicalTitle(title)
+
+	team, err := s.pgRepo.FindTeamRating(title, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+	team.Provisional = team.RD > provisionalRD
+
+	history, err := s.pgRepo.GetRatingHistory(title, team.TeamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return team, history, nil
+}
+
+// glickoTable rates teams series by series. Each series is its own rating period
+// for the two teams involved, and a team's deviation grows by one period per
+// week it did not play.
+type glickoTable struct {
+	title   string
+	g       rating.Glicko2
+	players map[string]rating.Glicko2Player
+	teams   map[string]*models.TeamRating
+}
+
+func newGlickoTable(title string) *glickoTable {
+	return &glickoTable{
+		title:   title,
+		g:       rating.Glicko2{Tau: rating.DefaultGlickoTau},
+		players: make(map[string]rating.Glicko2Player),
+		teams:   make(map[string]*models.TeamRating),
+	}
+}
+
+// at returns a team's rating as of at, with its deviation inflated for the
+// weeks since it last played. Unknown teams are unrated.
+func (t *glickoTable) at(id string, at time.Time) rating.Glicko2Player {
+	team, ok := t.teams[id]
+	if !ok {
+		return rating.NewGlicko2Player()
+	}
+	return t.g.Inflate(t.players[id], idlePeriods(team.LastPlayed, at))
+}
+
+// seriesPlayed is how many series have rated a team
+func (t *glickoTable) seriesPlayed(id string) int {
+	if team, ok := t.teams[id]; ok {
+		return team.SeriesPlayed
+	}
+	return 0
+}
+
+// play rates both teams on a series and returns their ratings before and after
+func (t *glickoTable) play(s models.SeriesRecord) (before1, after1, before2, after2 rating.Glicko2Player) {
+	before1, before2 = t.at(s.Team1ID, s.StartTime), t.at(s.Team2ID, s.StartTime)
+
+	score1, score2 := 0.0, 1.0
+	if s.Team1Won {
+		score1, score2 = 1, 0
+	}
+	after1 = t.g.Update(before1, []rating.Glicko2Player{before2}, []float64{score1})
+	after2 = t.g.Update(before2, []rating.Glicko2Player{before1}, []float64{score2})
+
+	record := func(id, name string, after rating.Glicko2Player, won bool) {
+		team, ok := t.teams[id]
+		if !ok {
+			team = &models.TeamRating{Title: t.title, TeamID: id}
+			t.teams[id] = team
+		}
+		team.TeamName = name
+		t.players[id] = after
+		team.SeriesPlayed++
+		if won {
+			team.Wins++
+		} else {
+			team.Losses++
+		}
+		team.LastPlayed = s.StartTime
+	}
+	record(s.Team1ID, s.Team1Name, after1, s.Team1Won)
+	record(s.Team2ID, s.Team2Name, after2, !s.Team1Won)
+	return before1, after1, before2, after2
+}
+
+// ReplayGlicko rates every team by replaying series in chronological order on a
+// glickoTable. Deviations are finally inflated up to now so long-inactive teams
+// show their uncertainty.
+func ReplayGlicko(title string, series []models.SeriesRecord, now time.Time) ([]models.TeamRating, map[string][]models.RatingHistoryEntry) {
+	table := newGlickoTable(title)
+	history := make(map[string][]models.RatingHistoryEntry)
+
+	for _, s := range series {
+		before1, after1, before2, after2 := table.play(s)
+
+		record := func(id, opponent string, before, after, opp rating.Glicko2Player, won bool) {
+			history[id] = append(history[id], models.RatingHistoryEntry{
+				SeriesID:       s.ID,
+				PlayedAt:       s.StartTime,
+				Opponent:       opponent,
+				OpponentRating: roundRating(opp.Rating),
+				Won:            won,
+				RatingBefore:   roundRating(before.Rating),
+				RatingAfter:    roundRating(after.Rating),
+				RD:             roundRating(after.RD),
+			})
+		}
+		record(s.Team1ID, s.Team2Name, before1, after1, before2, s.Team1Won)
+		record(s.Team2ID, s.Team1Name, before2, after2, before1, !s.Team1Won)
+	}
+
+	ratings := make([]models.TeamRating, 0, len(table.teams))
+	for id, t := range table.teams {
+		p := table.at(id, now)
+		t.Rating = roundRating(p.Rating)
+		t.RD = roundRating(p.RD)
+		t.Volatility = p.Volatility
+		t.Provisional = t.RD > provisionalRD
+		t.UpdatedAt = now
+		ratings = append(ratings, *t)
+	}
+
+	sort.Slice(ratings, func(i, j int) bool {
+		if ratings[i].Rating != ratings[j].Rating {
+			return ratings[i].Rating > ratings[j].Rating
+		}
+		return strings.ToLower(ratings[i].TeamName) < strings.ToLower(ratings[j].TeamName)
+	})
+	for i := range ratings {
+		ratings[i].Rank = i + 1
+	}
+
+	return ratings, history
+}
+
+// idlePeriods is the number of whole rating periods between two times
+func idlePeriods(last, at time.Time) float64 {
+	if last.IsZero() || !at.After(last) {
+		return 0
+	}
+	return math.Floor(at.Sub(last).Hours() / ratingPeriod.Hours())
+}
+
+func roundRating(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplayGlicko(t *testing.T) {
+	series := simulateSeries(3, 6)
+	now := series[len(series)-1].StartTime.Add(24 * time.Hour)
+
+	ratings, history := ReplayGlicko("valorant", series, now)
+
+	if len(ratings) != 8 {
+		t.Fatalf("rated %d teams, want 8", len(ratings))
+	}
+	if ratings[0].Rank != 1 || ratings[0].Rating < ratings[len(ratings)-1].Rating {
+		t.Error("leaderboard is not sorted by rating")
+	}
+
+	// Team 0 is the strongest simulated team and team 7 the weakest
+	rank := make(map[string]int)
+	for _, r := range ratings {
+		rank[r.TeamID] = r.Rank
+	}
+	if rank["team0"] > 3 || rank["team7"] < 6 {
+		t.Errorf("unexpected ranks: team0 #%d, team7 #%d", rank["team0"], rank["team7"])
+	}
+
+	for _, r := range ratings {
+		if r.SeriesPlayed != 6*7 || r.Wins+r.Losses != r.SeriesPlayed {
+			t.Errorf("%s: %d series (%d-%d), want 42", r.TeamName, r.SeriesPlayed, r.Wins, r.Losses)
+		}
+		if r.RD >= 350 || r.Provisional {
+			t.Errorf("%s: RD %.1f should have settled after 42 series", r.TeamName, r.RD)
+		}
+		if len(history[r.TeamID]) != r.SeriesPlayed {
+			t.Errorf("%s: %d history entries, want %d", r.TeamName, len(history[r.TeamID]), r.SeriesPlayed)
+		}
+	}
+
+	first := history["team0"][0]
+	if first.RatingBefore != 1500 || first.RatingAfter == 1500 {
+		t.Errorf("first series should move an unrated team off 1500: %+v", first)
+	}
+}
+
+func TestReplayGlickoInactivityWidensRD(t *testing.T) {
+	series := simulateSeries(4, 3)
+	last := series[len(series)-1].StartTime
+
+	soon, _ := ReplayGlicko("lol", series, last.Add(time.Hour))
+	later, _ := ReplayGlicko("lol", series, last.AddDate(1, 0, 0))
+
+	if later[0].RD <= soon[0].RD {
+		t.Errorf("a year of inactivity should widen RD: %.1f -> %.1f", soon[0].RD, later[0].RD)
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// DefaultFormHalfLifeDays is the half-life used when none is configured
+const DefaultFormHalfLifeDays = 14
+
+// ApplyRecencyForm fills in stats.Form from stats.Series, weighting each series by
+// 0.5^(age/halfLifeDays) as of now
+func ApplyRecencyForm(stats *models.TeamStats, halfLifeDays float64, now time.Time) {
+	if len(stats.Series) == 0 || halfLifeDays <= 0 {
+		return
+	}
+
+	var weightSum, weightSquares, winWeight float64
+	var kills, deaths float64
+	for _, series := range stats.Series {
+		w := recencyWeight(series.StartTime, halfLifeDays, now)
+		weightSum += w
+		weightSquares += w * w
+		if series.Won {
+			winWeight += w
+		}
+		if series.HasStats {
+			kills += w * float64(series.Kills)
+			deaths += w * float64(series.Deaths)
+		}
+	}
+	if weightSum == 0 {
+		return
+	}
+
+	form := &models.RecencyForm{
+		HalfLifeDays:    halfLifeDays,
+		AsOf:            now,
+		WinRate:         winWeight / weightSum,
+		EffectiveSeries: weightSum * weightSum / weightSquares,
+	}
+	if deaths > 0 {
+		form.KDRatio = kills / deaths
+	}
+	stats.Form = form
+}
+
+// recencyWeight halves for every halfLifeDays between played and now. Series
+// dated in the future count fully.
+func recencyWeight(played time.Time, halfLifeDays float64, now time.Time) float64 {
+	ageDays := now.Sub(played).Hours() / 24
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	return math.Pow(0.5, ageDays/halfLifeDays)
+}
+
+// seriesRecencyWeights returns the recency weight of every series seriesKDRatios
+// returns a ratio for, in the same order. ApplyRecencyForm must have been called.
+func seriesRecencyWeights(stats *models.TeamStats) []float64 {
+	var weights []float64
+	for _, series := range stats.Series {
+		if series.HasStats && series.Deaths > 0 {
+			weights = append(weights, recencyWeight(series.StartTime, stats.Form.HalfLifeDays, stats.Form.AsOf))
+		}
+	}
+	return weights
+}
+
+// effectiveCount rounds a form's effective sample size for count-based tests
+func effectiveCount(form *models.RecencyForm) int {
+	n := int(math.Round(form.EffectiveSeries))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// datedStats builds stats with one series per result, results[0] the most recent,
+// played every gapDays days up to now
+func datedStats(results string, gapDays int, kd float64, now time.Time) *models.TeamStats {
+	stats := &models.TeamStats{}
+	for i, r := range results {
+		deaths := 50
+		kills := int(kd * float64(deaths))
+		stats.Series = append(stats.Series, models.SeriesResult{
+			SeriesID:  string(rune('a' + i)),
+			StartTime: now.AddDate(0, 0, -gapDays*i),
+			Won:       r == 'W',
+			HasStats:  true,
+			Kills:     kills,
+			Deaths:    deaths,
+		})
+		stats.MatchesPlayed++
+		stats.Kills += kills
+		stats.Deaths += deaths
+		if r == 'W' {
+			stats.Wins++
+		}
+	}
+	stats.SampleSize = stats.MatchesPlayed
+	stats.WinRate = float64(stats.Wins) / float64(stats.MatchesPlayed)
+	stats.KDRatio = float64(stats.Kills) / float64(stats.Deaths)
+	return stats
+}
+
+func TestApplyRecencyForm(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		results     string
+		gapDays     int
+		halfLife    float64
+		wantWinRate float64
+		wantEffN    float64
+	}{
+		// Weights 1 and 0.5: (1*1 + 0.5*0) / 1.5
+		{"One half-life apart", "WL", 14, 14, 2.0 / 3, 1.5 * 1.5 / 1.25},
+		{"Same day counts equally", "WLWL", 0, 14, 0.5, 4},
+		// Weights 1, 0.25, 0.0625: wins only in the oldest
+		{"Old wins fade", "LLW", 28, 14, 0.0625 / 1.3125, 1.3125 * 1.3125 / (1 + 0.0625 + 0.00390625)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := datedStats(tt.results, tt.gapDays, 1.2, now)
+			ApplyRecencyForm(stats, tt.halfLife, now)
+			if stats.Form == nil {
+				t.Fatal("expected form")
+			}
+			if math.Abs(stats.Form.WinRate-tt.wantWinRate) > 1e-9 {
+				t.Errorf("win rate = %.4f, want %.4f", stats.Form.WinRate, tt.wantWinRate)
+			}
+			if math.Abs(stats.Form.EffectiveSeries-tt.wantEffN) > 1e-9 {
+				t.Errorf("effective series = %.4f, want %.4f", stats.Form.EffectiveSeries, tt.wantEffN)
+			}
+			if math.Abs(stats.Form.KDRatio-1.2) > 1e-9 {
+				t.Errorf("K/D = %.4f, want 1.2 when every series has the same K/D", stats.Form.KDRatio)
+			}
+		})
+	}
+}
+
+func TestCalculateAdvantagesRecencyWeighting(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	// Same 12-12 record, but team 1 won the recent half and team 2 the older half
+	stats1 := datedStats("WWWWWWWWWWWWLLLLLLLLLLLL", 4, 1.0, now)
+	stats2 := datedStats("LLLLLLLLLLLLWWWWWWWWWWWW", 4, 1.0, now)
+	ApplyRecencyForm(stats1, 14, now)
+	ApplyRecencyForm(stats2, 14, now)
+
+	s := &ComparisonService{}
+
+	report := comparisonFor(stats1, stats2)
+	s.calculateAdvantages(report, stats1, stats2, models.WeightingUniform)
+	if len(report.Advantages.Team1)+len(report.Advantages.Team2)+len(report.Advantages.Inconclusive) != 0 {
+		t.Errorf("uniform weighting should see identical records, got %+v", report.Advantages)
+	}
+
+	report = comparisonFor(stats1, stats2)
+	s.calculateAdvantages(report, stats1, stats2, models.WeightingRecency)
+	got := metrics(report.Advantages.Team1)
+	if len(got) != 1 || got[0] != models.MetricWinRate {
+		t.Fatalf("Team1 advantages: got %v, want [winRate]", got)
+	}
+	if !report.Advantages.Team1[0].RecencyWeighted {
+		t.Error("advantage not marked as recency-weighted")
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// DiffReports compares an earlier scouting report (base) with a newer one for the same matchup
+func DiffReports(base, current *models.ScoutingReport) (*models.ReportDiff, error) {
+	if !sameMatchup(base.Matchup, current.Matchup) {
+		return nil, fmt.Errorf("reports are for different matchups: %s vs %s (%s) and %s vs %s (%s)",
+			base.Matchup.YourTeam, base.Matchup.Opponent, base.Matchup.Title,
+			current.Matchup.YourTeam, current.Matchup.Opponent, current.Matchup.Title)
+	}
+
+	// Comparison Team1 is always your team, Team2 the opponent
+	diff := &models.ReportDiff{
+		Matchup:            current.Matchup,
+		BaseReportID:       base.ReportID,
+		BaseGeneratedAt:    base.GeneratedAt,
+		CurrentReportID:    current.ReportID,
+		CurrentGeneratedAt: current.GeneratedAt,
+		YourTeam: diffTeamStats(current.Matchup.YourTeam,
+			base.Comparison.Team1.Stats, base.Comparison.DataQuality.Team1Matches,
+			current.Comparison.Team1.Stats, current.Comparison.DataQuality.Team1Matches),
+		Opponent: diffTeamStats(current.Matchup.Opponent,
+			base.Comparison.Team2.Stats, base.Comparison.DataQuality.Team2Matches,
+			current.Comparison.Team2.Stats, current.Comparison.DataQuality.Team2Matches),
+		NewInsights:     insightsMissingFrom(current.KeyInsights, base.KeyInsights),
+		RemovedInsights: insightsMissingFrom(base.KeyInsights, current.KeyInsights),
+		OpponentAlerts: models.AlertChanges{
+			New:      alertsMissingFrom(current.Trends.Opponent.Alerts, base.Trends.Opponent.Alerts),
+			Resolved: alertsMissingFrom(base.Trends.Opponent.Alerts, current.Trends.Opponent.Alerts),
+		},
+		YourTeamAlerts: models.AlertChanges{
+			New:      alertsMissingFrom(current.Trends.YourTeam.Alerts, base.Trends.YourTeam.Alerts),
+			Resolved: alertsMissingFrom(base.Trends.YourTeam.Alerts, current.Trends.YourTeam.Alerts),
+		},
+		Confidence: diffConfidence(base.Confidence, current.Confidence),
+	}
+
+	diff.Summary = summarizeDiff(diff)
+	return diff, nil
+}
+
+func sameMatchup(a, b models.MatchupInfo) bool {
+	return strings.EqualFold(a.Opponent, b.Opponent) &&
+		strings.EqualFold(a.YourTeam, b.YourTeam) &&
+		strings.EqualFold(a.Title, b.Title)
+}
+
+func diffTeamStats(name string, before models.ComparisonStats, matchesBefore int, after models.ComparisonStats, matchesAfter int) models.TeamStatsDiff {
+	return models.TeamStatsDiff{
+		Name:         name,
+		WinRate:      statDelta(before.WinRate, after.WinRate),
+		KDRatio:      statDelta(before.KDRatio, after.KDRatio),
+		KillsAvg:     statDelta(before.Kills.Avg, after.Kills.Avg),
+		DeathsAvg:    statDelta(before.Deaths.Avg, after.Deaths.Avg),
+		Matches:      statDelta(float64(matchesBefore), float64(matchesAfter)),
+		StreakBefore: before.CurrentStreak,
+		StreakAfter:  after.CurrentStreak,
+		Confidence:   diffConfidence(before.Confidence, after.Confidence),
+	}
+}
+
+func statDelta(before, after float64) models.StatDelta {
+	return models.StatDelta{Before: before, After: after, Delta: after - before}
+}
+
+func diffConfidence(before, after models.Confidence) models.ConfidenceDelta {
+	return models.ConfidenceDelta{
+		LevelBefore:       before.Level,
+		LevelAfter:        after.Level,
+		ReliabilityBefore: before.ReliabilityScore,
+		ReliabilityAfter:  after.ReliabilityScore,
+		ReliabilityDelta:  after.ReliabilityScore - before.ReliabilityScore,
+	}
+}
+
+// insightsMissingFrom returns insights in a that have no identical message in b
+func insightsMissingFrom(a, b []models.KeyInsight) []models.KeyInsight {
+	seen := make(map[string]bool, len(b))
+	for _, insight := range b {
+		seen[insight.Message] = true
+	}
+
+	missing := []models.KeyInsight{}
+	for _, insight := range a {
+		if !seen[insight.Message] {
+			missing = append(missing, insight)
+		}
+	}
+	return missing
+}
+
+// alertsMissingFrom returns alerts in a that have no alert of the same type and message in b
+func alertsMissingFrom(a, b []models.TrendAlert) []models.TrendAlert {
+	seen := make(map[string]bool, len(b))
+	for _, alert := range b {
+		seen[string(alert.Type)+"|"+alert.Message] = true
+	}
+
+	missing := []models.TrendAlert{}
+	for _, alert := range a {
+		if !seen[string(alert.Type)+"|"+alert.Message] {
+			missing = append(missing, alert)
+		}
+	}
+	return missing
+}
+
+// summarizeDiff produces human-readable lines for the changes worth a coach's attention
+func summarizeDiff(diff *models.ReportDiff) []string {
+	summary := []string{}
+
+	for _, team := range []models.TeamStatsDiff{diff.Opponent, diff.YourTeam} {
+		if math.Abs(team.WinRate.Delta) >= 0.05 {
+			summary = append(summary, fmt.Sprintf("%s win rate %.0f%% → %.0f%% (%+.0f pts)",
+				team.Name, team.WinRate.Before*100, team.WinRate.After*100, team.WinRate.Delta*100))
+		}
+		if math.Abs(team.KDRatio.Delta) >= 0.1 {
+			summary = append(summary, fmt.Sprintf("%s K/D %.2f → %.2f (%+.2f)",
+				team.Name, team.KDRatio.Before, team.KDRatio.After, team.KDRatio.Delta))
+		}
+		if team.Matches.Delta > 0 {
+			summary = append(summary, fmt.Sprintf("%s played %.0f more match(es) in the analysed window", team.Name, team.Matches.Delta))
+		}
+		if team.Confidence.LevelBefore != team.Confidence.LevelAfter {
+			summary = append(summary, fmt.Sprintf("%s confidence %s → %s",
+				team.Name, team.Confidence.LevelBefore, team.Confidence.LevelAfter))
+		}
+	}
+
+	if n := len(diff.OpponentAlerts.New); n > 0 {
+		summary = append(summary, fmt.Sprintf("%d new trend alert(s) for %s", n, diff.Opponent.Name))
+	}
+	if n := len(diff.YourTeamAlerts.New); n > 0 {
+		summary = append(summary, fmt.Sprintf("%d new trend alert(s) for %s", n, diff.YourTeam.Name))
+	}
+	if n := len(diff.NewInsights); n > 0 {
+		summary = append(summary, fmt.Sprintf("%d new key insight(s)", n))
+	}
+
+	if len(summary) == 0 {
+		summary = append(summary, "No significant changes since the base report")
+	}
+	return summary
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestDiffReports(t *testing.T) {
+	matchup := models.MatchupInfo{Opponent: "G2 Esports", YourTeam: "Cloud9", Title: "valorant"}
+
+	base := &models.ScoutingReport{
+		ReportID: "base",
+		Matchup:  matchup,
+		Comparison: models.ComparisonReport{
+			Team1: models.ComparisonTeamData{Stats: models.ComparisonStats{WinRate: 0.50, KDRatio: 1.00}},
+			Team2: models.ComparisonTeamData{Stats: models.ComparisonStats{
+				WinRate:    0.40,
+				KDRatio:    0.95,
+				Confidence: models.Confidence{Level: models.ConfidenceLow, ReliabilityScore: 30},
+			}},
+			DataQuality: models.DataQuality{Team1Matches: 10, Team2Matches: 4},
+		},
+		KeyInsights: []models.KeyInsight{{Priority: "LOW", Message: "Both teams showing consistent performance"}},
+	}
+
+	current := &models.ScoutingReport{
+		ReportID: "current",
+		Matchup:  models.MatchupInfo{Opponent: "g2 esports", YourTeam: "cloud9", Title: "VALORANT"},
+		Comparison: models.ComparisonReport{
+			Team1: models.ComparisonTeamData{Stats: models.ComparisonStats{WinRate: 0.52, KDRatio: 1.02}},
+			Team2: models.ComparisonTeamData{Stats: models.ComparisonStats{
+				WinRate:    0.70,
+				KDRatio:    1.20,
+				Confidence: models.Confidence{Level: models.ConfidenceMedium, ReliabilityScore: 60},
+			}},
+			DataQuality: models.DataQuality{Team1Matches: 10, Team2Matches: 10},
+		},
+		Trends: models.TrendsInfo{
+			Opponent: models.TrendReport{Alerts: []models.TrendAlert{{Type: models.AlertPositiveShift, Message: "Win rate increased by 40% in recent matches"}}},
+		},
+		KeyInsights: []models.KeyInsight{{Priority: "HIGH", Message: "Opponent has win rate advantage (+18%)"}},
+	}
+
+	diff, err := DiffReports(base, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := diff.Opponent.WinRate.Delta; got < 0.299 || got > 0.301 {
+		t.Errorf("opponent win rate delta = %v, want 0.30", got)
+	}
+	if diff.Opponent.Matches.Delta != 6 {
+		t.Errorf("opponent matches delta = %v, want 6", diff.Opponent.Matches.Delta)
+	}
+	if diff.Opponent.Confidence.LevelAfter != models.ConfidenceMedium || diff.Opponent.Confidence.ReliabilityDelta != 30 {
+		t.Errorf("unexpected opponent confidence change: %+v", diff.Opponent.Confidence)
+	}
+	if len(diff.NewInsights) != 1 || len(diff.RemovedInsights) != 1 {
+		t.Errorf("insight changes: got %d new / %d removed, want 1 / 1", len(diff.NewInsights), len(diff.RemovedInsights))
+	}
+	if len(diff.OpponentAlerts.New) != 1 || len(diff.YourTeamAlerts.New) != 0 {
+		t.Errorf("alert changes: got %d opponent / %d your team, want 1 / 0", len(diff.OpponentAlerts.New), len(diff.YourTeamAlerts.New))
+	}
+	// Your team barely moved, so only opponent lines should appear
+	for _, line := range diff.Summary {
+		if line == "No significant changes since the base report" {
+			t.Errorf("summary should list changes, got %v", diff.Summary)
+		}
+	}
+
+	other := *current
+	other.Matchup.Opponent = "Sentinels"
+	if _, err := DiffReports(base, &other); err == nil {
+		t.Error("expected an error when diffing different matchups")
+	}
+}
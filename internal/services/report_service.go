@@ -3,13 +3,13 @@ package services
 import (
 	"context"
 	"fmt"
-	"math"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/yourusername/esports-scouting-backend/internal/grid"
 	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
 	"github.com/yourusername/esports-scouting-backend/internal/repository"
 	"github.com/yourusername/esports-scouting-backend/pkg/cache"
 )
@@ -21,16 +21,24 @@ type ReportService struct {
 	compService   *ComparisonService
 	trendsService *TrendsService
 	metaService   *MetaService
+	predictions   *PredictionService
+	sites         *SiteService
+	drafts        *DraftService
+	rosters       *RosterService
 }
 
-func NewReportService(gc *grid.Client, rc *cache.RedisClient, pg *repository.PostgresRepo) *ReportService {
+func NewReportService(gc *grid.Client, rc *cache.RedisClient, pg *repository.PostgresRepo, alpha, halfLifeDays float64, patches *patch.Calendar) *ReportService {
 	return &ReportService{
 		gridClient:    gc,
 		cache:         rc,
 		pgRepo:        pg,
-		compService:   NewComparisonService(gc, rc, pg),
-		trendsService: NewTrendsService(gc, rc),
-		metaService:   NewMetaService(gc, rc),
+		compService:   NewComparisonService(gc, rc, pg, alpha, halfLifeDays, patches),
+		trendsService: NewTrendsService(gc, rc, pg, patches),
+		metaService:   NewMetaService(gc, rc, pg, patches),
+		predictions:   NewPredictionService(gc, rc, pg),
+		sites:         NewSiteService(pg),
+		drafts:        NewDraftService(pg),
+		rosters:       NewRosterService(pg),
 	}
 }
 
@@ -40,18 +48,18 @@ func (s *ReportService) GenerateScoutingReport(
 	opponent, myTeam, title string,
 	timeWindow models.TimeWindow,
 	tournamentIDs []string,
+	dateRange *models.DateRange,
+	strict bool,
+	sinceRosterChange bool,
 ) (*models.ScoutingReport, error) {
 	start := time.Now()
 	cacheHit := false
 
 	// Check cache first
-	cacheKey := fmt.Sprintf("scouting:%s:%s:%s:%s", opponent, myTeam, title, timeWindow)
+	cacheKey := fmt.Sprintf("scouting:%s:%s:%s:%s:%s:%t:%t", opponent, myTeam, title, timeWindow, dateRange.Key(), strict, sinceRosterChange)
 	var cachedReport models.ScoutingReport
 	if err := s.cache.Get(ctx, cacheKey, &cachedReport); err == nil {
-		cachedReport.CacheStatus = models.CacheStatus{
-			FromCache: true,
-			Age:       time.Since(cachedReport.GeneratedAt).String(),
-		}
+		servedFromCache(&cachedReport, time.Now())
 		return &cachedReport, nil
 	}
 
@@ -70,7 +78,7 @@ func (s *ReportService) GenerateScoutingReport(
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		comp, err := s.compService.CompareTeams(ctx, myTeam, opponent, title, timeWindow, tournamentIDs)
+		comp, err := s.compService.CompareTeams(ctx, myTeam, opponent, title, timeWindow, tournamentIDs, models.CompareOptions{DateRange: dateRange, Strict: strict, SinceRosterChange: sinceRosterChange})
 		mu.Lock()
 		if err != nil {
 			errors = append(errors, fmt.Errorf("comparison failed: %w", err))
@@ -80,11 +88,19 @@ func (s *ReportService) GenerateScoutingReport(
 		mu.Unlock()
 	}()
 
+	// With sinceRosterChange each team's trends cover its own current lineup
+	trendsRange := func(team string) (*models.DateRange, error) {
+		if !sinceRosterChange {
+			return dateRange, nil
+		}
+		return s.rosters.CurrentLineupRange(title, team)
+	}
+
 	// 2. Fetch trends for your team
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		t, err := s.trendsService.AnalyzeTrends(ctx, myTeam, title, tournamentIDs)
+		t, err := s.analyzeTrendsSince(ctx, myTeam, title, tournamentIDs, trendsRange)
 		mu.Lock()
 		if err != nil {
 			errors = append(errors, fmt.Errorf("trends for %s failed: %w", myTeam, err))
@@ -98,7 +114,7 @@ func (s *ReportService) GenerateScoutingReport(
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		t, err := s.trendsService.AnalyzeTrends(ctx, opponent, title, tournamentIDs)
+		t, err := s.analyzeTrendsSince(ctx, opponent, title, tournamentIDs, trendsRange)
 		mu.Lock()
 		if err != nil {
 			errors = append(errors, fmt.Errorf("trends for %s failed: %w", opponent, err))
@@ -133,9 +149,13 @@ func (s *ReportService) GenerateScoutingReport(
 		ReportID:    uuid.New().String(),
 		GeneratedAt: time.Now(),
 		Matchup: models.MatchupInfo{
-			Opponent: opponent,
-			YourTeam: myTeam,
-			Title:    title,
+			Opponent:  opponent,
+			YourTeam:  myTeam,
+			Title:     title,
+			DateRange: dateRange,
+			Strict:    strict,
+
+			SinceRosterChange: sinceRosterChange,
 		},
 		Comparison: *comparison,
 		Trends: models.TrendsInfo{
@@ -163,8 +183,72 @@ func (s *ReportService) GenerateScoutingReport(
 		report.MetaContext = *metaCtx
 	}
 
+	// Win probability from stored ratings (optional - needs synced series). Historic
+	// reports only use series played before the range ends.
+	var asOf time.Time
+	if dateRange != nil {
+		asOf = dateRange.To
+	}
+	prediction, err := s.predictions.PredictWithTrends(ctx, myTeam, opponent, title, trends1, trends2, asOf)
+	if err != nil {
+		fmt.Printf("[DEBUG] No prediction for %s vs %s: %v\n", myTeam, opponent, err)
+	} else {
+		report.Prediction = prediction
+	}
+
 	// Generate key insights
 	report.KeyInsights = s.generateKeyInsights(comparison, trends1, trends2)
+	if insight := predictionInsight(report.Prediction); insight != nil {
+		report.KeyInsights = append(report.KeyInsights, *insight)
+	}
+	if insight := entryInsight(opponent, comparison.Team2.Stats.Openings); insight != nil {
+		report.KeyInsights = append(report.KeyInsights, *insight)
+	}
+
+	// Economy section (Valorant teams with synced events)
+	if yours, theirs := comparison.Team1.Stats.Economy, comparison.Team2.Stats.Economy; yours != nil || theirs != nil {
+		report.Economy = &models.EconomyMatchup{Opponent: theirs, YourTeam: yours}
+	}
+
+	// Opponent's site tendencies (Valorant teams with synced events), over the
+	// series the comparison used so the block covers the report's range
+	if opponentSeries := comparison.Team2.Stats.SeriesIDs; models.CanonicalTitle(title) == "valorant" && len(opponentSeries) > 0 {
+		sites, err := s.sites.TeamSites(title, opponent, "", opponentSeries)
+		if err != nil {
+			fmt.Printf("[DEBUG] No site tendencies for %s: %v\n", opponent, err)
+		} else {
+			report.SiteTendencies = sites
+			if insight := siteInsight(opponent, sites); insight != nil {
+				report.KeyInsights = append(report.KeyInsights, *insight)
+			}
+		}
+	}
+
+	// Side choice from both teams' side splits
+	report.SideChoice = RecommendSide(comparison.Team1.Stats.Side, comparison.Team2.Stats.Side)
+	if insight := sideInsight(report.SideChoice); insight != nil {
+		report.KeyInsights = append(report.KeyInsights, *insight)
+	}
+
+	// Draft section with suggested bans (LoL teams with synced drafts), over the
+	// series the comparison used so the block covers the report's range
+	if opponentSeries := comparison.Team2.Stats.SeriesIDs; models.CanonicalTitle(title) == "lol" && len(opponentSeries) > 0 {
+		theirs, err := s.drafts.TeamDraft(title, opponent, opponentSeries)
+		if err != nil {
+			fmt.Printf("[DEBUG] No draft data for %s: %v\n", opponent, err)
+		} else {
+			draft := &models.DraftMatchup{Opponent: theirs, SuggestedBans: SuggestBans(theirs)}
+			if yourSeries := comparison.Team1.Stats.SeriesIDs; len(yourSeries) > 0 {
+				if yours, err := s.drafts.TeamDraft(title, myTeam, yourSeries); err == nil {
+					draft.YourTeam = yours
+				}
+			}
+			report.Draft = draft
+			if insight := draftInsight(opponent, draft); insight != nil {
+				report.KeyInsights = append(report.KeyInsights, *insight)
+			}
+		}
+	}
 
 	// Cache the report for 1 hour
 	if err := s.cache.Set(ctx, cacheKey, report, 1*time.Hour); err != nil {
@@ -177,6 +261,55 @@ func (s *ReportService) GenerateScoutingReport(
 	return report, nil
 }
 
+// analyzeTrendsSince runs AnalyzeTrends over the range rangeFor resolves for the team
+func (s *ReportService) analyzeTrendsSince(ctx context.Context, team, title string, tournamentIDs []string, rangeFor func(team string) (*models.DateRange, error)) (*models.TrendReport, error) {
+	dateRange, err := rangeFor(team)
+	if err != nil {
+		return nil, err
+	}
+	return s.trendsService.AnalyzeTrends(ctx, team, title, tournamentIDs, dateRange)
+}
+
+// servedFromCache gives a cached report its own ID, so every request can be saved
+// as a separate snapshot. GeneratedAt keeps the time the data was fetched.
+func servedFromCache(report *models.ScoutingReport, now time.Time) {
+	report.ReportID = uuid.New().String()
+	report.CacheStatus = models.CacheStatus{
+		FromCache: true,
+		Age:       now.Sub(report.GeneratedAt).String(),
+	}
+}
+
+// newSavedReport builds the snapshot of a report. CreatedAt is when the snapshot
+// was taken, which for a cached report is later than its GeneratedAt.
+func newSavedReport(orgID, createdBy string, timeWindow models.TimeWindow, report *models.ScoutingReport, now time.Time) *models.SavedReport {
+	report.Permalink = models.ReportPermalink(report.ReportID)
+
+	return &models.SavedReport{
+		ID:         report.ReportID,
+		OrgID:      orgID,
+		Opponent:   report.Matchup.Opponent,
+		YourTeam:   report.Matchup.YourTeam,
+		Title:      report.Matchup.Title,
+		TimeWindow: timeWindow,
+		CreatedBy:  createdBy,
+		CreatedAt:  now.UTC(),
+		Permalink:  report.Permalink,
+		Report:     report,
+	}
+}
+
+// SaveReport persists a generated report for an organisation and sets its permalink
+func (s *ReportService) SaveReport(orgID, createdBy string, timeWindow models.TimeWindow, report *models.ScoutingReport) (*models.SavedReport, error) {
+	saved := newSavedReport(orgID, createdBy, timeWindow, report, time.Now())
+
+	if err := s.pgRepo.SaveReport(saved); err != nil {
+		report.Permalink = ""
+		return nil, fmt.Errorf("failed to save report: %w", err)
+	}
+	return saved, nil
+}
+
 // calculateOverallConfidence determines overall report confidence
 func (s *ReportService) calculateOverallConfidence(comp *models.ComparisonReport) models.Confidence {
 	team1Conf := comp.Team1.Stats.Confidence
@@ -226,6 +359,13 @@ func (s *ReportService) generateKeyInsights(
 					Message:  fmt.Sprintf("Opponent struggling: %s - exploit this weakness", alert.Message),
 				})
 			}
+			if alert.Type == models.AlertRosterChange {
+				insights = append(insights, models.KeyInsight{
+					Priority: "MEDIUM",
+					Icon:     "🟡",
+					Message:  fmt.Sprintf("Opponent %s - older results may not reflect the current roster", alert.Message),
+				})
+			}
 		}
 	}
 
@@ -242,42 +382,44 @@ func (s *ReportService) generateKeyInsights(
 		}
 	}
 
-	// Statistical advantages (MEDIUM priority)
-	wrDiff := comp.Team1.Stats.WinRate - comp.Team2.Stats.WinRate
-	if math.Abs(wrDiff) >= 0.15 {
-		priority := "MEDIUM"
-		icon := "🟡"
-		var message string
-		if wrDiff > 0 {
-			message = fmt.Sprintf("You have significant win rate advantage (+%.0f%%)", wrDiff*100)
-			icon = "🟢"
-		} else {
-			message = fmt.Sprintf("Opponent has win rate advantage (+%.0f%%)", -wrDiff*100)
-			priority = "HIGH"
-			icon = "🔴"
-		}
+	// Statistical advantages (MEDIUM priority) - only gaps that passed the significance test
+	if adv := findAdvantage(comp.Advantages.Team1, models.MetricWinRate); adv != nil && adv.Difference >= 0.15 {
+		insights = append(insights, models.KeyInsight{
+			Priority: "MEDIUM",
+			Icon:     "🟢",
+			Message:  fmt.Sprintf("You have significant %s advantage (+%.0f%%, p=%.3f)", winRateLabel(adv), adv.Difference*100, *adv.PValue),
+		})
+	}
+	if adv := findAdvantage(comp.Advantages.Team2, models.MetricWinRate); adv != nil && adv.Difference >= 0.15 {
 		insights = append(insights, models.KeyInsight{
-			Priority: priority,
-			Icon:     icon,
-			Message:  message,
+			Priority: "HIGH",
+			Icon:     "🔴",
+			Message:  fmt.Sprintf("Opponent has %s advantage (+%.0f%%, p=%.3f)", winRateLabel(adv), adv.Difference*100, *adv.PValue),
 		})
 	}
 
 	// K/D ratio comparison
-	kdDiff := comp.Team1.Stats.KDRatio - comp.Team2.Stats.KDRatio
-	if math.Abs(kdDiff) >= 0.15 {
-		var message string
-		icon := "🟡"
-		if kdDiff > 0 {
-			message = fmt.Sprintf("You have better K/D ratio (+%.2f) - maintain aggressive plays", kdDiff)
-			icon = "🟢"
-		} else {
-			message = fmt.Sprintf("Opponent has better K/D ratio (+%.2f) - focus on trades", -kdDiff)
-		}
+	if adv := findAdvantage(comp.Advantages.Team1, models.MetricKDRatio); adv != nil && adv.Difference >= 0.15 {
+		insights = append(insights, models.KeyInsight{
+			Priority: "MEDIUM",
+			Icon:     "🟢",
+			Message:  fmt.Sprintf("You have better K/D ratio (+%.2f) - maintain aggressive plays", adv.Difference),
+		})
+	}
+	if adv := findAdvantage(comp.Advantages.Team2, models.MetricKDRatio); adv != nil && adv.Difference >= 0.15 {
 		insights = append(insights, models.KeyInsight{
 			Priority: "MEDIUM",
-			Icon:     icon,
-			Message:  message,
+			Icon:     "🟡",
+			Message:  fmt.Sprintf("Opponent has better K/D ratio (+%.2f) - focus on trades", adv.Difference),
+		})
+	}
+
+	// Large win rate gaps that are still within noise - warn against over-reading them
+	if adv := findAdvantage(comp.Advantages.Inconclusive, models.MetricWinRate); adv != nil && adv.Difference >= 0.15 {
+		insights = append(insights, models.KeyInsight{
+			Priority: "LOW",
+			Icon:     "⚠️",
+			Message:  fmt.Sprintf("%s's %.0f%% win rate edge is not statistically significant - don't over-read it", adv.Team, adv.Difference*100),
 		})
 	}
 
@@ -304,6 +446,48 @@ func (s *ReportService) generateKeyInsights(
 	return insights
 }
 
+// predictionInsight summarises a lopsided prediction; close matchups get no insight
+func predictionInsight(p *models.Prediction) *models.KeyInsight {
+	if p == nil {
+		return nil
+	}
+
+	switch {
+	case p.Team1WinProbability >= 0.65:
+		return &models.KeyInsight{
+			Priority: "MEDIUM",
+			Icon:     "🟢",
+			Message:  fmt.Sprintf("Model makes you %.0f%% favourites for the series", p.Team1WinProbability*100),
+		}
+	case p.Team1WinProbability <= 0.35:
+		return &models.KeyInsight{
+			Priority: "HIGH",
+			Icon:     "🔴",
+			Message:  fmt.Sprintf("Model gives you a %.0f%% chance to win the series - opponent is favoured", p.Team1WinProbability*100),
+		}
+	default:
+		return nil
+	}
+}
+
+// winRateLabel names the win rate an advantage was measured on
+func winRateLabel(adv *models.Advantage) string {
+	if adv.ScheduleAdjusted {
+		return "schedule-adjusted win rate"
+	}
+	return "win rate"
+}
+
+// findAdvantage returns the advantage for metric, or nil
+func findAdvantage(advantages []models.Advantage, metric string) *models.Advantage {
+	for i := range advantages {
+		if advantages[i].Metric == metric {
+			return &advantages[i]
+		}
+	}
+	return nil
+}
+
 // hasHighPriorityInsight checks if any HIGH priority insights exist
 func (s *ReportService) hasHighPriorityInsight(insights []models.KeyInsight) bool {
 	for _, insight := range insights {
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestCachedReportSnapshot(t *testing.T) {
+	generated := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := generated.Add(40 * time.Minute)
+
+	cached := models.ScoutingReport{
+		ReportID:    "cached-id",
+		GeneratedAt: generated,
+		Matchup:     models.MatchupInfo{Opponent: "G2 Esports", YourTeam: "Cloud9", Title: "valorant"},
+	}
+
+	first, second := cached, cached
+	servedFromCache(&first, now)
+	servedFromCache(&second, now)
+
+	if first.ReportID == cached.ReportID || first.ReportID == second.ReportID {
+		t.Errorf("cache hits got IDs %q and %q, want two new IDs", first.ReportID, second.ReportID)
+	}
+	if !first.GeneratedAt.Equal(generated) {
+		t.Errorf("GeneratedAt = %s, want the cached %s", first.GeneratedAt, generated)
+	}
+	if !first.CacheStatus.FromCache || first.CacheStatus.Age != "40m0s" {
+		t.Errorf("CacheStatus = %+v, want fromCache with age 40m0s", first.CacheStatus)
+	}
+
+	saved := newSavedReport("org-1", "coach@team.gg", models.Last3Months, &first, now)
+	if saved.ID != first.ReportID {
+		t.Errorf("saved ID = %q, want the report's %q", saved.ID, first.ReportID)
+	}
+	if !saved.CreatedAt.Equal(now) {
+		t.Errorf("saved CreatedAt = %s, want the snapshot time %s", saved.CreatedAt, now)
+	}
+	if want := models.ReportPermalink(first.ReportID); saved.Permalink != want || first.Permalink != want {
+		t.Errorf("permalinks = %q and %q, want %q", saved.Permalink, first.Permalink, want)
+	}
+	if saved.Opponent != "G2 Esports" || saved.YourTeam != "Cloud9" || saved.Title != "valorant" || saved.Report != &first {
+		t.Errorf("saved = %+v, want the report's matchup and payload", saved)
+	}
+}
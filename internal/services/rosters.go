@@ -0,0 +1,204 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// RosterService dates lineup changes from the rosters stored by sync-series
+type RosterService struct {
+	pgRepo *repository.PostgresRepo
+}
+
+func NewRosterService(pg *repository.PostgresRepo) *RosterService {
+	return &RosterService{pgRepo: pg}
+}
+
+// CurrentLineupRange returns the range from the team's first series with its
+// current lineup up to now, for sinceRosterChange. Teams are found by name
+// among rated teams, so the title must have been synced.
+func (s *RosterService) CurrentLineupRange(title, teamName string) (*models.DateRange, error) {
+	team, ids, err := storedTeamSeries(s.pgRepo, title, teamName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lineups, err := s.pgRepo.ListSeriesLineups(ids)
+	if err != nil {
+		return nil, err
+	}
+	start, changed, ok := currentLineupStart(teamLineups(lineups, func(string) string { return team.TeamID }))
+	if !ok {
+		return nil, fmt.Errorf("lineups for %s: %w", team.TeamName, repository.ErrNotFound)
+	}
+
+	anchor := "roster change " + start.StartTime.Format("2006-01-02")
+	if !changed {
+		anchor = "first stored lineup " + start.StartTime.Format("2006-01-02")
+	}
+	dateRange := &models.DateRange{From: start.StartTime, To: models.OpenRangeEnd(time.Now()), Anchor: anchor}
+	if !dateRange.From.Before(dateRange.To) {
+		return nil, fmt.Errorf("series with the current lineup of %s: %w", team.TeamName, repository.ErrNotFound)
+	}
+	return dateRange, nil
+}
+
+// teamLineups keeps the lineups of the team teamOf names in each series
+func teamLineups(lineups []models.SeriesLineup, teamOf func(seriesID string) string) []models.SeriesLineup {
+	var mine []models.SeriesLineup
+	for _, l := range lineups {
+		if team := teamOf(l.SeriesID); team != "" && l.TeamID == team && len(l.Players) > 0 {
+			mine = append(mine, l)
+		}
+	}
+	return mine
+}
+
+// lineupKey identifies a lineup by its player IDs, whatever their order
+func lineupKey(players []models.RosterPlayer) string {
+	ids := make([]string, len(players))
+	for i, p := range players {
+		ids[i] = p.ID
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// lineupDiff returns the players in next but not prev, and in prev but not
+// next, each ordered by player ID
+func lineupDiff(prev, next []models.RosterPlayer) (joined, left []models.RosterPlayer) {
+	inPrev := make(map[string]bool, len(prev))
+	for _, p := range prev {
+		inPrev[p.ID] = true
+	}
+	inNext := make(map[string]bool, len(next))
+	for _, p := range next {
+		inNext[p.ID] = true
+		if !inPrev[p.ID] {
+			joined = append(joined, p)
+		}
+	}
+	for _, p := range prev {
+		if !inNext[p.ID] {
+			left = append(left, p)
+		}
+	}
+	sort.Slice(joined, func(i, j int) bool { return joined[i].ID < joined[j].ID })
+	sort.Slice(left, func(i, j int) bool { return left[i].ID < left[j].ID })
+	return joined, left
+}
+
+// minLineupSeries is how many series in a row a lineup must play before it counts
+// as the current lineup. A shorter run, such as a one-series stand-in, is not a
+// roster change.
+const minLineupSeries = 2
+
+// lineupRun is a stretch of consecutive series played with the same lineup
+type lineupRun struct {
+	key          string
+	first, count int // index of the first series and how many series
+}
+
+// currentLineupStart returns the first series of the current lineup, and whether
+// an earlier lineup preceded it. The current lineup is the latest one that played
+// minLineupSeries series in a row. Shorter runs after it, or between two of its
+// runs, are stand-ins and do not start a new range. Without such a lineup the
+// range covers every stored series. ok is false without lineups.
+func currentLineupStart(lineups []models.SeriesLineup) (start models.SeriesLineup, changed, ok bool) {
+	if len(lineups) == 0 {
+		return models.SeriesLineup{}, false, false
+	}
+
+	var runs []lineupRun
+	for i, l := range lineups {
+		key := lineupKey(l.Players)
+		if n := len(runs); n > 0 && runs[n-1].key == key {
+			runs[n-1].count++
+			continue
+		}
+		runs = append(runs, lineupRun{key: key, first: i, count: 1})
+	}
+
+	current := len(runs) - 1
+	for current >= 0 && runs[current].count < minLineupSeries {
+		current--
+	}
+	if current < 0 {
+		return lineups[0], false, true
+	}
+
+	// Step back over stand-ins that interrupted the same lineup
+	key := runs[current].key
+	for current >= 2 && runs[current-1].count < minLineupSeries && runs[current-2].key == key {
+		current -= 2
+	}
+	return lineups[runs[current].first], current > 0, true
+}
+
+// rosterChangeAlert compares the current lineup (see currentLineupStart) with the
+// one that played most of the baseline series (up to baselineEnd, the later one
+// on a tie). It returns nil when they match or either is unknown.
+func rosterChangeAlert(lineups []models.SeriesLineup, baselineEnd time.Time) *models.TrendAlert {
+	start, changed, ok := currentLineupStart(lineups)
+	if !ok || !changed {
+		return nil
+	}
+	current := start
+	currentKey := lineupKey(current.Players)
+
+	counts := make(map[string]int)
+	var baseline *models.SeriesLineup
+	baselineSeries, other := 0, 0
+	for i, l := range lineups {
+		if l.StartTime.After(baselineEnd) {
+			continue
+		}
+		key := lineupKey(l.Players)
+		counts[key]++
+		baselineSeries++
+		if key != currentKey {
+			other++
+		}
+		if baseline == nil || counts[key] >= counts[lineupKey(baseline.Players)] {
+			baseline = &lineups[i]
+		}
+	}
+	if baseline == nil || lineupKey(baseline.Players) == currentKey {
+		return nil
+	}
+
+	joined, left := lineupDiff(baseline.Players, current.Players)
+	severity := models.AlertMedium
+	if len(joined) >= 2 || len(left) >= 2 {
+		severity = models.AlertHigh
+	}
+	return &models.TrendAlert{
+		Type:     models.AlertRosterChange,
+		Severity: severity,
+		Message: fmt.Sprintf("Lineup changed since the baseline period: %s in, %s out",
+			playerNames(joined), playerNames(left)),
+		Context: fmt.Sprintf("Current lineup since %s - %d of %d baseline series were played with another lineup; use sinceRosterChange=true to limit stats to it",
+			start.StartTime.Format("2006-01-02"), other, baselineSeries),
+		Roster: &models.RosterChange{SeriesID: start.SeriesID, At: start.StartTime, Joined: joined, Left: left},
+	}
+}
+
+// playerNames lists players by name, falling back to their ID
+func playerNames(players []models.RosterPlayer) string {
+	if len(players) == 0 {
+		return "nobody"
+	}
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name
+		if names[i] == "" {
+			names[i] = p.ID
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func testLineup(series string, day int, ids ...string) models.SeriesLineup {
+	l := models.SeriesLineup{SeriesID: series, TeamID: "us", StartTime: time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)}
+	for _, id := range ids {
+		l.Players = append(l.Players, models.RosterPlayer{ID: id, Name: strings.ToUpper(id)})
+	}
+	return l
+}
+
+func TestCurrentLineupStart(t *testing.T) {
+	tests := []struct {
+		name        string
+		lineups     []models.SeriesLineup
+		wantSeries  string
+		wantChanged bool
+		wantOK      bool
+	}{
+		{"no lineups", nil, "", false, false},
+		{"never changed", []models.SeriesLineup{
+			testLineup("s1", 1, "a", "b"), testLineup("s2", 2, "b", "a"),
+		}, "s1", false, true},
+		{"one change", []models.SeriesLineup{
+			testLineup("s1", 1, "a", "b"), testLineup("s2", 2, "a", "c"), testLineup("s3", 3, "c", "a"),
+		}, "s2", true, true},
+		{"stand-in returns", []models.SeriesLineup{
+			testLineup("s1", 1, "a", "b"), testLineup("s2", 2, "a", "b"), testLineup("s3", 3, "a", "c"), testLineup("s4", 4, "a", "b"),
+		}, "s1", false, true},
+		{"stand-in in the latest series", []models.SeriesLineup{
+			testLineup("s1", 1, "a", "b"), testLineup("s2", 2, "a", "c"), testLineup("s3", 3, "a", "c"), testLineup("s4", 4, "a", "d"),
+		}, "s2", true, true},
+		{"stand-in inside the current lineup", []models.SeriesLineup{
+			testLineup("s1", 1, "a", "b"), testLineup("s2", 2, "a", "c"), testLineup("s3", 3, "a", "c"),
+			testLineup("s4", 4, "a", "x"), testLineup("s5", 5, "a", "c"), testLineup("s6", 6, "a", "c"),
+		}, "s2", true, true},
+		{"no lineup has settled", []models.SeriesLineup{
+			testLineup("s1", 1, "a", "b"), testLineup("s2", 2, "a", "c"), testLineup("s3", 3, "a", "d"),
+		}, "s1", false, true},
+	}
+	for _, tt := range tests {
+		start, changed, ok := currentLineupStart(tt.lineups)
+		if start.SeriesID != tt.wantSeries || changed != tt.wantChanged || ok != tt.wantOK {
+			t.Errorf("%s: got %s (changed %v, ok %v), want %s (changed %v, ok %v)",
+				tt.name, start.SeriesID, changed, ok, tt.wantSeries, tt.wantChanged, tt.wantOK)
+		}
+	}
+}
+
+func TestRosterChangeAlert(t *testing.T) {
+	baselineEnd := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	lineups := []models.SeriesLineup{
+		testLineup("s1", 1, "a", "b", "c"),
+		testLineup("s2", 3, "a", "b", "c"),
+		testLineup("s3", 5, "a", "b", "x"), // one-off stand-in
+		testLineup("s4", 8, "a", "b", "c"),
+		testLineup("s5", 12, "a", "d", "e"),
+		testLineup("s6", 14, "e", "d", "a"),
+	}
+
+	alert := rosterChangeAlert(lineups, baselineEnd)
+	if alert == nil {
+		t.Fatal("no alert for a new lineup")
+	}
+	if alert.Type != models.AlertRosterChange || alert.Severity != models.AlertHigh {
+		t.Errorf("alert = %s/%s, want ROSTER_CHANGE/HIGH", alert.Type, alert.Severity)
+	}
+	if alert.Message != "Lineup changed since the baseline period: D, E in, B, C out" {
+		t.Errorf("message = %q", alert.Message)
+	}
+	if !strings.HasPrefix(alert.Context, "Current lineup since 2025-03-12 - 4 of 4 baseline series") {
+		t.Errorf("context = %q", alert.Context)
+	}
+	if alert.Roster == nil || alert.Roster.SeriesID != "s5" {
+		t.Errorf("roster = %+v, want the change dated to s5", alert.Roster)
+	}
+
+	// The stand-in never became the baseline lineup, and a lineup back to normal is no change
+	if got := rosterChangeAlert(lineups[:4], baselineEnd); got != nil {
+		t.Errorf("unchanged lineup gave %+v", got)
+	}
+	// Nothing to compare against without baseline lineups
+	if got := rosterChangeAlert(lineups[4:], baselineEnd); got != nil {
+		t.Errorf("no baseline gave %+v", got)
+	}
+
+	single := append(append([]models.SeriesLineup{}, lineups[:4]...), testLineup("s5", 12, "a", "b", "d"), testLineup("s6", 14, "a", "b", "d"))
+	if got := rosterChangeAlert(single, baselineEnd); got == nil || got.Severity != models.AlertMedium {
+		t.Errorf("one swap gave %+v, want a MEDIUM alert", got)
+	}
+
+	// A one-series stand-in in the latest series is not a roster change
+	standIn := append(append([]models.SeriesLineup{}, lineups[:4]...), testLineup("s5", 12, "a", "b", "d"))
+	if got := rosterChangeAlert(standIn, baselineEnd); got != nil {
+		t.Errorf("a latest-series stand-in gave %+v", got)
+	}
+}
+
+func TestTeamLineups(t *testing.T) {
+	them := testLineup("s1", 1, "x")
+	them.TeamID = "them"
+	lineups := []models.SeriesLineup{testLineup("s1", 1, "a"), them, testLineup("s2", 2, "a"), {SeriesID: "s3", TeamID: "us"}}
+
+	got := teamLineups(lineups, func(seriesID string) string {
+		if seriesID == "s2" {
+			return ""
+		}
+		return "us"
+	})
+	if len(got) != 1 || got[0].SeriesID != "s1" || got[0].TeamID != "us" {
+		t.Errorf("teamLineups = %+v, want only our s1 lineup", got)
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"math"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
+)
+
+const (
+	// Opponents need this many series against other teams before their record counts
+	minOpponentSeries = 2
+
+	// Pseudo-series at 50% mixed into every opponent record, so a 2-0 team is
+	// treated as good rather than unbeatable
+	opponentPriorSeries = 4
+
+	// Adjusted values are used for advantages once this many series have a rated opponent
+	minRatedSeries = 5
+
+	// Change in log K/D per unit of opponent log-odds. A 60% favourite typically
+	// posts about a 1.1 K/D, which puts the slope around 0.3.
+	kdPerLogOdds = 0.3
+)
+
+// ApplyScheduleAdjustment corrects win rate and K/D for the strength of the
+// opponents in stats.Series. Opponent strength is the opponent's win rate against
+// other teams, shrunk towards 50%. The adjusted win rate is 50% plus how far the
+// team beat the win rate an average team would expect against the same schedule;
+// the adjusted K/D scales the raw K/D by the average opponent log-odds.
+// Leaves ScheduleAdjusted nil when no opponent has a known record.
+func ApplyScheduleAdjustment(stats *models.TeamStats) {
+	if len(stats.Series) == 0 {
+		return
+	}
+
+	var rated int
+	var strengthSum, expectedSum, logOddsSum float64
+	var kdSeries int
+	for _, series := range stats.Series {
+		strength := opponentStrength(series)
+		if series.OpponentSeries >= minOpponentSeries {
+			rated++
+			strengthSum += strength
+		}
+		expectedSum += 1 - strength
+
+		if series.HasStats && series.Deaths > 0 {
+			logOddsSum += appstats.Logit(strength)
+			kdSeries++
+		}
+	}
+	if rated == 0 {
+		return
+	}
+
+	n := float64(len(stats.Series))
+	expected := expectedSum / n
+	adjusted := &models.ScheduleAdjustedStats{
+		WinRate:          math.Max(0, math.Min(1, 0.5+stats.WinRate-expected)),
+		ExpectedWinRate:  expected,
+		OpponentStrength: strengthSum / float64(rated),
+		RatedSeries:      rated,
+		// Most of the record has to be rated, or the adjustment is mostly guesswork
+		Sufficient: rated >= minRatedSeries && rated*2 >= len(stats.Series),
+	}
+	if kdSeries > 0 && stats.KDRatio > 0 {
+		adjusted.KDRatio = stats.KDRatio * math.Exp(kdPerLogOdds*logOddsSum/float64(kdSeries))
+	}
+	stats.ScheduleAdjusted = adjusted
+}
+
+// opponentStrength estimates the chance the opponent beats an average team.
+// Opponents without enough other series are treated as average.
+func opponentStrength(series models.SeriesResult) float64 {
+	if series.OpponentSeries < minOpponentSeries {
+		return 0.5
+	}
+	return (float64(series.OpponentWins) + opponentPriorSeries/2) / float64(series.OpponentSeries+opponentPriorSeries)
+}
+
+// adjustedSeriesKDRatios returns per-series K/D ratios scaled by opponent strength,
+// matching the scaling behind ScheduleAdjustedStats.KDRatio
+func adjustedSeriesKDRatios(stats *models.TeamStats) []float64 {
+	var ratios []float64
+	for _, series := range stats.Series {
+		if series.HasStats && series.Deaths > 0 {
+			factor := math.Exp(kdPerLogOdds * appstats.Logit(opponentStrength(series)))
+			ratios = append(ratios, float64(series.Kills)/float64(series.Deaths)*factor)
+		}
+	}
+	return ratios
+}
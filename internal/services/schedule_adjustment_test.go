@@ -0,0 +1,116 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// withSchedule gives every series in stats an opponent with the given record
+func withSchedule(stats *models.TeamStats, opponentWins, opponentSeries int) *models.TeamStats {
+	for i := range stats.Series {
+		stats.Series[i].OpponentWins = opponentWins
+		stats.Series[i].OpponentSeries = opponentSeries
+	}
+	return stats
+}
+
+func TestApplyScheduleAdjustment(t *testing.T) {
+	kd := []float64{1.0, 1.1, 0.9, 1.0, 1.05, 0.95, 1.0, 1.1, 0.9, 1.0}
+
+	tests := []struct {
+		name           string
+		stats          *models.TeamStats
+		expectNil      bool
+		wantWinRate    float64
+		wantSufficient bool
+		kdDirection    int // sign of adjusted minus raw K/D
+	}{
+		{
+			name:           "Strong schedule lifts the record",
+			stats:          withSchedule(buildStats(5, 10, kd, models.Streak{}), 16, 20), // opponents 75%
+			wantWinRate:    0.75,
+			wantSufficient: true,
+			kdDirection:    1,
+		},
+		{
+			name:           "Weak schedule lowers the record",
+			stats:          withSchedule(buildStats(5, 10, kd, models.Streak{}), 4, 20), // opponents 25%
+			wantWinRate:    0.25,
+			wantSufficient: true,
+			kdDirection:    -1,
+		},
+		{
+			name:           "Average schedule leaves the record alone",
+			stats:          withSchedule(buildStats(5, 10, kd, models.Streak{}), 10, 20),
+			wantWinRate:    0.5,
+			wantSufficient: true,
+		},
+		{
+			name:           "Too few rated opponents is reported but not sufficient",
+			stats:          withSchedule(buildStats(2, 3, kd[:3], models.Streak{}), 10, 20),
+			wantWinRate:    2.0 / 3,
+			wantSufficient: false,
+		},
+		{
+			name:      "Unknown opponents leave no adjustment",
+			stats:     withSchedule(buildStats(5, 10, kd, models.Streak{}), 1, 1),
+			expectNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ApplyScheduleAdjustment(tt.stats)
+			adjusted := tt.stats.ScheduleAdjusted
+			if tt.expectNil {
+				if adjusted != nil {
+					t.Fatalf("expected no adjustment, got %+v", adjusted)
+				}
+				return
+			}
+			if adjusted == nil {
+				t.Fatal("expected an adjustment")
+			}
+			if math.Abs(adjusted.WinRate-tt.wantWinRate) > 1e-9 {
+				t.Errorf("adjusted win rate = %.3f, want %.3f", adjusted.WinRate, tt.wantWinRate)
+			}
+			if adjusted.Sufficient != tt.wantSufficient {
+				t.Errorf("sufficient = %v, want %v", adjusted.Sufficient, tt.wantSufficient)
+			}
+			diff := adjusted.KDRatio - tt.stats.KDRatio
+			if (tt.kdDirection > 0 && diff <= 0) || (tt.kdDirection < 0 && diff >= 0) || (tt.kdDirection == 0 && math.Abs(diff) > 1e-9) {
+				t.Errorf("adjusted K/D %.3f vs raw %.3f, want direction %d", adjusted.KDRatio, tt.stats.KDRatio, tt.kdDirection)
+			}
+		})
+	}
+}
+
+func TestCalculateAdvantagesUsesScheduleAdjustment(t *testing.T) {
+	kd := []float64{1.0, 1.1, 0.9, 1.0, 1.05, 0.95, 1.0, 1.1, 0.9, 1.0,
+		1.0, 1.1, 0.9, 1.0, 1.05, 0.95, 1.0, 1.1, 0.9, 1.0}
+
+	// Identical raw records, but team 1 earned theirs against much stronger opponents
+	stats1 := withSchedule(buildStats(14, 20, kd, models.Streak{}), 16, 20)
+	stats2 := withSchedule(buildStats(14, 20, kd, models.Streak{}), 4, 20)
+	ApplyScheduleAdjustment(stats1)
+	ApplyScheduleAdjustment(stats2)
+
+	s := &ComparisonService{}
+	report := comparisonFor(stats1, stats2)
+	s.calculateAdvantages(report, stats1, stats2, models.WeightingUniform)
+
+	got := metrics(report.Advantages.Team1)
+	if len(got) != 2 || got[0] != models.MetricWinRate || got[1] != models.MetricKDRatio {
+		t.Fatalf("Team1 advantages: got %v, want [win_rate kd_ratio]", got)
+	}
+	for _, adv := range report.Advantages.Team1 {
+		if !adv.ScheduleAdjusted {
+			t.Errorf("%s advantage not marked as schedule-adjusted", adv.Metric)
+		}
+	}
+	if len(report.Advantages.Team2) != 0 {
+		t.Errorf("Team2 advantages: got %v, want none", metrics(report.Advantages.Team2))
+	}
+}
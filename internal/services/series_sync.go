@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/esports-scouting-backend/internal/grid"
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// SeriesSyncService copies finished series from Grid into Postgres so ratings,
+// predictions and backtests can run on the full history
+type SeriesSyncService struct {
+	gridClient *grid.Client
+	pgRepo     *repository.PostgresRepo
+	patches    *patch.Calendar
+}
+
+func NewSeriesSyncService(gc *grid.Client, pg *repository.PostgresRepo, patches *patch.Calendar) *SeriesSyncService {
+	return &SeriesSyncService{
+		gridClient: gc,
+		pgRepo:     pg,
+		patches:    patches,
+	}
+}
+
+// SyncResult counts what a sync run did
+type SyncResult struct {
+	Listed  int
+	Skipped int // already stored
+	Saved   int
+	Failed  int // unfinished or unavailable
+}
+
+// SyncTitle stores every finished series in the given tournaments (the title's
+// default tournaments if none are given). Series already stored are skipped
+// unless refresh is set.
+func (s *SeriesSyncService) SyncTitle(ctx context.Context, title string, tournamentIDs []string, refresh bool) (*SyncResult, error) {
+	title = models.CanonicalTitle(title)
+	if len(tournamentIDs) == 0 {
+		tournamentIDs = grid.DefaultTournamentIDs(title)
+	}
+	if len(tournamentIDs) == 0 {
+		return nil, fmt.Errorf("no tournaments configured for title: %s", title)
+	}
+
+	listed, err := s.gridClient.ListTournamentSeries(ctx, tournamentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(listed))
+	for i, series := range listed {
+		ids[i] = series.ID
+	}
+	stored, err := s.pgRepo.DownloadedSeriesIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Listed: len(listed)}
+	for _, series := range listed {
+		if len(series.Teams) != 2 {
+			result.Failed++
+			continue
+		}
+		if stored[series.ID] && !refresh {
+			result.Skipped++
+			continue
+		}
+
+		if err := s.syncSeries(ctx, title, series); err != nil {
+			fmt.Printf("[DEBUG] Skipping series %s: %v\n", series.ID, err)
+			result.Failed++
+			continue
+		}
+		result.Saved++
+	}
+
+	fmt.Printf("[INFO] Synced %s series: %d listed, %d saved, %d already stored, %d unavailable\n",
+		title, result.Listed, result.Saved, result.Skipped, result.Failed)
+	return result, nil
+}
+
+func (s *SeriesSyncService) syncSeries(ctx context.Context, title string, series grid.TournamentSeries) error {
+	outcome, err := s.gridClient.GetSeriesOutcome(ctx, series.ID)
+	if err != nil {
+		return err
+	}
+
+	team1, team2 := series.Teams[0], series.Teams[1]
+	if outcome.WinnerID != team1.ID && outcome.WinnerID != team2.ID {
+		return fmt.Errorf("winner %s is not one of the scheduled teams", outcome.WinnerID)
+	}
+
+	record := &models.SeriesRecord{
+		ID:             series.ID,
+		TournamentID:   series.TournamentID,
+		Team1ID:        team1.ID,
+		Team2ID:        team2.ID,
+		Team1Name:      team1.Name,
+		Team2Name:      team2.Name,
+		Title:          title,
+		StartTime:      series.StartTime,
+		Team1Won:       outcome.WinnerID == team1.ID,
+		Format:         series.Format,
+		Patch:          s.patches.At(title, series.StartTime),
+		DataDownloaded: true,
+	}
+	if err := s.pgRepo.SaveSeries(record); err != nil {
+		return fmt.Errorf("failed to save series: %w", err)
+	}
+
+	for _, stats := range outcome.Stats {
+		if err := s.pgRepo.SaveSeriesStats(stats); err != nil {
+			return fmt.Errorf("failed to save series stats: %w", err)
+		}
+	}
+
+	if err := s.pgRepo.SaveSeriesGames(series.ID, outcome.Games); err != nil {
+		return err
+	}
+	return s.pgRepo.SaveSeriesLineups(series.ID, outcome.Lineups)
+}
+
+// RetagPatches re-derives the patch of every stored series of a title from the
+// calendar, e.g. after the calendar gained a new patch. It returns how many
+// series landed on each version ("" for series before the first known patch).
+func (s *SeriesSyncService) RetagPatches(title string) (map[string]int, error) {
+	title = models.CanonicalTitle(title)
+	series, err := s.pgRepo.ListSeries(title)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string, len(series))
+	counts := make(map[string]int)
+	for _, rec := range series {
+		version := s.patches.At(title, rec.StartTime)
+		counts[version]++
+		if version != rec.Patch {
+			tags[rec.ID] = version
+		}
+	}
+
+	if err := s.pgRepo.SetSeriesPatches(tags); err != nil {
+		return nil, err
+	}
+	fmt.Printf("[INFO] Retagged %d of %d stored %s series\n", len(tags), len(series), title)
+	return counts, nil
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+// minSideGames is how many games each team needs on every side before a side
+// choice is recommended
+const minSideGames = 5
+
+// valorantSideEventTypes are the stored event types the Valorant side split reads
+var valorantSideEventTypes = []string{models.EventRoundEnd, models.EventBombPlant, models.EventGameEnd}
+
+type halfKey struct {
+	seriesID   string
+	game, half int
+}
+
+// attackingHalves works out which regulation halves each series' team attacked:
+// whoever planted more in a half was attacking. Sides swap at half time, so a
+// half without plants takes the opposite of the other half.
+func attackingHalves(events []models.GridEvent, teamOf func(seriesID string) string) map[halfKey]bool {
+	plants := make(map[halfKey]int) // ours minus theirs
+	for _, e := range events {
+		team := teamOf(e.SeriesID)
+		if e.Type != models.EventBombPlant || team == "" || e.RoundNumber == 0 {
+			continue
+		}
+		half := valorantHalf(e.RoundNumber)
+		if half < 0 {
+			continue
+		}
+		key := halfKey{e.SeriesID, e.GameNumber, half}
+		if e.ActorTeamID == team {
+			plants[key]++
+		} else {
+			plants[key]--
+		}
+	}
+
+	halves := make(map[halfKey]bool, 2*len(plants))
+	for key, n := range plants {
+		if n != 0 {
+			halves[key] = n > 0
+		}
+	}
+	for key, n := range plants {
+		other := halfKey{key.seriesID, key.game, 1 - key.half}
+		if _, known := halves[other]; !known && n != 0 {
+			halves[other] = n < 0
+		}
+	}
+	return halves
+}
+
+// ApplyLoLSides fills in stats.Side from the stored games of the team's series
+// with known sides, adding objective stats from the games with events. Side
+// stays nil when no game has sides.
+func ApplyLoLSides(stats *models.TeamStats, games []models.SeriesGame, events []models.GridEvent) {
+	teamBySeries := make(map[string]string, len(stats.Series))
+	for _, s := range stats.Series {
+		if s.TeamID != "" {
+			teamBySeries[s.SeriesID] = s.TeamID
+		}
+	}
+	tempo, _ := collectGameTempo(stats, events)
+
+	records := make(map[string]*models.RoundRecord)
+	tempos := make(map[string][]*gameTempo)
+	for _, g := range games {
+		team := teamBySeries[g.SeriesID]
+		side := g.Sides[team]
+		if team == "" || side == "" || g.WinnerID == "" {
+			continue
+		}
+		if records[side] == nil {
+			records[side] = &models.RoundRecord{}
+		}
+		addRound(records[side], g.WinnerID == team)
+		if t := tempo[roundKey{g.SeriesID, g.Number, 0}]; t != nil {
+			tempos[side] = append(tempos[side], t)
+		}
+	}
+
+	var splits []models.SideSplit
+	for _, side := range []string{models.SideBlue, models.SideRed} {
+		record := records[side]
+		if record == nil {
+			continue
+		}
+		finishRecord(record)
+		splits = append(splits, models.SideSplit{
+			Side:       side,
+			Games:      *record,
+			Objectives: summariseObjectives(tempos[side]),
+		})
+	}
+	stats.Side = splits
+}
+
+// ApplyValorantSides fills in stats.Side from the stored round, plant and
+// game-end events of the team's series. Halves are assigned a side by
+// attackingHalves; overtime rounds are left out. Maps count towards the side the
+// team started on, won by the game-end winner or else by rounds won.
+func ApplyValorantSides(stats *models.TeamStats, events []models.GridEvent) {
+	teamBySeries := make(map[string]string, len(stats.Series))
+	for _, s := range stats.Series {
+		if s.TeamID != "" {
+			teamBySeries[s.SeriesID] = s.TeamID
+		}
+	}
+	halves := attackingHalves(events, func(seriesID string) string { return teamBySeries[seriesID] })
+
+	type mapScore struct {
+		ours, theirs int
+		winner       string
+	}
+	maps := make(map[roundKey]*mapScore)
+	var order []roundKey
+	rounds := make(map[string]*models.RoundRecord)
+	pistols := make(map[string]*models.RoundRecord)
+
+	for _, e := range events {
+		team := teamBySeries[e.SeriesID]
+		if team == "" || (e.Type != models.EventRoundEnd && e.Type != models.EventGameEnd) {
+			continue
+		}
+		key := roundKey{e.SeriesID, e.GameNumber, 0}
+		score := maps[key]
+		if score == nil {
+			score = &mapScore{}
+			maps[key] = score
+			order = append(order, key)
+		}
+		if e.Type == models.EventGameEnd {
+			score.winner = e.ActorTeamID
+			continue
+		}
+
+		won := e.ActorTeamID == team
+		if won {
+			score.ours++
+		} else {
+			score.theirs++
+		}
+
+		half := valorantHalf(e.RoundNumber)
+		if e.RoundNumber == 0 || half < 0 {
+			continue
+		}
+		attacking, known := halves[halfKey{e.SeriesID, e.GameNumber, half}]
+		if !known {
+			continue
+		}
+		side := sideName(attacking)
+		if rounds[side] == nil {
+			rounds[side] = &models.RoundRecord{}
+			pistols[side] = &models.RoundRecord{}
+		}
+		addRound(rounds[side], won)
+		if isPistolRound(e.RoundNumber) {
+			addRound(pistols[side], won)
+		}
+	}
+
+	games := make(map[string]*models.RoundRecord)
+	for _, key := range order {
+		score := maps[key]
+		attacking, known := halves[halfKey{key.seriesID, key.game, 0}]
+		if !known {
+			continue
+		}
+		var won bool
+		switch {
+		case score.winner != "":
+			won = score.winner == teamBySeries[key.seriesID]
+		case score.ours != score.theirs:
+			won = score.ours > score.theirs
+		default:
+			continue
+		}
+		side := sideName(attacking)
+		if games[side] == nil {
+			games[side] = &models.RoundRecord{}
+		}
+		addRound(games[side], won)
+	}
+
+	var splits []models.SideSplit
+	for _, side := range []string{models.SideAttack, models.SideDefense} {
+		if rounds[side] == nil && games[side] == nil {
+			continue
+		}
+		split := models.SideSplit{Side: side, Rounds: rounds[side], Pistols: pistols[side]}
+		if split.Rounds != nil {
+			finishRecord(split.Rounds)
+			finishRecord(split.Pistols)
+		}
+		if record := games[side]; record != nil {
+			finishRecord(record)
+			split.Games = *record
+		}
+		splits = append(splits, split)
+	}
+	stats.Side = splits
+}
+
+func sideName(attacking bool) string {
+	if attacking {
+		return models.SideAttack
+	}
+	return models.SideDefense
+}
+
+// RecommendSide picks the side to take when holding side selection: the one
+// where our win rate on it, less the opponent's on the other side, beats the
+// reverse by at least minWinRateGap. LoL compares games per side and Valorant
+// maps by starting side; both teams need minSideGames on each side.
+func RecommendSide(ours, theirs []models.SideSplit) *models.SideChoice {
+	if len(ours) != 2 || len(theirs) != 2 {
+		return nil
+	}
+	theirBySide := make(map[string]models.RoundRecord, 2)
+	for _, split := range theirs {
+		theirBySide[split.Side] = split.Games
+	}
+
+	var scores [2]float64
+	for i, split := range ours {
+		other := ours[1-i].Side
+		their, ok := theirBySide[other]
+		if !ok || split.Games.Rounds < minSideGames || their.Rounds < minSideGames {
+			return nil
+		}
+		scores[i] = split.Games.WinRate - their.WinRate
+	}
+
+	edge := scores[0] - scores[1]
+	if math.Abs(edge) < minWinRateGap {
+		return nil
+	}
+	best := 0
+	if edge < 0 {
+		best = 1
+	}
+	take, leave := ours[best], ours[1-best]
+	unit := "games"
+	if take.Rounds != nil {
+		unit = "maps started"
+	}
+	return &models.SideChoice{
+		Side: take.Side,
+		Edge: math.Abs(edge),
+		Reason: fmt.Sprintf("you win %.0f%% of %s on %s and they win %.0f%% on %s, against %.0f%% on %s and their %.0f%% on %s",
+			take.Games.WinRate*100, unit, take.Side, theirBySide[leave.Side].WinRate*100, leave.Side,
+			leave.Games.WinRate*100, leave.Side, theirBySide[take.Side].WinRate*100, take.Side),
+	}
+}
+
+// sideInsight turns a side recommendation into a key insight
+func sideInsight(choice *models.SideChoice) *models.KeyInsight {
+	if choice == nil {
+		return nil
+	}
+	return &models.KeyInsight{
+		Priority: "MEDIUM",
+		Icon:     "🟡",
+		Message:  fmt.Sprintf("If you hold side choice, take %s - %s", choice.Side, choice.Reason),
+	}
+}
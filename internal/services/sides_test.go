@@ -0,0 +1,147 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestAttackingHalves(t *testing.T) {
+	plant := func(game, round int, team string) models.GridEvent {
+		return models.GridEvent{SeriesID: "s1", GameNumber: game, RoundNumber: round, Type: models.EventBombPlant, ActorTeamID: team}
+	}
+	events := []models.GridEvent{
+		plant(1, 2, "us"), plant(1, 5, "us"), plant(1, 7, "them"), // first half: we attack
+		plant(1, 14, "them"),                   // second half: they attack
+		plant(2, 16, "us"),                     // only the second half is known
+		plant(2, 25, "us"),                     // overtime is ignored
+		plant(3, 3, "us"), plant(3, 4, "them"), // a tie says nothing
+	}
+
+	halves := attackingHalves(events, func(string) string { return "us" })
+	tests := []struct {
+		game, half int
+		attacking  bool
+		known      bool
+	}{
+		{1, 0, true, true},
+		{1, 1, false, true},
+		{2, 0, false, true},
+		{2, 1, true, true},
+		{3, 0, false, false},
+		{3, 1, false, false},
+	}
+	for _, tt := range tests {
+		attacking, known := halves[halfKey{"s1", tt.game, tt.half}]
+		if attacking != tt.attacking || known != tt.known {
+			t.Errorf("game %d half %d = %v (known %v), want %v (known %v)", tt.game, tt.half, attacking, known, tt.attacking, tt.known)
+		}
+	}
+}
+
+func TestApplyValorantSides(t *testing.T) {
+	var events []models.GridEvent
+	round := func(game, n int, planter, winner string) {
+		if planter != "" {
+			events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: game, RoundNumber: n, Type: models.EventBombPlant, ActorTeamID: planter})
+		}
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: game, RoundNumber: n, Type: models.EventRoundEnd, ActorTeamID: winner})
+	}
+
+	// Map 1: we start on attack, win 2 of 3 attack rounds and 1 of 2 defense
+	// rounds, then lose in overtime per the game-end event
+	round(1, 1, "us", "us")
+	round(1, 2, "", "them")
+	round(1, 3, "us", "us")
+	round(1, 13, "them", "us")
+	round(1, 14, "them", "them")
+	round(1, 25, "", "them")
+	events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, Type: models.EventGameEnd, ActorTeamID: "them"})
+	// Map 2: we start on defense and win on rounds
+	round(2, 1, "them", "us")
+	round(2, 2, "", "us")
+
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}}}
+	ApplyValorantSides(stats, events)
+
+	if len(stats.Side) != 2 || stats.Side[0].Side != models.SideAttack || stats.Side[1].Side != models.SideDefense {
+		t.Fatalf("sides = %+v, want attack then defense", stats.Side)
+	}
+	attack, defense := stats.Side[0], stats.Side[1]
+	if attack.Rounds.Rounds != 3 || attack.Rounds.Wins != 2 || attack.Pistols.Rounds != 1 || attack.Pistols.Wins != 1 {
+		t.Errorf("attack rounds = %+v, pistols = %+v", attack.Rounds, attack.Pistols)
+	}
+	if defense.Rounds.Rounds != 4 || defense.Rounds.Wins != 3 || defense.Pistols.Rounds != 2 || defense.Pistols.Wins != 2 {
+		t.Errorf("defense rounds = %+v, pistols = %+v", defense.Rounds, defense.Pistols)
+	}
+	if attack.Games.Rounds != 1 || attack.Games.Wins != 0 || defense.Games.Rounds != 1 || defense.Games.Wins != 1 {
+		t.Errorf("maps by starting side = %+v / %+v", attack.Games, defense.Games)
+	}
+}
+
+func TestApplyLoLSides(t *testing.T) {
+	game := func(series string, n int, ourSide, winner string) models.SeriesGame {
+		other := models.SideRed
+		if ourSide == models.SideRed {
+			other = models.SideBlue
+		}
+		return models.SeriesGame{SeriesID: series, Number: n, WinnerID: winner, Sides: map[string]string{"us": ourSide, "them": other}}
+	}
+	games := []models.SeriesGame{
+		game("s1", 1, models.SideBlue, "us"),
+		game("s1", 2, models.SideRed, "them"),
+		game("s1", 3, models.SideBlue, "us"),
+		{SeriesID: "s1", Number: 4, WinnerID: "us"}, // sides unknown
+	}
+	events := []models.GridEvent{
+		{SeriesID: "s1", GameNumber: 1, Type: models.EventObjective, SourceType: "team-killed-ATierNPC", ActorTeamID: "us", Detail: "cloudDrake"},
+		{SeriesID: "s1", GameNumber: 2, Type: models.EventObjective, SourceType: "team-killed-ATierNPC", ActorTeamID: "them", Detail: "oceanDrake"},
+	}
+
+	stats := &models.TeamStats{Series: []models.SeriesResult{{SeriesID: "s1", TeamID: "us"}}}
+	ApplyLoLSides(stats, games, events)
+
+	if len(stats.Side) != 2 {
+		t.Fatalf("sides = %+v", stats.Side)
+	}
+	blue, red := stats.Side[0], stats.Side[1]
+	if blue.Side != models.SideBlue || blue.Games.Rounds != 2 || blue.Games.Wins != 2 || red.Games.Rounds != 1 || red.Games.Wins != 0 {
+		t.Errorf("blue = %+v, red = %+v", blue.Games, red.Games)
+	}
+	if blue.Objectives == nil || blue.Objectives.Games != 1 || blue.Objectives.FirstDragon.Rate != 1 {
+		t.Errorf("blue objectives = %+v, want first dragon in its one game with events", blue.Objectives)
+	}
+	if red.Objectives == nil || red.Objectives.FirstDragon.Firsts != 0 {
+		t.Errorf("red objectives = %+v", red.Objectives)
+	}
+}
+
+func TestRecommendSide(t *testing.T) {
+	split := func(side string, games, wins int) models.SideSplit {
+		return models.SideSplit{Side: side, Games: models.RoundRecord{Rounds: games, Wins: wins, WinRate: float64(wins) / float64(games)}}
+	}
+
+	ours := []models.SideSplit{split(models.SideBlue, 10, 7), split(models.SideRed, 10, 5)}
+	theirs := []models.SideSplit{split(models.SideBlue, 10, 6), split(models.SideRed, 10, 4)}
+	choice := RecommendSide(ours, theirs)
+	if choice == nil || choice.Side != models.SideBlue {
+		t.Fatalf("choice = %+v, want blue", choice)
+	}
+	// Blue: 70% vs their red 40%; red: 50% vs their blue 60%
+	if choice.Edge < 0.399 || choice.Edge > 0.401 {
+		t.Errorf("edge = %.3f, want 0.4", choice.Edge)
+	}
+	if !strings.HasPrefix(choice.Reason, "you win 70% of games on blue and they win 40% on red") {
+		t.Errorf("reason = %q", choice.Reason)
+	}
+
+	even := []models.SideSplit{split(models.SideBlue, 10, 5), split(models.SideRed, 10, 5)}
+	if got := RecommendSide(even, even); got != nil {
+		t.Errorf("even sides gave %+v", got)
+	}
+	few := []models.SideSplit{split(models.SideBlue, 4, 4), split(models.SideRed, 10, 0)}
+	if got := RecommendSide(few, theirs); got != nil {
+		t.Errorf("too few games gave %+v", got)
+	}
+}
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+)
+
+// minSitePlants is how many plants a map needs before a site preference is
+// called out in a scouting report, and minSiteShare how lopsided it must be
+const (
+	minSitePlants = 10
+	minSiteShare  = 0.6
+)
+
+// siteEventTypes are the stored event types the site analysis reads
+var siteEventTypes = []string{models.EventGameStart, models.EventRoundStart, models.EventRoundEnd, models.EventBombPlant}
+
+// plantTimingBuckets split plants by time from round start
+var plantTimingBuckets = []struct {
+	label string
+	upTo  float64
+}{
+	{"0-30s", 30},
+	{"30-60s", 60},
+	{"60-90s", 90},
+	{"90s+", math.Inf(1)},
+}
+
+// SiteService reports Valorant plant and retake tendencies from stored events
+type SiteService struct {
+	pgRepo *repository.PostgresRepo
+}
+
+func NewSiteService(pg *repository.PostgresRepo) *SiteService {
+	return &SiteService{pgRepo: pg}
+}
+
+// TeamSites loads a team's stored series and their plant events, optionally for
+// one map. A non-nil seriesIDs limits it to those series, so a report only counts
+// plants from the period it covers. Teams are found by name among rated teams, so
+// the title must have been synced.
+func (s *SiteService) TeamSites(title, teamName, mapName string, seriesIDs []string) (*models.SiteReport, error) {
+	team, ids, err := storedTeamSeries(s.pgRepo, title, teamName, seriesIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.pgRepo.ListSeriesEvents(ids, siteEventTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	report := SiteTendencies(team.TeamID, events, mapName)
+	if report == nil {
+		return nil, fmt.Errorf("plant events for %s: %w", team.TeamName, repository.ErrNotFound)
+	}
+	report.Team = team.TeamName
+	return report, nil
+}
+
+// storedTeamSeries finds a rated team by name and the IDs of its stored series,
+// limited to only when it is non-nil
+func storedTeamSeries(pg *repository.PostgresRepo, title, teamName string, only []string) (*models.TeamRating, []string, error) {
+	title = models.CanonicalTitle(title)
+	team, err := pg.FindTeamRating(title, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series, err := pg.ListSeries(title)
+	if err != nil {
+		return nil, nil, err
+	}
+	return team, teamSeriesIDs(series, team.TeamID, only), nil
+}
+
+// teamSeriesIDs returns the IDs of the team's series, keeping only those in only
+// when it is non-nil
+func teamSeriesIDs(series []models.SeriesRecord, teamID string, only []string) []string {
+	var allowed map[string]bool
+	if only != nil {
+		allowed = make(map[string]bool, len(only))
+		for _, id := range only {
+			allowed[id] = true
+		}
+	}
+
+	var ids []string
+	for _, rec := range series {
+		if rec.Team1ID != teamID && rec.Team2ID != teamID {
+			continue
+		}
+		if allowed != nil && !allowed[rec.ID] {
+			continue
+		}
+		ids = append(ids, rec.ID)
+	}
+	return ids
+}
+
+type siteRound struct {
+	start        time.Time
+	plantSite    string
+	plantAt      time.Time
+	planted      bool
+	plantedByUs  bool
+	decided, won bool
+}
+
+// SiteTendencies works out where a team plants on attack and which sites it
+// concedes on defence, per map, from game-start, round and plant events in
+// series order. The side a team played in each half comes from attackingHalves.
+// Returns nil when no plants are found.
+func SiteTendencies(teamID string, events []models.GridEvent, mapFilter string) *models.SiteReport {
+	maps := make(map[roundKey]string) // keyed by game, round 0
+	rounds := make(map[roundKey]*siteRound)
+	var order []roundKey
+
+	for _, e := range events {
+		gameKey := roundKey{e.SeriesID, e.GameNumber, 0}
+		if e.Type == models.EventGameStart {
+			maps[gameKey] = strings.ToLower(e.Detail)
+			continue
+		}
+		if e.RoundNumber == 0 {
+			continue
+		}
+
+		key := roundKey{e.SeriesID, e.GameNumber, e.RoundNumber}
+		r := rounds[key]
+		if r == nil {
+			r = &siteRound{}
+			rounds[key] = r
+			order = append(order, key)
+		}
+
+		switch e.Type {
+		case models.EventRoundStart:
+			r.start = e.OccurredAt
+		case models.EventBombPlant:
+			if !r.planted {
+				r.planted = true
+				r.plantedByUs = e.ActorTeamID == teamID
+				r.plantSite = strings.ToUpper(e.Detail)
+				r.plantAt = e.OccurredAt
+			}
+		case models.EventRoundEnd:
+			r.decided = true
+			r.won = e.ActorTeamID == teamID
+		}
+	}
+
+	halves := attackingHalves(events, func(string) string { return teamID })
+
+	type mapTotals struct {
+		sites    map[string]*models.SiteTendency
+		conceded map[string]*models.SiteConcession
+		timings  []float64
+		summary  models.MapSites
+	}
+	byMap := make(map[string]*mapTotals)
+	series := make(map[string]bool)
+
+	for _, key := range order {
+		mapName := maps[roundKey{key.seriesID, key.game, 0}]
+		if mapName == "" || (mapFilter != "" && !strings.EqualFold(mapName, mapFilter)) {
+			continue
+		}
+		t := byMap[mapName]
+		if t == nil {
+			t = &mapTotals{
+				sites:    make(map[string]*models.SiteTendency),
+				conceded: make(map[string]*models.SiteConcession),
+				summary:  models.MapSites{Map: mapName},
+			}
+			byMap[mapName] = t
+		}
+		series[key.seriesID] = true
+
+		r := rounds[key]
+		half := valorantHalf(key.round)
+		attacking := (half >= 0 && halves[halfKey{key.seriesID, key.game, half}]) || (r.planted && r.plantedByUs)
+		if attacking {
+			t.summary.AttackRounds++
+		}
+		if !r.planted {
+			continue
+		}
+
+		if r.plantedByUs {
+			t.summary.Plants++
+			site := t.sites[r.plantSite]
+			if site == nil {
+				site = &models.SiteTendency{Site: r.plantSite}
+				t.sites[r.plantSite] = site
+			}
+			site.Plants++
+			if r.decided && r.won {
+				site.Wins++
+			}
+			if !r.start.IsZero() && !r.plantAt.Before(r.start) {
+				t.timings = append(t.timings, r.plantAt.Sub(r.start).Seconds())
+			}
+			continue
+		}
+
+		site := t.conceded[r.plantSite]
+		if site == nil {
+			site = &models.SiteConcession{Site: r.plantSite}
+			t.conceded[r.plantSite] = site
+		}
+		site.Plants++
+		if r.decided {
+			addRound(&t.summary.Retakes, r.won)
+			if r.won {
+				site.Retakes++
+			}
+		}
+	}
+
+	report := &models.SiteReport{TeamID: teamID, Series: len(series), Maps: []models.MapSites{}}
+	plants := 0
+	for _, t := range byMap {
+		m := t.summary
+		if m.AttackRounds > 0 {
+			m.PlantRate = float64(m.Plants) / float64(m.AttackRounds)
+		}
+
+		m.Sites = []models.SiteTendency{}
+		for _, site := range t.sites {
+			site.Share = float64(site.Plants) / float64(m.Plants)
+			site.WinRate = float64(site.Wins) / float64(site.Plants)
+			m.Sites = append(m.Sites, *site)
+		}
+		sort.Slice(m.Sites, func(i, j int) bool {
+			if m.Sites[i].Plants != m.Sites[j].Plants {
+				return m.Sites[i].Plants > m.Sites[j].Plants
+			}
+			return m.Sites[i].Site < m.Sites[j].Site
+		})
+
+		conceded := 0
+		for _, site := range t.conceded {
+			conceded += site.Plants
+		}
+		plants += m.Plants + conceded
+		m.Conceded = []models.SiteConcession{}
+		for _, site := range t.conceded {
+			site.Share = float64(site.Plants) / float64(conceded)
+			site.RetakeRate = float64(site.Retakes) / float64(site.Plants)
+			m.Conceded = append(m.Conceded, *site)
+		}
+		sort.Slice(m.Conceded, func(i, j int) bool {
+			if m.Conceded[i].Plants != m.Conceded[j].Plants {
+				return m.Conceded[i].Plants > m.Conceded[j].Plants
+			}
+			return m.Conceded[i].Site < m.Conceded[j].Site
+		})
+		finishRecord(&m.Retakes)
+
+		m.PlantTiming, m.MedianPlant = plantTiming(t.timings)
+		report.Maps = append(report.Maps, m)
+	}
+	if plants == 0 {
+		return nil
+	}
+
+	sort.Slice(report.Maps, func(i, j int) bool {
+		if report.Maps[i].Plants != report.Maps[j].Plants {
+			return report.Maps[i].Plants > report.Maps[j].Plants
+		}
+		return report.Maps[i].Map < report.Maps[j].Map
+	})
+	return report
+}
+
+// valorantHalf is 0 or 1 for regulation rounds and -1 in overtime, where sides
+// swap every round
+func valorantHalf(round int) int {
+	switch {
+	case round <= valorantHalfRounds:
+		return 0
+	case round <= 2*valorantHalfRounds:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// plantTiming buckets plant times and returns their median
+func plantTiming(seconds []float64) ([]models.TimingBucket, float64) {
+	buckets := make([]models.TimingBucket, len(plantTimingBuckets))
+	for i, b := range plantTimingBuckets {
+		buckets[i].Label = b.label
+	}
+	if len(seconds) == 0 {
+		return buckets, 0
+	}
+
+	for _, sec := range seconds {
+		for i, b := range plantTimingBuckets {
+			if sec < b.upTo {
+				buckets[i].Plants++
+				break
+			}
+		}
+	}
+	for i := range buckets {
+		buckets[i].Share = float64(buckets[i].Plants) / float64(len(seconds))
+	}
+
+	sorted := append([]float64(nil), seconds...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return buckets, (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return buckets, sorted[mid]
+}
+
+// siteInsight calls out the map where the opponent's plants lean hardest on one site
+func siteInsight(opponent string, report *models.SiteReport) *models.KeyInsight {
+	if report == nil {
+		return nil
+	}
+
+	var best *models.MapSites
+	for i := range report.Maps {
+		m := &report.Maps[i]
+		if m.Plants < minSitePlants || len(m.Sites) == 0 || m.Sites[0].Share < minSiteShare {
+			continue
+		}
+		if best == nil || m.Sites[0].Share > best.Sites[0].Share {
+			best = m
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	top := best.Sites[0]
+	return &models.KeyInsight{
+		Priority: "MEDIUM",
+		Icon:     "🟡",
+		Message: fmt.Sprintf("%s plant %s on %.0f%% of their %s attacks (%d of %d plants) - consider stacking %s",
+			opponent, top.Site, top.Share*100, best.Map, top.Plants, best.Plants, top.Site),
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestSiteTendencies(t *testing.T) {
+	start := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	var events []models.GridEvent
+	game := func(n int, mapName string) {
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: n, Type: models.EventGameStart, Detail: mapName})
+	}
+	round := func(g, n int, planter, site string, plantSec int, winner string) {
+		at := start.Add(time.Duration(g*10000+n*200) * time.Second)
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: g, RoundNumber: n, Type: models.EventRoundStart, OccurredAt: at})
+		if planter != "" {
+			events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: g, RoundNumber: n, Type: models.EventBombPlant,
+				ActorTeamID: planter, Detail: site, OccurredAt: at.Add(time.Duration(plantSec) * time.Second)})
+		}
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: g, RoundNumber: n, Type: models.EventRoundEnd, ActorTeamID: winner})
+	}
+
+	game(1, "Ascent")
+	// First half: we attack
+	round(1, 1, "us", "b", 40, "us")
+	round(1, 2, "us", "B", 70, "them")
+	round(1, 3, "", "", 0, "them") // no plant, still an attack round
+	round(1, 4, "us", "A", 95, "us")
+	// Second half: they attack
+	round(1, 13, "them", "A", 50, "us") // retaken
+	round(1, 14, "them", "A", 50, "them")
+	round(1, 15, "them", "B", 50, "them")
+	game(2, "Bind")
+	round(2, 1, "them", "A", 20, "them")
+
+	report := SiteTendencies("us", events, "")
+	if report == nil {
+		t.Fatal("no report")
+	}
+	if report.Series != 1 || len(report.Maps) != 2 || report.Maps[0].Map != "ascent" {
+		t.Fatalf("report = %+v, want ascent then bind", report)
+	}
+
+	ascent := report.Maps[0]
+	if ascent.AttackRounds != 4 || ascent.Plants != 3 || math.Abs(ascent.PlantRate-0.75) > 1e-9 {
+		t.Errorf("ascent attack = %d rounds, %d plants (%.2f)", ascent.AttackRounds, ascent.Plants, ascent.PlantRate)
+	}
+	if len(ascent.Sites) != 2 || ascent.Sites[0].Site != "B" || ascent.Sites[0].Plants != 2 || ascent.Sites[0].Wins != 1 {
+		t.Errorf("sites = %+v, want B twice (1 win) first", ascent.Sites)
+	}
+	if ascent.MedianPlant != 70 {
+		t.Errorf("median plant = %.0fs, want 70s", ascent.MedianPlant)
+	}
+	wantTiming := []int{0, 1, 1, 1}
+	for i, b := range ascent.PlantTiming {
+		if b.Plants != wantTiming[i] {
+			t.Errorf("timing %s = %d plants, want %d", b.Label, b.Plants, wantTiming[i])
+		}
+	}
+
+	if ascent.Retakes.Rounds != 3 || ascent.Retakes.Wins != 1 {
+		t.Errorf("retakes = %+v, want 1 of 3", ascent.Retakes)
+	}
+	if ascent.Conceded[0].Site != "A" || ascent.Conceded[0].Plants != 2 || ascent.Conceded[0].RetakeRate != 0.5 {
+		t.Errorf("conceded = %+v, want A twice, half retaken", ascent.Conceded)
+	}
+
+	only := SiteTendencies("us", events, "bind")
+	if only == nil || len(only.Maps) != 1 || only.Maps[0].Plants != 0 || only.Maps[0].Retakes.Rounds != 1 {
+		t.Errorf("bind only = %+v", only)
+	}
+}
+
+// A half without plants takes the opposite side of the other half, so rounds in
+// an attack half where the team never got a plant down still count as attack rounds
+func TestSiteTendenciesPlantlessAttackHalf(t *testing.T) {
+	var events []models.GridEvent
+	events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, Type: models.EventGameStart, Detail: "Ascent"})
+	round := func(n int, planter string) {
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, RoundNumber: n, Type: models.EventRoundStart})
+		if planter != "" {
+			events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, RoundNumber: n, Type: models.EventBombPlant, ActorTeamID: planter, Detail: "A"})
+		}
+		events = append(events, models.GridEvent{SeriesID: "s1", GameNumber: 1, RoundNumber: n, Type: models.EventRoundEnd, ActorTeamID: "them"})
+	}
+	// First half: they attack and plant
+	round(1, "them")
+	round(2, "them")
+	// Second half: we attack but never plant
+	round(13, "")
+	round(14, "")
+	round(15, "")
+
+	report := SiteTendencies("us", events, "")
+	if report == nil || len(report.Maps) != 1 {
+		t.Fatalf("report = %+v, want ascent", report)
+	}
+	ascent := report.Maps[0]
+	if ascent.AttackRounds != 3 || ascent.Plants != 0 || ascent.PlantRate != 0 {
+		t.Errorf("ascent attack = %d rounds, %d plants (%.2f), want 3 rounds without plants", ascent.AttackRounds, ascent.Plants, ascent.PlantRate)
+	}
+	if ascent.Retakes.Rounds != 2 {
+		t.Errorf("retakes = %+v, want the 2 defended plants", ascent.Retakes)
+	}
+}
+
+func TestSiteTendenciesWithoutPlants(t *testing.T) {
+	events := []models.GridEvent{
+		{SeriesID: "s1", GameNumber: 1, Type: models.EventGameStart, Detail: "ascent"},
+		{SeriesID: "s1", GameNumber: 1, RoundNumber: 1, Type: models.EventRoundEnd, ActorTeamID: "us"},
+	}
+	if report := SiteTendencies("us", events, ""); report != nil {
+		t.Errorf("report = %+v, want nil without plants", report)
+	}
+}
+
+func TestSiteInsight(t *testing.T) {
+	report := &models.SiteReport{Maps: []models.MapSites{
+		{Map: "bind", Plants: 20, Sites: []models.SiteTendency{{Site: "A", Plants: 11, Share: 0.55}}},
+		{Map: "ascent", Plants: 12, Sites: []models.SiteTendency{{Site: "B", Plants: 9, Share: 0.75}}},
+		{Map: "lotus", Plants: 5, Sites: []models.SiteTendency{{Site: "C", Plants: 5, Share: 1}}},
+	}}
+
+	insight := siteInsight("FNATIC", report)
+	if insight == nil {
+		t.Fatal("no insight for a 75% B preference")
+	}
+	if want := "FNATIC plant B on 75% of their ascent attacks (9 of 12 plants) - consider stacking B"; insight.Message != want {
+		t.Errorf("message = %q, want %q", insight.Message, want)
+	}
+
+	report.Maps[1].Sites[0].Share = 0.5
+	if insight := siteInsight("FNATIC", report); insight != nil {
+		t.Errorf("got %+v, want no insight without a lopsided map", insight)
+	}
+}
+
+func TestTeamSeriesIDs(t *testing.T) {
+	series := []models.SeriesRecord{
+		{ID: "old", Team1ID: "a", Team2ID: "b"},
+		{ID: "in-range", Team1ID: "c", Team2ID: "a"},
+		{ID: "other-teams", Team1ID: "b", Team2ID: "c"},
+		{ID: "latest", Team1ID: "a", Team2ID: "c"},
+	}
+
+	tests := []struct {
+		name string
+		only []string
+		want []string
+	}{
+		{"every stored series", nil, []string{"old", "in-range", "latest"}},
+		{"out-of-range series excluded", []string{"in-range", "latest"}, []string{"in-range", "latest"}},
+		{"other teams' series stay out", []string{"other-teams", "latest"}, []string{"latest"}},
+		{"no series in range", []string{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := teamSeriesIDs(series, "a", tt.only); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("teamSeriesIDs(%v) = %v, want %v", tt.only, got, tt.want)
+			}
+		})
+	}
+}
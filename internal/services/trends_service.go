@@ -4,54 +4,90 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
+	"time"
+
 	"github.com/yourusername/esports-scouting-backend/internal/grid"
 	"github.com/yourusername/esports-scouting-backend/internal/models"
+	"github.com/yourusername/esports-scouting-backend/internal/patch"
+	"github.com/yourusername/esports-scouting-backend/internal/repository"
+	appstats "github.com/yourusername/esports-scouting-backend/internal/stats"
 	"github.com/yourusername/esports-scouting-backend/pkg/cache"
 )
 
 type TrendsService struct {
 	gridClient *grid.Client
 	cache      *cache.RedisClient
+	pgRepo     *repository.PostgresRepo // stored lineups for roster change alerts
+	patches    *patch.Calendar
 }
 
-func NewTrendsService(gc *grid.Client, rc *cache.RedisClient) *TrendsService {
+func NewTrendsService(gc *grid.Client, rc *cache.RedisClient, pg *repository.PostgresRepo, patches *patch.Calendar) *TrendsService {
 	return &TrendsService{
 		gridClient: gc,
 		cache:      rc,
+		pgRepo:     pg,
+		patches:    patches,
 	}
 }
 
-// AnalyzeTrends compares recent performance to overall baseline
-func (s *TrendsService) AnalyzeTrends(ctx context.Context, teamName, title string, tournamentIDs []string) (*models.TrendReport, error) {
-	// Fetch overall stats (3 months baseline) - use team NAME, not ID
-	overallStats, err := s.gridClient.GetTeamStatistics(ctx, teamName, title, models.Last3Months, tournamentIDs)
+// recentPeriod is the span AnalyzeTrends treats as current form
+const recentPeriod = 7 * 24 * time.Hour
+
+const (
+	// Each side of a change point needs this many series
+	changePointMinSegment = 3
+	changePointAlpha      = 0.05
+	changePointRounds     = 2000
+)
+
+// AnalyzeTrends compares the last week to the rest of the last 3 months. Both periods
+// come from one fetch and do not overlap, so a thin week is reported as thin rather
+// than silently widened until it matches the baseline. With a date range, the
+// analysis is anchored to its end: the recent week is the range's final week and
+// the baseline the rest of the range.
+func (s *TrendsService) AnalyzeTrends(ctx context.Context, teamName, title string, tournamentIDs []string, dateRange *models.DateRange) (*models.TrendReport, error) {
+	var stats *models.TeamStats
+	var err error
+	if dateRange != nil {
+		stats, err = s.gridClient.GetTeamStatisticsBetween(ctx, teamName, title, *dateRange, tournamentIDs)
+	} else {
+		// Fetch overall stats (3 months baseline) - use team NAME, not ID
+		stats, err = s.gridClient.GetTeamStatistics(ctx, teamName, title, models.Last3Months, tournamentIDs, false)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch overall stats: %w", err)
 	}
 
-	// Fetch recent stats (last week)
-	recentStats, err := s.gridClient.GetTeamStatistics(ctx, teamName, title, models.LastWeek, tournamentIDs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch recent stats: %w", err)
+	now := time.Now()
+	baselineWindow := stats.ActualTimeWindow
+	if baselineWindow == "" {
+		baselineWindow = models.Last3Months
 	}
+	baselineFrom := baselineWindow.Cutoff(now)
+	if dateRange != nil {
+		now, baselineFrom = dateRange.To, dateRange.From
+	}
+	recentFrom := now.Add(-recentPeriod)
 
 	// Build period stats
-	overall := models.PeriodStats{
-		TimeWindow: models.Last3Months,
-		WinRate:    overallStats.WinRate,
-		KDRatio:    overallStats.KDRatio,
-		Matches:    overallStats.MatchesPlayed,
+	overall := periodStats(stats.Series, baselineFrom, recentFrom)
+	if dateRange == nil {
+		// An explicit range has no named window; From/To describe it
+		overall.TimeWindow = baselineWindow
 	}
 
-	recent := models.PeriodStats{
-		TimeWindow: models.LastWeek,
-		WinRate:    recentStats.WinRate,
-		KDRatio:    recentStats.KDRatio,
-		Matches:    recentStats.MatchesPlayed,
-	}
+	recent := periodStats(stats.Series, recentFrom, now)
+	recent.TimeWindow = models.LastWeek
+
+	played := seriesBetween(stats.Series, baselineFrom, now)
+	tagPatches(s.patches, title, played)
 
 	// Analyze trends and generate alerts
-	alerts := s.generateAlerts(overall, recent)
+	alerts := s.generateAlerts(played)
+	if alert := s.rosterAlert(played, recentFrom); alert != nil {
+		alerts = append([]models.TrendAlert{*alert}, alerts...)
+	}
 
 	// Calculate confidence for trend analysis
 	confidence := s.calculateTrendConfidence(recent.Matches, overall.Matches)
@@ -63,82 +99,289 @@ func (s *TrendsService) AnalyzeTrends(ctx context.Context, teamName, title strin
 		Recent:     recent,
 		Alerts:     alerts,
 		Confidence: confidence,
+		DateRange:  dateRange,
+		Patches:    patchBreakdown(s.patches, title, played),
 	}, nil
 }
 
-func (s *TrendsService) generateAlerts(overall, recent models.PeriodStats) []models.TrendAlert {
+// RollingTrends charts win rate and K/D over rolling windows of each size in
+// windowDays, one point every stepDays, across the lookback period or, when
+// given, the date range
+func (s *TrendsService) RollingTrends(ctx context.Context, teamName, title string, tournamentIDs []string, lookback models.TimeWindow, dateRange *models.DateRange, windowDays []int, stepDays int) (*models.TrendSeries, error) {
+	if dateRange != nil {
+		stats, err := s.gridClient.GetTeamStatisticsBetween(ctx, teamName, title, *dateRange, tournamentIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch stats: %w", err)
+		}
+		trends := buildTrendSeries(stats.Series, dateRange.From, dateRange.To, windowDays, stepDays)
+		tagPatches(s.patches, title, trends.Series)
+		trends.Team = teamName
+		trends.Title = title
+		return trends, nil
+	}
+
+	stats, err := s.gridClient.GetTeamStatistics(ctx, teamName, title, lookback, tournamentIDs, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stats: %w", err)
+	}
+
+	window := stats.ActualTimeWindow
+	if window == "" {
+		window = lookback
+	}
+
+	now := time.Now()
+	trends := buildTrendSeries(stats.Series, window.Cutoff(now), now, windowDays, stepDays)
+	tagPatches(s.patches, title, trends.Series)
+	trends.Team = teamName
+	trends.Title = title
+	return trends, nil
+}
+
+// periodTotals are the raw counts behind a period's rates
+type periodTotals struct {
+	matches, wins int
+	kills, deaths int
+	statsSeries   int // series with downloaded kills and deaths
+}
+
+// sumPeriod totals the series played in (from, to]
+func sumPeriod(series []models.SeriesResult, from, to time.Time) periodTotals {
+	var totals periodTotals
+	for _, result := range series {
+		if !result.StartTime.After(from) || result.StartTime.After(to) {
+			continue
+		}
+		totals.matches++
+		if result.Won {
+			totals.wins++
+		}
+		if result.HasStats {
+			totals.kills += result.Kills
+			totals.deaths += result.Deaths
+			totals.statsSeries++
+		}
+	}
+	return totals
+}
+
+// seriesBetween returns the series played in (from, to], oldest first
+func seriesBetween(series []models.SeriesResult, from, to time.Time) []models.SeriesResult {
+	var played []models.SeriesResult
+	for _, result := range series {
+		if result.StartTime.After(from) && !result.StartTime.After(to) {
+			played = append(played, result)
+		}
+	}
+	sort.Slice(played, func(i, j int) bool {
+		return played[i].StartTime.Before(played[j].StartTime)
+	})
+	return played
+}
+
+// periodStats aggregates the series played in (from, to]. K/D only counts series
+// with downloaded stats and is 0 when there are none.
+func periodStats(series []models.SeriesResult, from, to time.Time) models.PeriodStats {
+	totals := sumPeriod(series, from, to)
+	period := models.PeriodStats{From: from, To: to, Matches: totals.matches, SeriesIDs: seriesIDs(seriesBetween(series, from, to), false)}
+	if totals.matches > 0 {
+		period.WinRate = float64(totals.wins) / float64(totals.matches)
+	}
+	if totals.deaths > 0 {
+		period.KDRatio = float64(totals.kills) / float64(totals.deaths)
+	}
+	return period
+}
+
+// buildTrendSeries computes rolling points ending at to and stepping back to from.
+// Windows are clipped at from, and windows without series are omitted.
+func buildTrendSeries(series []models.SeriesResult, from, to time.Time, windowDays []int, stepDays int) *models.TrendSeries {
+	trends := &models.TrendSeries{From: from, To: to, StepDays: stepDays, Series: seriesBetween(series, from, to)}
+
+	step := time.Duration(stepDays) * 24 * time.Hour
+	for _, days := range windowDays {
+		rolling := models.RollingWindow{Days: days, Points: []models.RollingPoint{}}
+		for end := to; end.After(from); end = end.Add(-step) {
+			start := end.AddDate(0, 0, -days)
+			if start.Before(from) {
+				start = from
+			}
+
+			totals := sumPeriod(trends.Series, start, end)
+			if totals.matches == 0 {
+				continue
+			}
+			lower, upper := appstats.Wilson(totals.wins, totals.matches, appstats.Z95)
+			point := models.RollingPoint{
+				Start:           start,
+				End:             end,
+				Matches:         totals.matches,
+				Wins:            totals.wins,
+				WinRate:         float64(totals.wins) / float64(totals.matches),
+				WinRateInterval: models.Interval{Lower: lower, Upper: upper, Level: 0.95},
+				StatsSeries:     totals.statsSeries,
+			}
+			if totals.deaths > 0 {
+				point.KDRatio = float64(totals.kills) / float64(totals.deaths)
+			}
+			rolling.Points = append(rolling.Points, point)
+		}
+
+		// Collected newest first; charts want oldest first
+		for i, j := 0, len(rolling.Points)-1; i < j; i, j = i+1, j-1 {
+			rolling.Points[i], rolling.Points[j] = rolling.Points[j], rolling.Points[i]
+		}
+		trends.Windows = append(trends.Windows, rolling)
+	}
+
+	return trends
+}
+
+// generateAlerts runs change-point detection over the win/loss sequence and the
+// per-series K/D of played (oldest first) and reports each shift it finds, most
+// recent first. Unlike a fixed recent-vs-overall threshold, this dates the shift to
+// the series where it happened and reports the level on either side.
+func (s *TrendsService) generateAlerts(played []models.SeriesResult) []models.TrendAlert {
 	var alerts []models.TrendAlert
 
-	// Check if recent sample is too small
-	if recent.Matches < 2 {
+	// Check if the sample is too small to split
+	if len(played) < 2*changePointMinSegment {
 		alerts = append(alerts, models.TrendAlert{
 			Type:     models.AlertConsistency,
 			Severity: models.AlertLow,
-			Message:  "Insufficient recent data for trend analysis",
-			Context:  fmt.Sprintf("Only %d recent match(es) available", recent.Matches),
+			Message:  "Insufficient data for trend analysis",
+			Context:  fmt.Sprintf("Only %d match(es) available - at least %d are needed to detect a shift", len(played), 2*changePointMinSegment),
 		})
 		return alerts
 	}
 
-	// Analyze win rate change
-	winRateChange := (recent.WinRate - overall.WinRate) / overall.WinRate
-	winRateChangePct := winRateChange * 100
-
-	if math.Abs(winRateChangePct) >= 15 {
-		severity := s.determineSeverity(math.Abs(winRateChangePct))
+	// Win rate shifts
+	wins := make([]float64, len(played))
+	for i, result := range played {
+		if result.Won {
+			wins[i] = 1
+		}
+	}
+	for _, shift := range detectShifts(models.MetricWinRate, wins, played) {
+		change := (shift.After - shift.Before) * 100
 		alertType := models.AlertPositiveShift
-		direction := "increased"
-		context := "Team is performing significantly better recently"
-
-		if winRateChangePct < 0 {
+		direction := "rose"
+		context := "Team is performing significantly better"
+		if change < 0 {
 			alertType = models.AlertNegativeShift
-			direction = "decreased"
-			context = "Team is underperforming in recent matches"
+			direction = "fell"
+			context = "Team is underperforming"
 		}
 
 		alerts = append(alerts, models.TrendAlert{
 			Type:     alertType,
-			Severity: severity,
-			Message:  fmt.Sprintf("Win rate %s by %.0f%% in recent matches", direction, math.Abs(winRateChangePct)),
-			Context:  context,
+			Severity: s.determineSeverity(math.Abs(change)),
+			Message: fmt.Sprintf("Win rate %s from %.0f%% to %.0f%% starting %s vs %s",
+				direction, shift.Before*100, shift.After*100, shift.At.Format("2006-01-02"), shift.Opponent),
+			Context: fmt.Sprintf("%s since this series (%d series before, %d after, p=%.3f)",
+				context, shift.BeforeSeries, shift.AfterSeries, shift.PValue),
+			Shift: shift,
 		})
 	}
 
-	// Analyze K/D ratio change
-	kdChange := (recent.KDRatio - overall.KDRatio) / overall.KDRatio
-	kdChangePct := kdChange * 100
-
-	if math.Abs(kdChangePct) >= 10 {
-		severity := s.determineSeverity(math.Abs(kdChangePct))
+	// K/D shifts, over the series with downloaded stats
+	var statsPlayed []models.SeriesResult
+	var kd []float64
+	for _, result := range played {
+		if result.HasStats && result.Deaths > 0 {
+			statsPlayed = append(statsPlayed, result)
+			kd = append(kd, float64(result.Kills)/float64(result.Deaths))
+		}
+	}
+	for _, shift := range detectShifts(models.MetricKDRatio, kd, statsPlayed) {
+		if shift.Before <= 0 {
+			continue
+		}
+		change := (shift.After - shift.Before) / shift.Before * 100
 		direction := "improved"
 		context := "More aggressive or efficient plays"
-
-		if kdChangePct < 0 {
+		if change < 0 {
 			direction = "declined"
-			context = "Less efficient or more deaths recently"
+			context = "Less efficient or more deaths"
 		}
 
 		alerts = append(alerts, models.TrendAlert{
 			Type:     models.AlertPlaystyleChange,
-			Severity: severity,
-			Message:  fmt.Sprintf("K/D ratio %s by %.0f%%", direction, math.Abs(kdChangePct)),
-			Context:  context,
+			Severity: s.determineSeverity(math.Abs(change)),
+			Message: fmt.Sprintf("K/D %s from %.2f to %.2f starting %s vs %s",
+				direction, shift.Before, shift.After, shift.At.Format("2006-01-02"), shift.Opponent),
+			Context: fmt.Sprintf("%s since this series (%d series before, %d after, p=%.3f)",
+				context, shift.BeforeSeries, shift.AfterSeries, shift.PValue),
+			Shift: shift,
 		})
 	}
 
 	// Consistency check
-	if len(alerts) == 0 && recent.Matches >= 3 {
+	if len(alerts) == 0 {
 		alerts = append(alerts, models.TrendAlert{
 			Type:     models.AlertConsistency,
 			Severity: models.AlertLow,
 			Message:  "Performance remains consistent",
-			Context:  "No significant changes detected in recent matches",
+			Context:  fmt.Sprintf("No significant shift detected across %d matches", len(played)),
 		})
+		return alerts
 	}
 
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].Shift.At.After(alerts[j].Shift.At)
+	})
 	return alerts
 }
 
+// rosterAlert flags a lineup that differs from the baseline period's, from the
+// lineups stored for the played series. Series never synced have none.
+func (s *TrendsService) rosterAlert(played []models.SeriesResult, baselineEnd time.Time) *models.TrendAlert {
+	if s.pgRepo == nil || len(played) == 0 {
+		return nil
+	}
+	lineups, err := s.pgRepo.ListSeriesLineups(seriesIDs(played, false))
+	if err != nil {
+		fmt.Printf("[WARN] Failed to load lineups: %v\n", err)
+		return nil
+	}
+
+	teamBySeries := make(map[string]string, len(played))
+	for _, result := range played {
+		teamBySeries[result.SeriesID] = result.TeamID
+	}
+	return rosterChangeAlert(teamLineups(lineups, func(seriesID string) string { return teamBySeries[seriesID] }), baselineEnd)
+}
+
+// detectShifts locates change points in values, where values[i] belongs to played[i]
+func detectShifts(metric string, values []float64, played []models.SeriesResult) []*models.TrendShift {
+	points := appstats.DetectChangePoints(values, changePointMinSegment, changePointAlpha, changePointRounds, permutationSeed)
+
+	shifts := make([]*models.TrendShift, len(points))
+	for i, point := range points {
+		start, end := 0, len(values)
+		if i > 0 {
+			start = points[i-1].Index
+		}
+		if i < len(points)-1 {
+			end = points[i+1].Index
+		}
+
+		first := played[point.Index]
+		shifts[i] = &models.TrendShift{
+			Metric:       metric,
+			SeriesID:     first.SeriesID,
+			Opponent:     first.Opponent,
+			At:           first.StartTime,
+			Before:       point.Before,
+			After:        point.After,
+			BeforeSeries: point.Index - start,
+			AfterSeries:  end - point.Index,
+			PValue:       point.PValue,
+		}
+	}
+	return shifts
+}
+
 func (s *TrendsService) determineSeverity(changePct float64) models.AlertSeverity {
 	if changePct >= 25 {
 		return models.AlertHigh
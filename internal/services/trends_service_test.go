@@ -0,0 +1,142 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/esports-scouting-backend/internal/models"
+)
+
+func TestPeriodStatsDoesNotOverlap(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	recentFrom := now.Add(-recentPeriod)
+	series := []models.SeriesResult{
+		{StartTime: now.AddDate(0, 0, -1), Won: true, HasStats: true, Kills: 60, Deaths: 40},
+		{StartTime: now.AddDate(0, 0, -3), Won: true, HasStats: true, Kills: 50, Deaths: 50},
+		{StartTime: now.AddDate(0, 0, -10), Won: false, HasStats: true, Kills: 40, Deaths: 60},
+		{StartTime: now.AddDate(0, -1, 0), Won: false},
+		{StartTime: now.AddDate(0, -2, 0), Won: true},
+		{StartTime: now.AddDate(0, 0, 2), Won: false}, // scheduled, not played yet
+	}
+
+	recent := periodStats(series, recentFrom, now)
+	overall := periodStats(series, models.Last3Months.Cutoff(now), recentFrom)
+
+	if recent.Matches != 2 || recent.WinRate != 1 {
+		t.Errorf("recent = %d matches at %.2f, want 2 at 1.00", recent.Matches, recent.WinRate)
+	}
+	if recent.KDRatio != 110.0/90.0 {
+		t.Errorf("recent K/D = %.3f, want %.3f", recent.KDRatio, 110.0/90.0)
+	}
+	if overall.Matches != 3 {
+		t.Errorf("overall matches = %d, want 3 (recent series excluded)", overall.Matches)
+	}
+	if overall.KDRatio != 40.0/60.0 {
+		t.Errorf("overall K/D = %.3f, want K/D from series with stats only", overall.KDRatio)
+	}
+}
+
+func TestBuildTrendSeries(t *testing.T) {
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -28)
+
+	// One series every two days: wins for the first two weeks, losses after
+	var series []models.SeriesResult
+	for day := 27; day >= 1; day -= 2 {
+		series = append(series, models.SeriesResult{
+			SeriesID:  time.Duration(day).String(),
+			StartTime: to.AddDate(0, 0, -day),
+			Won:       day > 14,
+		})
+	}
+	series = append(series, models.SeriesResult{StartTime: from.AddDate(0, 0, -5), Won: true}) // before the range
+
+	trends := buildTrendSeries(series, from, to, []int{7, 14}, 7)
+
+	if len(trends.Series) != 14 {
+		t.Fatalf("series = %d, want 14 inside the range", len(trends.Series))
+	}
+	if !trends.Series[0].StartTime.Before(trends.Series[len(trends.Series)-1].StartTime) {
+		t.Error("series should be ordered oldest first")
+	}
+	if len(trends.Windows) != 2 {
+		t.Fatalf("windows = %d, want 2", len(trends.Windows))
+	}
+
+	weekly := trends.Windows[0]
+	if weekly.Days != 7 || len(weekly.Points) != 4 {
+		t.Fatalf("7-day window has %d points, want 4", len(weekly.Points))
+	}
+	first, last := weekly.Points[0], weekly.Points[len(weekly.Points)-1]
+	if !first.End.Before(last.End) || !last.End.Equal(to) {
+		t.Errorf("points should run oldest first and end at %v", to)
+	}
+	if first.WinRate != 1 || last.WinRate != 0 {
+		t.Errorf("form should fall from 100%% to 0%%, got %.2f -> %.2f", first.WinRate, last.WinRate)
+	}
+	for _, point := range weekly.Points {
+		if point.WinRateInterval.Lower > point.WinRate || point.WinRateInterval.Upper < point.WinRate {
+			t.Errorf("win rate %.2f outside interval %+v", point.WinRate, point.WinRateInterval)
+		}
+	}
+
+	for _, point := range trends.Windows[1].Points {
+		if point.Start.Before(from) {
+			t.Errorf("14-day point starts at %v, before the range", point.Start)
+		}
+	}
+}
+
+func TestGenerateAlertsDatesShifts(t *testing.T) {
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	played := func(results string) []models.SeriesResult {
+		var series []models.SeriesResult
+		for i, r := range results {
+			series = append(series, models.SeriesResult{
+				SeriesID:  string(rune('a' + i)),
+				StartTime: start.AddDate(0, 0, 3*i),
+				Opponent:  "Opponent",
+				Won:       r == 'W',
+			})
+		}
+		return series
+	}
+
+	tests := []struct {
+		name       string
+		results    string
+		wantType   models.AlertType
+		wantSeries string
+	}{
+		{"Late collapse", "WWLWWWLWWWLWWWLLLLL", models.AlertNegativeShift, "o"},
+		{"Turnaround", "LLWLLLWLLLWWWLWWWWWW", models.AlertPositiveShift, "k"},
+		{"Steady", "WLWLWLWLWLWL", models.AlertConsistency, ""},
+		{"Too few matches", "WWL", models.AlertConsistency, ""},
+	}
+
+	s := &TrendsService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := s.generateAlerts(played(tt.results))
+			if len(alerts) == 0 {
+				t.Fatal("expected at least one alert")
+			}
+			alert := alerts[0]
+			if alert.Type != tt.wantType {
+				t.Fatalf("alert type = %s (%s), want %s", alert.Type, alert.Message, tt.wantType)
+			}
+			if tt.wantSeries == "" {
+				if alert.Shift != nil {
+					t.Errorf("unexpected shift %+v", alert.Shift)
+				}
+				return
+			}
+			if alert.Shift == nil || alert.Shift.SeriesID != tt.wantSeries {
+				t.Fatalf("shift = %+v, want it at series %s", alert.Shift, tt.wantSeries)
+			}
+			if alert.Shift.BeforeSeries+alert.Shift.AfterSeries != len(tt.results) {
+				t.Errorf("segments %d + %d don't cover %d series", alert.Shift.BeforeSeries, alert.Shift.AfterSeries, len(tt.results))
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+// Sigmoid is the logistic function
+func Sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+// Logit is the inverse of Sigmoid. p is clamped away from 0 and 1.
+func Logit(p float64) float64 {
+	p = math.Min(math.Max(p, 1e-6), 1-1e-6)
+	return math.Log(p / (1 - p))
+}
+
+// Brier is the mean squared error of probabilistic predictions. Always
+// predicting 50% scores 0.25; lower is better.
+func Brier(predictions []float64, outcomes []bool) float64 {
+	if len(predictions) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i, p := range predictions {
+		sum += (p - outcome(outcomes[i])) * (p - outcome(outcomes[i]))
+	}
+	return sum / float64(len(predictions))
+}
+
+// LogLoss is the mean negative log-likelihood of the outcomes
+func LogLoss(predictions []float64, outcomes []bool) float64 {
+	if len(predictions) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i, p := range predictions {
+		p = math.Min(math.Max(p, 1e-6), 1-1e-6)
+		if outcomes[i] {
+			sum -= math.Log(p)
+		} else {
+			sum -= math.Log(1 - p)
+		}
+	}
+	return sum / float64(len(predictions))
+}
+
+// CalibrationBin summarises predictions that fell into one probability bucket
+type CalibrationBin struct {
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	Count         int     `json:"count"`
+	MeanPredicted float64 `json:"meanPredicted"`
+	ObservedRate  float64 `json:"observedRate"`
+}
+
+// Calibration buckets predictions into equal-width bins. A well calibrated
+// model has ObservedRate close to MeanPredicted in every bin. Empty bins are
+// omitted.
+func Calibration(predictions []float64, outcomes []bool, bins int) []CalibrationBin {
+	if bins <= 0 {
+		bins = 10
+	}
+
+	all := make([]CalibrationBin, bins)
+	for i := range all {
+		all[i].Lower = float64(i) / float64(bins)
+		all[i].Upper = float64(i+1) / float64(bins)
+	}
+
+	for i, p := range predictions {
+		idx := int(p * float64(bins))
+		if idx >= bins {
+			idx = bins - 1
+		}
+		if idx < 0 {
+			idx = 0
+		}
+		all[idx].Count++
+		all[idx].MeanPredicted += p
+		all[idx].ObservedRate += outcome(outcomes[i])
+	}
+
+	var result []CalibrationBin
+	for _, bin := range all {
+		if bin.Count == 0 {
+			continue
+		}
+		bin.MeanPredicted /= float64(bin.Count)
+		bin.ObservedRate /= float64(bin.Count)
+		result = append(result, bin)
+	}
+	return result
+}
+
+// FitLogistic fits a logistic regression without intercept by Newton's method.
+// Each row of features is one observation; ridge is a small L2 penalty that
+// keeps the fit stable when the data are (nearly) separable.
+func FitLogistic(features [][]float64, outcomes []bool, ridge float64) ([]float64, error) {
+	if len(features) == 0 {
+		return nil, errors.New("no observations to fit")
+	}
+	k := len(features[0])
+	weights := make([]float64, k)
+
+	for iter := 0; iter < 50; iter++ {
+		gradient := make([]float64, k)
+		hessian := make([][]float64, k)
+		for i := range hessian {
+			hessian[i] = make([]float64, k)
+			hessian[i][i] = ridge
+			gradient[i] = -ridge * weights[i]
+		}
+
+		for n, x := range features {
+			p := Sigmoid(dot(weights, x))
+			residual := outcome(outcomes[n]) - p
+			for i := 0; i < k; i++ {
+				gradient[i] += residual * x[i]
+				for j := 0; j < k; j++ {
+					hessian[i][j] += p * (1 - p) * x[i] * x[j]
+				}
+			}
+		}
+
+		step, err := solve(hessian, gradient)
+		if err != nil {
+			return nil, err
+		}
+
+		maxStep := 0.0
+		for i := range weights {
+			weights[i] += step[i]
+			maxStep = math.Max(maxStep, math.Abs(step[i]))
+		}
+		if maxStep < 1e-8 {
+			break
+		}
+	}
+
+	return weights, nil
+}
+
+func outcome(won bool) float64 {
+	if won {
+		return 1
+	}
+	return 0
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// solve returns x with a·x = b using Gaussian elimination with partial pivoting
+func solve(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range a {
+		m[i] = append(append([]float64{}, a[i]...), b[i])
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-12 {
+			return nil, errors.New("singular matrix")
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := m[row][col] / m[col][col]
+			for j := col; j <= n; j++ {
+				m[row][j] -= factor * m[col][j]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := m[row][n]
+		for j := row + 1; j < n; j++ {
+			sum -= m[row][j] * x[j]
+		}
+		x[row] = sum / m[row][row]
+	}
+	return x, nil
+}
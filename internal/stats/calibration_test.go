@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBrier(t *testing.T) {
+	if b := Brier([]float64{0.5, 0.5}, []bool{true, false}); b != 0.25 {
+		t.Errorf("coin flip Brier = %.3f, want 0.25", b)
+	}
+	if b := Brier([]float64{1, 0}, []bool{true, false}); b != 0 {
+		t.Errorf("perfect Brier = %.3f, want 0", b)
+	}
+}
+
+func TestCalibration(t *testing.T) {
+	bins := Calibration([]float64{0.05, 0.15, 0.12, 0.95}, []bool{false, true, false, true}, 10)
+	if len(bins) != 3 {
+		t.Fatalf("got %d non-empty bins, want 3", len(bins))
+	}
+	if bins[1].Count != 2 || bins[1].ObservedRate != 0.5 {
+		t.Errorf("second bin = %+v, want 2 predictions with 50%% observed", bins[1])
+	}
+}
+
+func TestFitLogisticRecoversWeights(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	var features [][]float64
+	var outcomes []bool
+	for i := 0; i < 5000; i++ {
+		x1, x2 := rng.NormFloat64(), rng.NormFloat64()
+		features = append(features, []float64{x1, x2})
+		outcomes = append(outcomes, rng.Float64() < Sigmoid(0.8*x1-0.5*x2))
+	}
+
+	weights, err := FitLogistic(features, outcomes, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(weights[0]-0.8) > 0.1 || math.Abs(weights[1]+0.5) > 0.1 {
+		t.Errorf("weights = %.3f, want about [0.8 -0.5]", weights)
+	}
+}
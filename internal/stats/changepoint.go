@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ChangePoint is a shift in the mean level of a sequence
+type ChangePoint struct {
+	Index  int     // first observation at the new level
+	Before float64 // mean from the previous change point (or the start) up to Index
+	After  float64 // mean from Index up to the next change point (or the end)
+	PValue float64
+}
+
+// DetectChangePoints finds shifts in the mean of xs by binary segmentation. In each
+// segment the candidate split is the peak of the standardised CUSUM of deviations
+// from the segment mean; it is kept when a permutation test of that peak gives p <= alpha, and the two
+// halves are then searched in turn. Both sides of every split hold at least minSegment
+// observations. Results are ordered by Index.
+func DetectChangePoints(xs []float64, minSegment int, alpha float64, rounds int, seed int64) []ChangePoint {
+	if minSegment < 1 {
+		minSegment = 1
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	var points []ChangePoint
+	var search func(lo, hi int)
+	search = func(lo, hi int) {
+		segment := xs[lo:hi]
+		k, peak := cusumPeak(segment, minSegment)
+		if k < 0 || peak == 0 {
+			return
+		}
+
+		shuffled := append([]float64(nil), segment...)
+		extreme := 0
+		for i := 0; i < rounds; i++ {
+			rng.Shuffle(len(shuffled), func(x, y int) { shuffled[x], shuffled[y] = shuffled[y], shuffled[x] })
+			if _, p := cusumPeak(shuffled, minSegment); p >= peak-1e-12 {
+				extreme++
+			}
+		}
+		p := float64(extreme+1) / float64(rounds+1)
+		if p > alpha {
+			return
+		}
+
+		points = append(points, ChangePoint{Index: lo + k, PValue: p})
+		search(lo, lo+k)
+		search(lo+k, hi)
+	}
+	search(0, len(xs))
+
+	sort.Slice(points, func(i, j int) bool { return points[i].Index < points[j].Index })
+
+	// Levels are measured between neighbouring change points, not the segment
+	// that was being searched when each one was found
+	for i := range points {
+		start, end := 0, len(xs)
+		if i > 0 {
+			start = points[i-1].Index
+		}
+		if i < len(points)-1 {
+			end = points[i+1].Index
+		}
+		points[i].Before = mean(xs[start:points[i].Index])
+		points[i].After = mean(xs[points[i].Index:end])
+	}
+	return points
+}
+
+// cusumPeak returns the split k (first index of the second part) maximising the
+// absolute cumulative sum of deviations from the mean, scaled by sqrt(k(n-k)/n) so
+// shifts near either end are not drowned out by the middle. k is -1 when the
+// sequence is too short to leave minSegment observations on each side.
+func cusumPeak(xs []float64, minSegment int) (k int, peak float64) {
+	n := len(xs)
+	if n < 2*minSegment {
+		return -1, 0
+	}
+
+	m := mean(xs)
+	k = -1
+	sum := 0.0
+	for i := 0; i < n-minSegment; i++ {
+		sum += xs[i] - m
+		if i+1 < minSegment {
+			continue
+		}
+		left := float64(i + 1)
+		stat := math.Abs(sum) / math.Sqrt(left*(float64(n)-left)/float64(n))
+		if stat > peak {
+			k, peak = i+1, stat
+		}
+	}
+	return k, peak
+}
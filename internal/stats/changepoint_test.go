@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDetectChangePoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		xs          []float64
+		wantIndexes []int
+	}{
+		{
+			name:        "Losing run turns into a winning one",
+			xs:          []float64{0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1, 1, 1, 0, 1, 1, 1, 1, 1, 1},
+			wantIndexes: []int{10},
+		},
+		{
+			name:        "Late collapse",
+			xs:          []float64{1, 1, 0, 1, 1, 1, 0, 1, 1, 1, 0, 1, 1, 1, 0, 0, 0, 0, 0},
+			wantIndexes: []int{14},
+		},
+		{
+			name:        "Rise then collapse",
+			xs:          append(append(repeat(0, 12), repeat(1, 12)...), repeat(0, 12)...),
+			wantIndexes: []int{12, 24},
+		},
+		{
+			name: "Alternating results have no shift",
+			xs:   []float64{1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0},
+		},
+		{
+			name: "Too short to split",
+			xs:   []float64{0, 0, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := DetectChangePoints(tt.xs, 3, 0.05, 2000, 42)
+			if len(points) != len(tt.wantIndexes) {
+				t.Fatalf("got %d change points %+v, want indexes %v", len(points), points, tt.wantIndexes)
+			}
+			for i, point := range points {
+				if point.Index != tt.wantIndexes[i] {
+					t.Errorf("change point %d at %d, want %d", i, point.Index, tt.wantIndexes[i])
+				}
+				if point.PValue > 0.05 {
+					t.Errorf("change point %d has p=%.3f above alpha", i, point.PValue)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectChangePointsLevels(t *testing.T) {
+	xs := []float64{1.0, 1.1, 0.9, 1.0, 1.0, 1.6, 1.5, 1.7, 1.6, 1.6}
+	points := DetectChangePoints(xs, 3, 0.05, 2000, 42)
+	if len(points) != 1 {
+		t.Fatalf("got %d change points, want 1", len(points))
+	}
+	if math.Abs(points[0].Before-1.0) > 1e-9 || math.Abs(points[0].After-1.6) > 1e-9 {
+		t.Errorf("levels = %.2f -> %.2f, want 1.00 -> 1.60", points[0].Before, points[0].After)
+	}
+}
+
+func repeat(x float64, n int) []float64 {
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = x
+	}
+	return xs
+}
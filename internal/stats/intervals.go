@@ -0,0 +1,61 @@
+// Package stats holds the small amount of statistics the scouting services need:
+// confidence intervals for rates and ratios, and significance tests.
+package stats
+
+import "math"
+
+// Z95 is the two-sided standard normal quantile for 95% coverage
+const Z95 = 1.959963984540054
+
+// Wilson returns the Wilson score interval for a binomial proportion
+// (e.g. series won out of series played). Unlike the normal approximation it
+// stays inside [0, 1] and behaves sensibly for small samples and 0%/100% rates.
+func Wilson(successes, n int, z float64) (lower, upper float64) {
+	if n <= 0 {
+		return 0, 1
+	}
+	if successes < 0 {
+		successes = 0
+	}
+	if successes > n {
+		successes = n
+	}
+
+	nf := float64(n)
+	p := float64(successes) / nf
+	z2 := z * z
+
+	denom := 1 + z2/nf
+	center := (p + z2/(2*nf)) / denom
+	margin := z * math.Sqrt(p*(1-p)/nf+z2/(4*nf*nf)) / denom
+
+	lower, upper = math.Max(0, center-margin), math.Min(1, center+margin)
+	// The bounds are exact at the extremes; avoid rounding noise like 5e-17
+	if successes == 0 {
+		lower = 0
+	}
+	if successes == n {
+		upper = 1
+	}
+	return lower, upper
+}
+
+// RateRatio returns an interval for the ratio a/b of two Poisson counts
+// (e.g. kills/deaths) using the normal approximation on the log scale.
+// A 0.5 continuity correction is applied when either count is zero.
+func RateRatio(a, b int, z float64) (lower, upper float64) {
+	if a < 0 || b < 0 || (a == 0 && b == 0) {
+		return 0, math.Inf(1)
+	}
+
+	af, bf := float64(a), float64(b)
+	if a == 0 || b == 0 {
+		af += 0.5
+		bf += 0.5
+	}
+
+	logRatio := math.Log(af / bf)
+	se := math.Sqrt(1/af + 1/bf)
+
+	return math.Exp(logRatio - z*se), math.Exp(logRatio + z*se)
+}
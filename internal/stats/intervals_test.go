@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWilson(t *testing.T) {
+	tests := []struct {
+		name         string
+		successes, n int
+		lower, upper float64
+	}{
+		{"7 of 10", 7, 10, 0.3968, 0.8922},
+		{"0 of 10", 0, 10, 0, 0.2775},
+		{"10 of 10", 10, 10, 0.7225, 1},
+		{"50 of 100", 50, 100, 0.4038, 0.5962},
+		{"no data", 0, 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper := Wilson(tt.successes, tt.n, Z95)
+			if math.Abs(lower-tt.lower) > 0.0005 || math.Abs(upper-tt.upper) > 0.0005 {
+				t.Errorf("Wilson(%d, %d) = (%.4f, %.4f), want (%.4f, %.4f)", tt.successes, tt.n, lower, upper, tt.lower, tt.upper)
+			}
+		})
+	}
+}
+
+func TestWilsonNarrowsWithSampleSize(t *testing.T) {
+	l1, u1 := Wilson(5, 10, Z95)
+	l2, u2 := Wilson(50, 100, Z95)
+	if u2-l2 >= u1-l1 {
+		t.Errorf("expected narrower interval for larger sample: %.3f vs %.3f", u2-l2, u1-l1)
+	}
+}
+
+func TestRateRatio(t *testing.T) {
+	lower, upper := RateRatio(200, 160, Z95)
+	if lower >= 1.25 || upper <= 1.25 {
+		t.Errorf("interval (%.3f, %.3f) should contain the point estimate 1.25", lower, upper)
+	}
+	if math.Abs(lower-1.0154) > 0.001 || math.Abs(upper-1.5388) > 0.001 {
+		t.Errorf("RateRatio(200, 160) = (%.4f, %.4f), want (1.0154, 1.5388)", lower, upper)
+	}
+
+	lower, upper = RateRatio(10, 0, Z95)
+	if math.IsInf(upper, 0) || lower <= 1 {
+		t.Errorf("zero deaths should still give a finite interval above 1, got (%.3f, %.3f)", lower, upper)
+	}
+}
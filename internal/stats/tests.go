@@ -0,0 +1,199 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+)
+
+// NormalCDF is the standard normal cumulative distribution function
+func NormalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// TwoProportionTest runs a two-sided pooled z-test for the difference between
+// two binomial proportions x1/n1 and x2/n2. It returns the z statistic and p-value.
+// With no data or no variance (both 0% or both 100%) the p-value is 1.
+func TwoProportionTest(x1, n1, x2, n2 int) (z, p float64) {
+	if n1 <= 0 || n2 <= 0 {
+		return 0, 1
+	}
+
+	p1 := float64(x1) / float64(n1)
+	p2 := float64(x2) / float64(n2)
+	pooled := float64(x1+x2) / float64(n1+n2)
+
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return 0, 1
+	}
+
+	z = (p1 - p2) / se
+	return z, 2 * (1 - NormalCDF(math.Abs(z)))
+}
+
+// FisherExactTest returns the two-sided p-value of Fisher's exact test on the
+// 2x2 table [x1, n1-x1; x2, n2-x2]. It is exact for any sample size, which makes
+// it the right choice when the z-test's normal approximation breaks down.
+func FisherExactTest(x1, n1, x2, n2 int) float64 {
+	if n1 <= 0 || n2 <= 0 {
+		return 1
+	}
+
+	total := n1 + n2
+	successes := x1 + x2
+	observed := hypergeometric(x1, n1, successes, total)
+
+	lo := max(0, successes-n2)
+	hi := min(n1, successes)
+	p := 0.0
+	for k := lo; k <= hi; k++ {
+		// Relative tolerance guards against floating point noise on tied tables
+		if prob := hypergeometric(k, n1, successes, total); prob <= observed*(1+1e-7) {
+			p += prob
+		}
+	}
+	return math.Min(1, p)
+}
+
+// ProportionTest compares two win rates. It uses Fisher's exact test when any
+// expected cell count is below 5 and the pooled z-test otherwise, and returns
+// the p-value together with the name of the test used.
+func ProportionTest(x1, n1, x2, n2 int) (p float64, test string) {
+	if n1 <= 0 || n2 <= 0 {
+		return 1, "none"
+	}
+
+	pooled := float64(x1+x2) / float64(n1+n2)
+	minExpected := math.Min(
+		math.Min(pooled*float64(n1), (1-pooled)*float64(n1)),
+		math.Min(pooled*float64(n2), (1-pooled)*float64(n2)),
+	)
+	if minExpected < 5 {
+		return FisherExactTest(x1, n1, x2, n2), "fisher-exact"
+	}
+
+	_, p = TwoProportionTest(x1, n1, x2, n2)
+	return p, "two-proportion-z"
+}
+
+// hypergeometric is P(X = k) when drawing n items from a population of total
+// containing successes successes
+func hypergeometric(k, n, successes, total int) float64 {
+	return math.Exp(logChoose(successes, k) + logChoose(total-successes, n-k) - logChoose(total, n))
+}
+
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// CohensH is the effect size for a difference between two proportions.
+// Rough guide: 0.2 small, 0.5 medium, 0.8 large.
+func CohensH(p1, p2 float64) float64 {
+	return 2*math.Asin(math.Sqrt(p1)) - 2*math.Asin(math.Sqrt(p2))
+}
+
+// CohensD is the standardised difference in means between two samples using
+// the pooled standard deviation. It returns 0 when there is no variance.
+func CohensD(a, b []float64) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+
+	ma, mb := mean(a), mean(b)
+	pooledVar := (variance(a, ma)*float64(len(a)-1) + variance(b, mb)*float64(len(b)-1)) / float64(len(a)+len(b)-2)
+	if pooledVar == 0 {
+		return 0
+	}
+	return (ma - mb) / math.Sqrt(pooledVar)
+}
+
+// PermutationTest runs a two-sided permutation test for a difference in means
+// between samples a and b. The labels are shuffled rounds times with a fixed
+// seed so the same inputs always give the same p-value. It returns the observed
+// difference mean(a) - mean(b) and the p-value.
+func PermutationTest(a, b []float64, rounds int, seed int64) (diff, p float64) {
+	if len(a) == 0 || len(b) == 0 || rounds <= 0 {
+		return 0, 1
+	}
+
+	observed := mean(a) - mean(b)
+
+	pool := make([]float64, 0, len(a)+len(b))
+	pool = append(pool, a...)
+	pool = append(pool, b...)
+
+	rng := rand.New(rand.NewSource(seed))
+	extreme := 0
+	for i := 0; i < rounds; i++ {
+		rng.Shuffle(len(pool), func(x, y int) { pool[x], pool[y] = pool[y], pool[x] })
+		d := mean(pool[:len(a)]) - mean(pool[len(a):])
+		// Small tolerance so ties with the observed value count as extreme
+		if math.Abs(d) >= math.Abs(observed)-1e-12 {
+			extreme++
+		}
+	}
+
+	// Add-one smoothing keeps the p-value away from an impossible exact zero
+	return observed, float64(extreme+1) / float64(rounds+1)
+}
+
+func mean(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// variance is the sample variance of xs around m
+func variance(xs []float64, m float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += (x - m) * (x - m)
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// WeightedPermutationTest is PermutationTest for weighted samples: the statistic is
+// the difference in weighted means, and (value, weight) pairs are shuffled together
+// between the groups. wa and wb must match a and b in length.
+func WeightedPermutationTest(a, wa, b, wb []float64, rounds int, seed int64) (diff, p float64) {
+	if len(a) == 0 || len(b) == 0 || len(a) != len(wa) || len(b) != len(wb) || rounds <= 0 {
+		return 0, 1
+	}
+
+	observed := weightedMean(a, wa) - weightedMean(b, wb)
+
+	pool := append(append([]float64(nil), a...), b...)
+	weights := append(append([]float64(nil), wa...), wb...)
+
+	rng := rand.New(rand.NewSource(seed))
+	extreme := 0
+	for i := 0; i < rounds; i++ {
+		rng.Shuffle(len(pool), func(x, y int) {
+			pool[x], pool[y] = pool[y], pool[x]
+			weights[x], weights[y] = weights[y], weights[x]
+		})
+		d := weightedMean(pool[:len(a)], weights[:len(a)]) - weightedMean(pool[len(a):], weights[len(a):])
+		if math.Abs(d) >= math.Abs(observed)-1e-12 {
+			extreme++
+		}
+	}
+
+	return observed, float64(extreme+1) / float64(rounds+1)
+}
+
+func weightedMean(xs, ws []float64) float64 {
+	var sum, total float64
+	for i, x := range xs {
+		sum += ws[i] * x
+		total += ws[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
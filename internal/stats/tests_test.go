@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTwoProportionTest(t *testing.T) {
+	z, p := TwoProportionTest(45, 60, 25, 60)
+	if math.Abs(z-3.7033) > 0.001 || p > 0.001 {
+		t.Errorf("45/60 vs 25/60 = (z %.4f, p %.5f), want z 3.7033 and p < 0.001", z, p)
+	}
+
+	if _, p = TwoProportionTest(0, 0, 1, 2); p != 1 {
+		t.Errorf("empty sample p = %.4f, want 1", p)
+	}
+}
+
+func TestFisherExactTest(t *testing.T) {
+	// 3-0 vs 0-3 looks like a 100 point gap but is weak evidence
+	if p := FisherExactTest(3, 3, 0, 3); math.Abs(p-0.1) > 0.0001 {
+		t.Errorf("3/3 vs 0/3 p = %.4f, want 0.1", p)
+	}
+	if p := FisherExactTest(2, 3, 1, 3); math.Abs(p-1) > 0.0001 {
+		t.Errorf("2/3 vs 1/3 p = %.4f, want 1", p)
+	}
+	if p := FisherExactTest(8, 10, 2, 10); math.Abs(p-0.0230) > 0.0005 {
+		t.Errorf("8/10 vs 2/10 p = %.4f, want 0.0230", p)
+	}
+}
+
+func TestProportionTest(t *testing.T) {
+	if _, test := ProportionTest(2, 3, 1, 3); test != "fisher-exact" {
+		t.Errorf("small samples used %s, want fisher-exact", test)
+	}
+	if _, test := ProportionTest(45, 60, 25, 60); test != "two-proportion-z" {
+		t.Errorf("large samples used %s, want two-proportion-z", test)
+	}
+}
+
+func TestCohensH(t *testing.T) {
+	if h := CohensH(0.5, 0.5); h != 0 {
+		t.Errorf("CohensH(0.5, 0.5) = %.4f, want 0", h)
+	}
+	if h := CohensH(0.75, 0.25); math.Abs(h-1.0472) > 0.0005 {
+		t.Errorf("CohensH(0.75, 0.25) = %.4f, want 1.0472", h)
+	}
+}
+
+func TestPermutationTest(t *testing.T) {
+	a := []float64{1.4, 1.6, 1.3, 1.5, 1.7, 1.45, 1.55, 1.6}
+	b := []float64{0.8, 0.9, 0.85, 1.0, 0.7, 0.95, 0.9, 0.8}
+
+	diff, p := PermutationTest(a, b, 2000, 1)
+	if diff <= 0 || p > 0.01 {
+		t.Errorf("clearly separated samples: diff %.3f p %.4f", diff, p)
+	}
+
+	// Deterministic for a fixed seed
+	if _, p2 := PermutationTest(a, b, 2000, 1); p2 != p {
+		t.Errorf("same seed gave different p-values: %.4f vs %.4f", p, p2)
+	}
+
+	_, p = PermutationTest([]float64{1.1, 0.9}, []float64{1.0, 1.05}, 2000, 1)
+	if p < 0.5 {
+		t.Errorf("overlapping samples p = %.4f, expected no significance", p)
+	}
+}
+
+func TestWeightedPermutationTest(t *testing.T) {
+	a := []float64{1.4, 1.6, 1.3, 1.5, 1.7, 1.45, 1.55, 1.6}
+	b := []float64{0.8, 0.9, 0.85, 1.0, 0.7, 0.95, 0.9, 0.8}
+	ones := []float64{1, 1, 1, 1, 1, 1, 1, 1}
+
+	// Equal weights match the unweighted test
+	diff, p := WeightedPermutationTest(a, ones, b, ones, 2000, 1)
+	wantDiff, wantP := PermutationTest(a, b, 2000, 1)
+	if math.Abs(diff-wantDiff) > 1e-9 || p != wantP {
+		t.Errorf("equal weights: diff %.4f p %.4f, want %.4f %.4f", diff, p, wantDiff, wantP)
+	}
+
+	// Weight decides which observations count
+	diff, _ = WeightedPermutationTest([]float64{1, 3}, []float64{1, 0}, []float64{2}, []float64{1}, 100, 1)
+	if math.Abs(diff-(-1)) > 1e-9 {
+		t.Errorf("weighted diff = %.4f, want -1", diff)
+	}
+
+	if _, p := WeightedPermutationTest(a, ones[:2], b, ones, 100, 1); p != 1 {
+		t.Errorf("mismatched weights p = %.4f, want 1", p)
+	}
+}
+
+func TestCohensD(t *testing.T) {
+	d := CohensD([]float64{2, 4, 6}, []float64{1, 3, 5})
+	if math.Abs(d-0.5) > 1e-9 {
+		t.Errorf("CohensD = %.4f, want 0.5", d)
+	}
+	if d := CohensD([]float64{1}, []float64{2, 3}); d != 0 {
+		t.Errorf("CohensD with one observation = %.4f, want 0", d)
+	}
+}